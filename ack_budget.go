@@ -0,0 +1,69 @@
+package fix
+
+import (
+	"context"
+	"errors"
+	"math"
+	"strconv"
+	"time"
+)
+
+// LatencyBudgetExceededError is returned when an order is not acknowledged
+// within its ack latency budget. The order may still reach the server, or
+// already have reached it: its outcome is unknown.
+type LatencyBudgetExceededError struct {
+	ClOrdID string
+	Budget  time.Duration
+	// CancelSent is true if a cancel is chasing the order.
+	CancelSent bool
+}
+
+func (e *LatencyBudgetExceededError) Error() string {
+	return "order " + e.ClOrdID + " not acknowledged within " + e.Budget.String() +
+		", cancel sent: " + strconv.FormatBool(e.CancelSent)
+}
+
+// withAckBudget bounds ctx by budget, if any. The returned function turns the
+// error of a call made with the bounded context into a
+// *LatencyBudgetExceededError when the budget, rather than ctx, expired.
+func withAckBudget(
+	ctx context.Context, budget time.Duration,
+) (context.Context, context.CancelFunc, func(err error) (*LatencyBudgetExceededError, bool)) {
+	if budget <= 0 {
+		return ctx, func() {}, func(error) (*LatencyBudgetExceededError, bool) { return nil, false }
+	}
+
+	callCtx, cancel := context.WithTimeout(ctx, budget)
+	exceeded := func(err error) (*LatencyBudgetExceededError, bool) {
+		if !errors.Is(err, context.DeadlineExceeded) || ctx.Err() != nil {
+			return nil, false
+		}
+		return &LatencyBudgetExceededError{Budget: budget}, true
+	}
+	return callCtx, cancel, exceeded
+}
+
+// chaseCancel cancels an order in the background, retrying cancel rejects
+// which may resolve by themselves until chaseCancelTimeout. An UNKNOWN_ORDER
+// reject is retried too since the order may not have reached the matching
+// engine yet.
+func (c *Client) chaseCancel(symbol, clOrdID, reason string) {
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), chaseCancelTimeout)
+		defer cancel()
+
+		c.l.Infow("Canceling order", "clOrdID", clOrdID, "reason", reason)
+		policy := CancelRetryPolicy{
+			MaxAttempts: math.MaxInt, // Bounded by ctx.
+			Backoff:     100 * time.Millisecond,
+			RetryIf:     RetryUnknownOrder,
+		}
+		err := RetryCancel(ctx, policy, func(ctx context.Context) error {
+			_, err := c.cancelOrder(ctx, symbol, clOrdID)
+			return err
+		})
+		if err != nil {
+			c.l.Warnw("Failed to cancel order", "clOrdID", clOrdID, "reason", reason, "error", err)
+		}
+	}()
+}
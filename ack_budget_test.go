@@ -0,0 +1,59 @@
+package fix
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/quickfixgo/enum"
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/tag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithAckBudget(t *testing.T) {
+	ctx, cancel, exceeded := withAckBudget(context.Background(), time.Millisecond)
+	defer cancel()
+	<-ctx.Done()
+
+	e, ok := exceeded(ctx.Err())
+	require.True(t, ok)
+	assert.Equal(t, time.Millisecond, e.Budget)
+
+	_, ok = exceeded(errors.New("rejected"))
+	assert.False(t, ok)
+
+	// The caller's own deadline is not the budget's fault.
+	parent, cancelParent := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancelParent()
+	ctx, cancel, exceeded = withAckBudget(parent, time.Hour)
+	defer cancel()
+	<-ctx.Done()
+
+	_, ok = exceeded(ctx.Err())
+	assert.False(t, ok)
+}
+
+func TestChaseCancelRetriesUnknownOrder(t *testing.T) {
+	var orders lateOrders
+	canceled := make(chan string, 1)
+	c := NewWithCaller(CallerFunc(func(_ context.Context, id string, msg *quickfix.Message) (*quickfix.Message, error) {
+		resp := orders.cancel(id, msg)
+		if resp.IsMsgTypeOf(string(enum.MsgType_EXECUTION_REPORT)) {
+			orig, _ := msg.Body.GetString(tag.OrigClOrdID)
+			canceled <- orig
+		}
+		return resp, nil
+	}))
+
+	c.chaseCancel("BTCUSDT", "a", "test")
+	select {
+	case orig := <-canceled:
+		assert.Equal(t, "a", orig)
+	case <-time.After(time.Second):
+		t.Fatal("order was not canceled")
+	}
+	assert.Equal(t, 2, orders.attemptsOf("a"))
+}
@@ -0,0 +1,203 @@
+package fix
+
+import (
+	"sync"
+	"time"
+
+	"github.com/quickfixgo/enum"
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/tag"
+)
+
+// AlertKind identifies the anomaly an Alert is about.
+type AlertKind string
+
+const (
+	// AlertRejectStorm is raised when many orders or messages are rejected
+	// in a short time.
+	AlertRejectStorm AlertKind = "reject_storm"
+	// AlertReconnectLoop is raised when the session logs on again and again.
+	AlertReconnectLoop AlertKind = "reconnect_loop"
+	// AlertHeartbeatLoss is raised when the server went silent for longer
+	// than the heartbeat interval and the session had to probe it with a
+	// TestRequest<1>.
+	AlertHeartbeatLoss AlertKind = "heartbeat_loss"
+	// AlertSequenceGap is raised when a ResendRequest<2> is sent for missing
+	// inbound messages.
+	AlertSequenceGap AlertKind = "sequence_gap"
+	// AlertLatencySpike is raised when a call is answered slower than
+	// tolerated.
+	AlertLatencySpike AlertKind = "latency_spike"
+)
+
+// Alert is the payload handed to an AlertSink.
+type Alert struct {
+	Kind    AlertKind
+	At      time.Time
+	Message string
+	Fields  map[string]any // Kind specific details, e.g. "count" or "latency".
+}
+
+// AlertSink receives the anomalies detected by the client. Alert is called
+// from its own goroutine and should not block for long.
+type AlertSink interface {
+	Alert(a Alert)
+}
+
+// AlertSinkFunc adapts a function to an AlertSink.
+type AlertSinkFunc func(a Alert)
+
+func (f AlertSinkFunc) Alert(a Alert) {
+	f(a)
+}
+
+// AlertThresholds tunes anomaly detection. A zero count or duration disables
+// the matching alert.
+type AlertThresholds struct {
+	RejectStormCount  int // Rejects within RejectStormWindow raising an alert.
+	RejectStormWindow time.Duration
+
+	ReconnectLoopCount  int // Logons within ReconnectLoopWindow raising an alert.
+	ReconnectLoopWindow time.Duration
+
+	LatencySpike time.Duration // Call latency raising an alert.
+
+	HeartbeatLoss bool
+	SequenceGap   bool
+}
+
+// DefaultAlertThresholds returns thresholds enabling every alert.
+func DefaultAlertThresholds() AlertThresholds {
+	return AlertThresholds{
+		RejectStormCount:    20,
+		RejectStormWindow:   10 * time.Second,
+		ReconnectLoopCount:  5,
+		ReconnectLoopWindow: 5 * time.Minute,
+		LatencySpike:        time.Second,
+		HeartbeatLoss:       true,
+		SequenceGap:         true,
+	}
+}
+
+// WithAlertSinkOpt reports the anomalies detected with thresholds to sink.
+func WithAlertSinkOpt(sink AlertSink, thresholds AlertThresholds) NewClientOption {
+	return func(o *Options) {
+		o.alertSink = sink
+		o.alertThresholds = thresholds
+	}
+}
+
+// alertCounter counts events in a sliding window.
+type alertCounter struct {
+	mu     sync.Mutex
+	events []time.Time
+}
+
+// add records an event at now and reports whether count events happened
+// within window. The counter is reset when it does, so that a storm raises
+// a single alert per window.
+func (ac *alertCounter) add(now time.Time, count int, window time.Duration) bool {
+	ac.mu.Lock()
+	defer ac.mu.Unlock()
+
+	cutoff := now.Add(-window)
+	kept := ac.events[:0]
+	for _, t := range ac.events {
+		if t.After(cutoff) {
+			kept = append(kept, t)
+		}
+	}
+	ac.events = append(kept, now)
+
+	if len(ac.events) < count {
+		return false
+	}
+	ac.events = ac.events[:0]
+	return true
+}
+
+// alerts holds the anomaly detection state of a client.
+type alerts struct {
+	rejects alertCounter
+	logons  alertCounter
+}
+
+func (c *Client) raiseAlert(kind AlertKind, message string, fields map[string]any) {
+	sink := c.options.alertSink
+	if sink == nil {
+		return
+	}
+	a := Alert{Kind: kind, At: time.Now(), Message: message, Fields: fields}
+	c.l.Warnw("Raising alert", "kind", kind, "message", message, "fields", fields)
+	go sink.Alert(a)
+}
+
+func (c *Client) recordRejectForAlert() {
+	th := c.options.alertThresholds
+	if c.options.alertSink == nil || th.RejectStormCount <= 0 {
+		return
+	}
+	if c.alerts.rejects.add(time.Now(), th.RejectStormCount, th.RejectStormWindow) {
+		c.raiseAlert(AlertRejectStorm, "Too many rejects", map[string]any{
+			"count":  th.RejectStormCount,
+			"window": th.RejectStormWindow,
+		})
+	}
+}
+
+func (c *Client) recordLogonForAlert() {
+	th := c.options.alertThresholds
+	if c.options.alertSink == nil || th.ReconnectLoopCount <= 0 {
+		return
+	}
+	if c.alerts.logons.add(time.Now(), th.ReconnectLoopCount, th.ReconnectLoopWindow) {
+		c.raiseAlert(AlertReconnectLoop, "Session keeps reconnecting", map[string]any{
+			"count":  th.ReconnectLoopCount,
+			"window": th.ReconnectLoopWindow,
+		})
+	}
+}
+
+func (c *Client) recordLatencyForAlert(id string, latency time.Duration) {
+	th := c.options.alertThresholds
+	if c.options.alertSink == nil || th.LatencySpike <= 0 || latency <= th.LatencySpike {
+		return
+	}
+	c.raiseAlert(AlertLatencySpike, "Slow response", map[string]any{
+		"id":      id,
+		"latency": latency,
+	})
+}
+
+// detectSessionAnomaly raises the alerts revealed by the admin messages the
+// session sends on its own.
+func (c *Client) detectSessionAnomaly(msgType enum.MsgType, msg *quickfix.Message) {
+	th := c.options.alertThresholds
+	if c.options.alertSink == nil {
+		return
+	}
+
+	switch msgType {
+	case enum.MsgType_TEST_REQUEST:
+		if !th.HeartbeatLoss {
+			return
+		}
+		id, _ := msg.Body.GetString(tag.TestReqID)
+		c.mu.Lock()
+		_, ours := c.testRequests[id]
+		c.mu.Unlock()
+		if !ours {
+			c.raiseAlert(AlertHeartbeatLoss, "No message received within the heartbeat interval", nil)
+		}
+	case enum.MsgType_RESEND_REQUEST:
+		if !th.SequenceGap {
+			return
+		}
+		begin, _ := msg.Body.GetInt(tag.BeginSeqNo)
+		end, _ := msg.Body.GetInt(tag.EndSeqNo)
+		c.raiseAlert(AlertSequenceGap, "Requesting resend of missing messages", map[string]any{
+			"begin_seq_no": begin,
+			"end_seq_no":   end,
+		})
+	}
+}
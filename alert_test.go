@@ -0,0 +1,21 @@
+package fix
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAlertCounter(t *testing.T) {
+	var ac alertCounter
+	now := time.Now()
+
+	assert.False(t, ac.add(now, 3, time.Second))
+	assert.False(t, ac.add(now.Add(100*time.Millisecond), 3, time.Second))
+	// The first event left the window.
+	assert.False(t, ac.add(now.Add(1050*time.Millisecond), 3, time.Second))
+	assert.True(t, ac.add(now.Add(1080*time.Millisecond), 3, time.Second))
+	// Reset after raising.
+	assert.False(t, ac.add(now.Add(1300*time.Millisecond), 3, time.Second))
+}
@@ -0,0 +1,375 @@
+package fix
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/quickfixgo/enum"
+	"github.com/shopspring/decimal"
+)
+
+// AlgoParent is the order an algo works through child orders.
+type AlgoParent struct {
+	Symbol   string
+	Side     enum.Side
+	Quantity float64
+}
+
+// ChildOrder is a child order an algo asks to place. It is a market order if
+// Price is zero, a good-till-cancel limit order otherwise unless TimeInForce
+// is set.
+type ChildOrder struct {
+	Quantity    float64
+	Price       float64
+	TimeInForce enum.TimeInForce
+}
+
+// AlgoProgress is the state of an algo execution, derived from the order
+// tracker.
+type AlgoProgress struct {
+	Parent    AlgoParent
+	StartedAt time.Time
+	Children  []Order // In placement order, with their latest update.
+	Filled    float64 // Filled quantity of all children.
+	Working   float64 // Unfilled quantity of the open children.
+}
+
+// Remaining is the parent quantity neither filled nor working.
+func (p AlgoProgress) Remaining() float64 {
+	return p.Parent.Quantity - p.Filled - p.Working
+}
+
+// AlgoStep is the decision of an algo. With a Child, it is placed right away.
+// Otherwise the algo is asked again at At, if set, or on the next update of
+// a child.
+type AlgoStep struct {
+	Child *ChildOrder
+	At    time.Time
+	Done  bool
+}
+
+// Algo schedules the child orders of an execution. Next is called from a
+// single goroutine. Child quantities are sent as returned: algos are
+// responsible for rounding them to the lot size of the symbol.
+type Algo interface {
+	Next(p AlgoProgress, now time.Time) AlgoStep
+}
+
+// AlgoExecution runs an algo, see Client.RunAlgo.
+type AlgoExecution struct {
+	c      *Client
+	algo   Algo
+	parent AlgoParent
+
+	mu        sync.Mutex
+	startedAt time.Time
+	children  []Order
+	paused    bool
+	err       error
+
+	updated chan struct{} // Signaled on child updates and resumes.
+	cancel  context.CancelFunc
+	ctx     context.Context
+	done    chan struct{}
+}
+
+// RunAlgo starts working parent with algo. Child orders are held back while
+// the order rate limit budget is exhausted.
+func (c *Client) RunAlgo(parent AlgoParent, algo Algo) *AlgoExecution {
+	ctx, cancel := context.WithCancel(context.Background())
+	e := &AlgoExecution{
+		c:         c,
+		algo:      algo,
+		parent:    parent,
+		startedAt: time.Now(),
+		updated:   make(chan struct{}, 1),
+		cancel:    cancel,
+		ctx:       ctx,
+		done:      make(chan struct{}),
+	}
+	go e.run()
+	return e
+}
+
+// Progress returns the current state of the execution.
+func (e *AlgoExecution) Progress() AlgoProgress {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	p := AlgoProgress{
+		Parent:    e.parent,
+		StartedAt: e.startedAt,
+		Children:  make([]Order, 0, len(e.children)),
+	}
+	for _, child := range e.children {
+		if o, ok := e.c.tracker.Order(child.ClientOrderID); ok {
+			child = o
+		}
+		p.Children = append(p.Children, child)
+		p.Filled += child.CumQty
+		if !child.Status.IsTerminal() {
+			p.Working += child.OrderQty - child.CumQty
+		}
+	}
+	return p
+}
+
+// Pause stops placing child orders. Working children are left untouched.
+func (e *AlgoExecution) Pause() {
+	e.mu.Lock()
+	e.paused = true
+	e.mu.Unlock()
+}
+
+// Resume places child orders again after Pause.
+func (e *AlgoExecution) Resume() {
+	e.mu.Lock()
+	e.paused = false
+	e.mu.Unlock()
+	e.signal()
+}
+
+// Cancel stops the execution and cancels its open children, including one
+// which was in flight when the execution stopped.
+func (e *AlgoExecution) Cancel(ctx context.Context) error {
+	e.cancel()
+	<-e.done
+
+	var errs []error
+	for _, child := range e.Progress().Children {
+		if child.Status.IsTerminal() {
+			continue
+		}
+		// A child in flight may not have reached the matching engine yet, and
+		// be unknown to the first cancel.
+		policy := CancelRetryPolicy{Backoff: 100 * time.Millisecond, RetryIf: RetryUnknownOrder}
+		err := RetryCancel(ctx, policy, func(ctx context.Context) error {
+			_, err := e.c.cancelOrder(ctx, e.parent.Symbol, child.ClientOrderID)
+			return err
+		})
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Done is closed once the algo is done or the execution stopped.
+func (e *AlgoExecution) Done() <-chan struct{} {
+	return e.done
+}
+
+// Err returns why the execution stopped early, if it did.
+func (e *AlgoExecution) Err() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.err
+}
+
+func (e *AlgoExecution) signal() {
+	select {
+	case e.updated <- struct{}{}:
+	default:
+	}
+}
+
+func (e *AlgoExecution) isPaused() bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.paused
+}
+
+func (e *AlgoExecution) run() {
+	defer close(e.done)
+	defer e.cancel()
+
+	for {
+		var step AlgoStep
+		if !e.isPaused() {
+			step = e.algo.Next(e.Progress(), time.Now())
+		}
+		if step.Done {
+			return
+		}
+
+		if step.Child != nil {
+			if err := e.place(*step.Child); err != nil {
+				e.mu.Lock()
+				e.err = err
+				e.mu.Unlock()
+				return
+			}
+			continue
+		}
+
+		var (
+			timer *time.Timer
+			at    <-chan time.Time
+		)
+		if !step.At.IsZero() {
+			timer = time.NewTimer(time.Until(step.At))
+			at = timer.C
+		}
+		select {
+		case <-at:
+		case <-e.updated:
+		case <-e.ctx.Done():
+		}
+		if timer != nil {
+			timer.Stop()
+		}
+		if e.ctx.Err() != nil {
+			return
+		}
+	}
+}
+
+// place sends a child order, waiting for the order rate limit budget first.
+func (e *AlgoExecution) place(child ChildOrder) error {
+	for {
+		if left, ok := e.c.RemainingOrderBudget(); !ok || left > 0 {
+			break
+		}
+		select {
+		case <-time.After(budgetPollInterval):
+		case <-e.ctx.Done():
+			return e.ctx.Err()
+		}
+	}
+
+	svc := e.c.NewOrderSingleService().
+		Symbol(e.parent.Symbol).
+		Side(e.parent.Side).
+		Quantity(child.Quantity)
+	if child.Price > 0 {
+		tif := child.TimeInForce
+		if tif == "" {
+			tif = enum.TimeInForce_GOOD_TILL_CANCEL
+		}
+		svc.Type(enum.OrdType_LIMIT).Price(child.Price).TimeInForce(tif)
+	} else {
+		svc.Type(enum.OrdType_MARKET)
+	}
+
+	id, err := svc.clientOrderID()
+	if err != nil {
+		return err
+	}
+	svc.ClOrdID(id)
+
+	// Record the child before sending so that Cancel cancels it even if the
+	// execution stops while it is in flight.
+	e.mu.Lock()
+	i := len(e.children)
+	e.children = append(e.children, Order{
+		Symbol:        e.parent.Symbol,
+		ClientOrderID: id,
+		OrderQty:      child.Quantity,
+	})
+	e.mu.Unlock()
+
+	order, err := svc.Do(e.ctx)
+	if err != nil {
+		if !outcomeUnknown(err) {
+			e.mu.Lock()
+			e.children = e.children[:i]
+			e.mu.Unlock()
+		}
+		return err
+	}
+
+	e.mu.Lock()
+	e.children[i] = order
+	e.mu.Unlock()
+
+	if !order.Status.IsTerminal() {
+		updates := e.c.OrderWatcher(e.ctx, order.ClientOrderID)
+		go func() {
+			for range updates {
+				e.signal()
+			}
+		}()
+	}
+	return nil
+}
+
+// TWAP splits the parent into Slices children evenly spread over Duration.
+// The quantity left unfilled by a child is spread over the next slices.
+type TWAP struct {
+	Duration time.Duration
+	Slices   int
+	// StepSize is the lot size step of the symbol. Children are rounded down
+	// to it, to at least one step, and the last one takes the remainder. No
+	// rounding if zero.
+	StepSize float64
+	// LimitPrice of the children, market children if zero.
+	LimitPrice float64
+	// TimeInForce of limit children, IOC if empty so that no child rests.
+	TimeInForce enum.TimeInForce
+}
+
+func (a TWAP) Next(p AlgoProgress, now time.Time) AlgoStep {
+	slice := len(p.Children)
+	remaining := p.Remaining()
+	if slice >= a.Slices || remaining <= 0 {
+		return AlgoStep{Done: true}
+	}
+
+	at := p.StartedAt.Add(time.Duration(slice) * a.Duration / time.Duration(a.Slices))
+	if now.Before(at) {
+		return AlgoStep{At: at}
+	}
+
+	tif := a.TimeInForce
+	if tif == "" {
+		tif = enum.TimeInForce_IMMEDIATE_OR_CANCEL
+	}
+	return AlgoStep{Child: &ChildOrder{
+		Quantity:    a.sliceQty(p, slice),
+		Price:       a.LimitPrice,
+		TimeInForce: tif,
+	}}
+}
+
+// sliceQty returns the quantity of the child of the given slice.
+func (a TWAP) sliceQty(p AlgoProgress, slice int) float64 {
+	if a.StepSize <= 0 {
+		return p.Remaining() / float64(a.Slices-slice)
+	}
+
+	remaining := decimal.NewFromFloat(p.Parent.Quantity).
+		Sub(decimal.NewFromFloat(p.Filled)).
+		Sub(decimal.NewFromFloat(p.Working))
+	if slice == a.Slices-1 {
+		return remaining.InexactFloat64()
+	}
+	step := decimal.NewFromFloat(a.StepSize)
+	qty := remaining.Div(decimal.NewFromInt(int64(a.Slices - slice))).Div(step).Floor().Mul(step)
+	if qty.IsZero() {
+		qty = decimal.Min(step, remaining)
+	}
+	return qty.InexactFloat64()
+}
+
+// Iceberg works the parent as a sequence of limit children of at most
+// DisplayQty, placing the next one once the previous one is done.
+type Iceberg struct {
+	DisplayQty float64
+	Price      float64
+}
+
+func (a Iceberg) Next(p AlgoProgress, _ time.Time) AlgoStep {
+	if p.Working > 0 {
+		return AlgoStep{}
+	}
+	remaining := p.Remaining()
+	if remaining <= 0 {
+		return AlgoStep{Done: true}
+	}
+	return AlgoStep{Child: &ChildOrder{
+		Quantity: min(a.DisplayQty, remaining),
+		Price:    a.Price,
+	}}
+}
@@ -0,0 +1,109 @@
+package fix
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/quickfixgo/enum"
+	"github.com/quickfixgo/quickfix"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTWAP(t *testing.T) {
+	start := time.Now()
+	a := TWAP{Duration: time.Minute, Slices: 4}
+	p := AlgoProgress{Parent: AlgoParent{Quantity: 8}, StartedAt: start}
+
+	step := a.Next(p, start)
+	if assert.NotNil(t, step.Child) {
+		assert.Equal(t, 2.0, step.Child.Quantity)
+	}
+
+	// The first child only filled half, the rest is spread over the next slices.
+	p.Children = []Order{{OrderQty: 2, CumQty: 1, Status: OrderStatusExpired}}
+	p.Filled = 1
+	step = a.Next(p, start)
+	assert.Nil(t, step.Child)
+	assert.Equal(t, start.Add(15*time.Second), step.At)
+
+	step = a.Next(p, start.Add(15*time.Second))
+	if assert.NotNil(t, step.Child) {
+		assert.InDelta(t, 7.0/3, step.Child.Quantity, 1e-9)
+	}
+
+	p.Children = make([]Order, 4)
+	assert.True(t, a.Next(p, start.Add(time.Minute)).Done)
+}
+
+func TestTWAPStepSize(t *testing.T) {
+	start := time.Now()
+	a := TWAP{Slices: 3, StepSize: 0.01}
+	p := AlgoProgress{Parent: AlgoParent{Quantity: 1}, StartedAt: start}
+
+	var quantities []float64
+	for !a.Next(p, start).Done {
+		child := a.Next(p, start).Child
+		require.NotNil(t, child)
+		quantities = append(quantities, child.Quantity)
+		p.Children = append(p.Children, Order{OrderQty: child.Quantity})
+		p.Working += child.Quantity
+	}
+	assert.Equal(t, []float64{0.33, 0.33, 0.34}, quantities)
+
+	// A slice is at least one step.
+	a = TWAP{Slices: 4, StepSize: 0.1}
+	p = AlgoProgress{Parent: AlgoParent{Quantity: 0.2}, StartedAt: start}
+	if step := a.Next(p, start); assert.NotNil(t, step.Child) {
+		assert.Equal(t, 0.1, step.Child.Quantity)
+	}
+}
+
+func TestIceberg(t *testing.T) {
+	a := Iceberg{DisplayQty: 3, Price: 10}
+	p := AlgoProgress{Parent: AlgoParent{Quantity: 7}}
+
+	step := a.Next(p, time.Now())
+	if assert.NotNil(t, step.Child) {
+		assert.Equal(t, ChildOrder{Quantity: 3, Price: 10}, *step.Child)
+	}
+
+	p.Working = 3
+	assert.Equal(t, AlgoStep{}, a.Next(p, time.Now()))
+
+	p.Working, p.Filled = 0, 6
+	step = a.Next(p, time.Now())
+	if assert.NotNil(t, step.Child) {
+		assert.Equal(t, 1.0, step.Child.Quantity)
+	}
+
+	p.Filled = 7
+	assert.True(t, a.Next(p, time.Now()).Done)
+}
+
+func TestAlgoCancelsChildInFlight(t *testing.T) {
+	placing := make(chan string, 1)
+	// The child has not reached the server when the first cancel arrives.
+	var orders lateOrders
+	c := NewWithCaller(CallerFunc(func(ctx context.Context, id string, msg *quickfix.Message) (*quickfix.Message, error) {
+		msgType, _ := msg.MsgType()
+		if enum.MsgType(msgType) == enum.MsgType_ORDER_CANCEL_REQUEST {
+			require.NoError(t, ctx.Err())
+			return orders.cancel(id, msg), nil
+		}
+		// The ack is still on its way when the execution is canceled.
+		placing <- id
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}))
+
+	e := c.RunAlgo(AlgoParent{Symbol: "BTCUSDT", Side: enum.Side_BUY, Quantity: 1}, TWAP{Slices: 1})
+	id := <-placing
+	require.NoError(t, e.Cancel(context.Background()))
+	assert.Equal(t, 2, orders.attemptsOf(id))
+
+	children := e.Progress().Children
+	require.Len(t, children, 1)
+	assert.Equal(t, OrderStatusCanceled, children[0].Status)
+}
@@ -0,0 +1,171 @@
+package fix
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/KyberNetwork/binance_fix_api/binancetag"
+	"github.com/google/uuid"
+	"github.com/quickfixgo/field"
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/tag"
+)
+
+/*
+Tag     Name            Type    Required    Description
+11      ClOrdID         STRING  Y           New ClOrdID of the amended order.
+41      OrigClOrdID     STRING  N           ClOrdID of the order to amend.
+37      OrderID         INT     N           OrderID of the order to amend.
+55      Symbol          STRING  Y
+38      OrderQty        QTY     Y           New quantity, lower than the current one.
+Either OrigClOrdID or OrderID must be provided.
+*/
+
+// OrderAmendRejectedError is returned when the server rejects an amend with
+// an OrderAmendReject<XAR>.
+type OrderAmendRejectedError struct {
+	ClOrdID     string
+	OrigClOrdID string
+	OrderID     int64 // 0 if absent.
+	ErrorCode   int   // Binance ErrorCode<25016>, 0 if absent.
+	Text        string
+}
+
+func (e *OrderAmendRejectedError) Error() string {
+	return "amend rejected: " + e.Text + " (code " + strconv.Itoa(e.ErrorCode) + ")"
+}
+
+// AmendOrderService reduces the quantity of an order without losing its
+// priority in the book, with an OrderAmendKeepPriorityRequest<XAK>.
+type AmendOrderService struct {
+	c           *Client
+	symbol      string
+	origClOrdID string
+	orderID     *int64
+	quantity    float64
+	fields      customFields
+}
+
+func (c *Client) NewAmendOrderService() *AmendOrderService {
+	return &AmendOrderService{c: c}
+}
+
+// Symbol set symbol
+func (s *AmendOrderService) Symbol(symbol string) *AmendOrderService {
+	s.symbol = symbol
+	return s
+}
+
+// OrigClientOrderID set the ClOrdID of the order to amend
+func (s *AmendOrderService) OrigClientOrderID(clOrdID string) *AmendOrderService {
+	s.origClOrdID = clOrdID
+	return s
+}
+
+// OrderID set the OrderID of the order to amend
+func (s *AmendOrderService) OrderID(orderID int64) *AmendOrderService {
+	s.orderID = &orderID
+	return s
+}
+
+// Quantity set the new quantity
+func (s *AmendOrderService) Quantity(quantity float64) *AmendOrderService {
+	s.quantity = quantity
+	return s
+}
+
+// SetField sets any body tag, e.g. one newly added by Binance.
+func (s *AmendOrderService) SetField(t quickfix.Tag, value string) *AmendOrderService {
+	s.fields.set(t, value)
+	return s
+}
+
+// Do sends the amend and returns the execution report of the amended order,
+// which carries the new ClOrdID. A reject is returned as an
+// *OrderAmendRejectedError.
+func (s *AmendOrderService) Do(ctx context.Context) (Order, error) {
+	if s.origClOrdID == "" && s.orderID == nil {
+		return Order{}, ErrNoOrigOrder
+	}
+	if s.symbol == "" {
+		return Order{}, invalidOrder("Symbol is required")
+	}
+	if s.quantity <= 0 {
+		return Order{}, invalidOrder("Quantity must be positive")
+	}
+
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return Order{}, err
+	}
+
+	msg := quickfix.NewMessage()
+	msg.Header.Set(field.NewMsgType(msgType_AMEND_KEEP_PRIORITY))
+	msg.Body.Set(field.NewClOrdID(id.String()))
+	if s.origClOrdID != "" {
+		msg.Body.Set(field.NewOrigClOrdID(s.origClOrdID))
+	}
+	if s.orderID != nil {
+		msg.Body.SetString(tag.OrderID, strconv.FormatInt(*s.orderID, 10))
+	}
+	msg.Body.Set(field.NewSymbol(s.symbol))
+	msg.Body.SetString(tag.OrderQty, floatToString(s.quantity))
+	s.fields.apply(msg)
+
+	l := s.c.logger(ctx)
+	resp, err := s.c.Call(ctx, id.String(), msg)
+	if err != nil {
+		l.Errorw("Failed to amend order", "request", msg, "err", err)
+		return Order{}, err
+	}
+
+	if resp.IsMsgTypeOf(string(msgType_AMEND_REJECT)) {
+		rejErr, err := decodeAmendReject(resp)
+		if err != nil {
+			return Order{}, s.c.escalateDecode("decode amend reject", newMessageError(err, resp))
+		}
+		return Order{}, rejErr
+	}
+
+	order, err := decodeExecutionReport(resp)
+	if err != nil {
+		l.Errorw("Failed to decode ExecutionReport message", "request", msg, "response", resp, "error", err)
+		return order, s.c.escalateDecode("decode execution report", newMessageError(err, resp))
+	}
+	s.c.attachRaw(&order, resp)
+	order.Metadata = MetadataFromContext(ctx)
+
+	return order, nil
+}
+
+// DoAsync sends the request like Do, without waiting for the response.
+func (s *AmendOrderService) DoAsync(ctx context.Context) *Future[Order] {
+	return doAsync(ctx, s.Do)
+}
+
+func decodeAmendReject(msg *quickfix.Message) (*OrderAmendRejectedError, error) {
+	var (
+		e   OrderAmendRejectedError
+		err error
+	)
+
+	if e.ClOrdID, err = binancetag.GetString(msg, tag.ClOrdID); err != nil {
+		return nil, err
+	}
+	if e.OrigClOrdID, err = binancetag.GetString(msg, tag.OrigClOrdID); err != nil {
+		return nil, err
+	}
+	if msg.Body.Has(tag.OrderID) {
+		if e.OrderID, err = getOrderID(msg); err != nil {
+			return nil, err
+		}
+	}
+	if e.ErrorCode, err = binancetag.GetErrorCode(msg); err != nil {
+		return nil, err
+	}
+	if e.Text, err = getText(msg); err != nil {
+		return nil, err
+	}
+
+	return &e, nil
+}
@@ -0,0 +1,67 @@
+package fix
+
+import (
+	"context"
+	"testing"
+
+	"github.com/KyberNetwork/binance_fix_api/binancetag"
+	"github.com/quickfixgo/enum"
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/tag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAmendOrder(t *testing.T) {
+	g := newTestGateway(t)
+	g.handle(func(msg *quickfix.Message, sessionID quickfix.SessionID) {
+		if !msg.IsMsgTypeOf(string(msgType_AMEND_KEEP_PRIORITY)) {
+			return
+		}
+		clOrdID, _ := msg.Body.GetString(tag.ClOrdID)
+		origID, _ := msg.Body.GetString(tag.OrigClOrdID)
+		qty, _ := msg.Body.GetString(tag.OrderQty)
+
+		if origID == "unknown" {
+			reject := newTestMessage(msgType_AMEND_REJECT)
+			reject.Body.SetString(tag.ClOrdID, clOrdID)
+			reject.Body.SetString(tag.OrigClOrdID, origID)
+			reject.Body.SetInt(binancetag.ErrorCode, -2013)
+			reject.Body.SetString(tag.Text, "Order does not exist.")
+			g.reply(t, sessionID, reject)
+			return
+		}
+		report := newTestReport(clOrdID, enum.OrdStatus_NEW)
+		report.Body.SetString(tag.OrigClOrdID, origID)
+		report.Body.SetString(tag.ExecType, string(enum.ExecType_REPLACED))
+		report.Body.SetString(tag.OrderQty, qty)
+		g.reply(t, sessionID, report)
+	})
+	c := g.startClient(t)
+
+	order, err := c.NewAmendOrderService().Symbol("BTCUSDT").OrigClientOrderID("order").Quantity(0.5).
+		Do(context.Background())
+	require.NoError(t, err)
+	assert.NotEqual(t, "order", order.ClientOrderID)
+	assert.Equal(t, 0.5, order.OrderQty)
+
+	_, err = c.NewAmendOrderService().Symbol("BTCUSDT").OrigClientOrderID("unknown").Quantity(0.5).
+		Do(context.Background())
+	var rejErr *OrderAmendRejectedError
+	require.ErrorAs(t, err, &rejErr)
+	assert.Equal(t, "unknown", rejErr.OrigClOrdID)
+	assert.Equal(t, -2013, rejErr.ErrorCode)
+	assert.Equal(t, "Order does not exist.", rejErr.Text)
+}
+
+func TestAmendOrderValidate(t *testing.T) {
+	c := &Client{}
+	ctx := context.Background()
+
+	_, err := c.NewAmendOrderService().Symbol("BTCUSDT").Quantity(1).Do(ctx)
+	assert.ErrorIs(t, err, ErrNoOrigOrder)
+	_, err = c.NewAmendOrderService().OrigClientOrderID("order").Quantity(1).Do(ctx)
+	assert.ErrorIs(t, err, ErrInvalidOrder)
+	_, err = c.NewAmendOrderService().Symbol("BTCUSDT").OrderID(1).Do(ctx)
+	assert.ErrorIs(t, err, ErrInvalidOrder)
+}
@@ -0,0 +1,125 @@
+package fix
+
+import "sync"
+
+// orderBacklog is a fixed size ring buffer of the most recent execution
+// reports. Each report is numbered as it is recorded so that subscribers
+// replaying the backlog can skip the live reports they already replayed.
+type orderBacklog struct {
+	mu     sync.Mutex
+	orders []*Order
+	next   int
+	full   bool
+	seq    uint64 // Number of the last recorded report.
+}
+
+func newOrderBacklog(size int) *orderBacklog {
+	return &orderBacklog{orders: make([]*Order, size)}
+}
+
+func (b *orderBacklog) add(o *Order) {
+	b.seq++
+	o.backlogSeq = b.seq
+	b.orders[b.next] = o
+	b.next = (b.next + 1) % len(b.orders)
+	if b.next == 0 {
+		b.full = true
+	}
+}
+
+// last returns up to n most recent orders, oldest first.
+func (b *orderBacklog) last(n int) []*Order {
+	size := b.next
+	if b.full {
+		size = len(b.orders)
+	}
+	if n > size || n < 0 {
+		n = size
+	}
+
+	out := make([]*Order, 0, n)
+	for i := b.next - n; i < b.next; i++ {
+		out = append(out, b.orders[(i+len(b.orders))%len(b.orders)])
+	}
+	return out
+}
+
+// RecentExecutionReports returns up to n of the most recently received
+// execution reports, oldest first. It returns nil unless the client was
+// created with WithEventBacklogOpt.
+func (c *Client) RecentExecutionReports(n int) []*Order {
+	if c.backlog == nil {
+		return nil
+	}
+
+	c.backlog.mu.Lock()
+	defer c.backlog.mu.Unlock()
+	return c.backlog.last(n)
+}
+
+// SubscribeToExecutionReportWithBacklog calls listener with up to n of the
+// most recent execution reports before subscribing it to new ones, without
+// gaps or duplicates in between. Reports received during the replay are
+// passed to listener once it is over.
+func (c *Client) SubscribeToExecutionReportWithBacklog(n int, listener ExecutionReportHandler) {
+	if c.backlog == nil {
+		c.SubscribeToExecutionReport(listener)
+		return
+	}
+
+	c.backlog.mu.Lock()
+	replay := c.backlog.last(n)
+	s := &backlogSubscriber{listener: listener, after: c.backlog.seq, replaying: true}
+	c.SubscribeToExecutionReport(s.live)
+	c.backlog.mu.Unlock()
+
+	for _, o := range replay {
+		listener(o)
+	}
+	s.replayed()
+}
+
+// backlogSubscriber holds back the live reports of a subscriber until its
+// backlog is replayed.
+type backlogSubscriber struct {
+	listener ExecutionReportHandler
+	after    uint64 // Reports up to this number are in the replay.
+
+	mu        sync.Mutex
+	replaying bool
+	queued    []*Order
+}
+
+func (s *backlogSubscriber) live(o *Order) {
+	if o.backlogSeq <= s.after {
+		return
+	}
+
+	s.mu.Lock()
+	if s.replaying {
+		s.queued = append(s.queued, o)
+		s.mu.Unlock()
+		return
+	}
+	s.mu.Unlock()
+	s.listener(o)
+}
+
+// replayed passes on the reports queued during the replay.
+func (s *backlogSubscriber) replayed() {
+	for {
+		s.mu.Lock()
+		queued := s.queued
+		s.queued = nil
+		if len(queued) == 0 {
+			s.replaying = false
+			s.mu.Unlock()
+			return
+		}
+		s.mu.Unlock()
+
+		for _, o := range queued {
+			s.listener(o)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package fix
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrderBacklog(t *testing.T) {
+	b := newOrderBacklog(3)
+	assert.Empty(t, b.last(2))
+
+	orders := make([]*Order, 5)
+	for i := range orders {
+		orders[i] = &Order{OrderID: int64(i)}
+	}
+
+	b.add(orders[0])
+	b.add(orders[1])
+	assert.Equal(t, []*Order{orders[0], orders[1]}, b.last(5))
+
+	b.add(orders[2])
+	b.add(orders[3])
+	b.add(orders[4])
+	assert.Equal(t, []*Order{orders[2], orders[3], orders[4]}, b.last(-1))
+	assert.Equal(t, []*Order{orders[3], orders[4]}, b.last(2))
+}
+
+func TestBacklogListenerCallsBack(t *testing.T) {
+	c := NewWithCaller(nil)
+	c.backlog = newOrderBacklog(3)
+	c.deliverOrder(Order{ClientOrderID: "a"})
+
+	var seen []string
+	var recent []*Order
+	c.SubscribeToExecutionReportWithBacklog(-1, func(o *Order) {
+		seen = append(seen, o.ClientOrderID)
+		if o.ClientOrderID == "a" {
+			// A report delivered during the replay comes after it.
+			c.deliverOrder(Order{ClientOrderID: "b"})
+		}
+		recent = c.RecentExecutionReports(-1)
+	})
+	assert.Equal(t, []string{"a", "b"}, seen)
+	assert.Len(t, recent, 2)
+
+	c.SubscribeToExecutionReport(func(o *Order) {
+		c.SubscribeToExecutionReportWithBacklog(1, func(*Order) {})
+	})
+	done := make(chan struct{})
+	go func() {
+		c.deliverOrder(Order{ClientOrderID: "c"})
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("deliverOrder deadlocked")
+	}
+	assert.Equal(t, []string{"a", "b", "c"}, seen)
+}
@@ -0,0 +1,149 @@
+// Package binancetag defines the custom FIX tags used by the Binance spot FIX
+// API, together with helpers to read them from raw quickfix messages.
+package binancetag
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/quickfixgo/quickfix"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	ReqID      quickfix.Tag = 6136 // Request ID of LimitQuery<XLQ> and LimitResponse<XLR>.
+	StrategyID quickfix.Tag = 7940 // Strategy an order is attributed to.
+
+	RecvWindow              quickfix.Tag = 25000 // Header tag, in milliseconds after SendingTime<52>.
+	SelfTradePreventionMode quickfix.Tag = 25001
+	CancelRestrictions      quickfix.Tag = 25002
+
+	NoLimitIndicators            quickfix.Tag = 25003
+	LimitType                    quickfix.Tag = 25004
+	LimitCount                   quickfix.Tag = 25005
+	LimitMax                     quickfix.Tag = 25006
+	LimitResetInterval           quickfix.Tag = 25007
+	LimitResetIntervalResolution quickfix.Tag = 25008
+
+	TriggerTrailingDeltaBps quickfix.Tag = 25009
+
+	NoListTriggeringInstructions quickfix.Tag = 25010
+	ListTriggerType              quickfix.Tag = 25011
+	ListTriggerTriggerIndex      quickfix.Tag = 25012
+	ListTriggerAction            quickfix.Tag = 25013
+	ClListID                     quickfix.Tag = 25014
+	OrigClListID                 quickfix.Tag = 25015
+
+	ErrorCode         quickfix.Tag = 25016
+	CumQuoteQty       quickfix.Tag = 25017
+	OrderCreationTime quickfix.Tag = 25018
+	WorkingFloor      quickfix.Tag = 25021
+	TrailingTime      quickfix.Tag = 25022
+	WorkingTime       quickfix.Tag = 25023
+
+	PreventedMatchID        quickfix.Tag = 25024
+	PreventedExecutionPrice quickfix.Tag = 25025
+	PreventedExecutionQty   quickfix.Tag = 25026
+	TradeGroupID            quickfix.Tag = 25027
+	CounterSymbol           quickfix.Tag = 25028
+	CounterOrderID          quickfix.Tag = 25029
+	PreventedQty            quickfix.Tag = 25030
+	LastPreventedQty        quickfix.Tag = 25031
+
+	SOR quickfix.Tag = 25032
+
+	OrderCancelRequestAndNewOrderSingleMode quickfix.Tag = 25033
+	CancelClOrdID                           quickfix.Tag = 25034
+
+	MessageHandling quickfix.Tag = 25035
+	ResponseMode    quickfix.Tag = 25036
+)
+
+// GetString returns the body value of t, empty if absent.
+func GetString(msg *quickfix.Message, t quickfix.Tag) (string, error) {
+	if !msg.Body.Has(t) {
+		return "", nil
+	}
+	return msg.Body.GetString(t)
+}
+
+// GetInt returns the body value of t as an integer, 0 if absent.
+func GetInt(msg *quickfix.Message, t quickfix.Tag) (int, error) {
+	if !msg.Body.Has(t) {
+		return 0, nil
+	}
+	return msg.Body.GetInt(t)
+}
+
+// GetInt64 returns the body value of t as a 64-bit integer, 0 if absent.
+func GetInt64(msg *quickfix.Message, t quickfix.Tag) (int64, error) {
+	str, err := GetString(msg, t)
+	if err != nil || str == "" {
+		return 0, err
+	}
+	return strconv.ParseInt(str, 10, 64)
+}
+
+// GetFloat returns the body value of t as a float, 0 if absent.
+func GetFloat(msg *quickfix.Message, t quickfix.Tag) (float64, error) {
+	str, err := GetString(msg, t)
+	if err != nil || str == "" {
+		return 0, err
+	}
+	return strconv.ParseFloat(str, 64)
+}
+
+// GetDecimal returns the body value of t as an exact decimal, 0 if absent.
+func GetDecimal(msg *quickfix.Message, t quickfix.Tag) (decimal.Decimal, error) {
+	str, err := GetString(msg, t)
+	if err != nil || str == "" {
+		return decimal.Decimal{}, err
+	}
+	return decimal.NewFromString(str)
+}
+
+// GetUTCTimestamp returns the body value of t as a UTC time, zero if absent.
+// Seconds, millis, micros and nanos precisions are accepted.
+func GetUTCTimestamp(msg *quickfix.Message, t quickfix.Tag) (time.Time, error) {
+	str, err := GetString(msg, t)
+	if err != nil || str == "" {
+		return time.Time{}, err
+	}
+	return ParseUTCTimestamp(str)
+}
+
+// ParseUTCTimestamp parses a FIX UTCTimestamp of any supported precision.
+func ParseUTCTimestamp(str string) (time.Time, error) {
+	var ts quickfix.FIXUTCTimestamp
+	if err := ts.Read([]byte(str)); err != nil {
+		return time.Time{}, err
+	}
+	return ts.Time, nil
+}
+
+// GetErrorCode returns the Binance error code of a reject, 0 if absent.
+func GetErrorCode(msg *quickfix.Message) (int, error) {
+	return GetInt(msg, ErrorCode)
+}
+
+// GetCumQuoteQty returns the total quote quantity filled of an ExecutionReport<8>.
+func GetCumQuoteQty(msg *quickfix.Message) (float64, error) {
+	return GetFloat(msg, CumQuoteQty)
+}
+
+// GetOrderCreationTime returns when the order of an ExecutionReport<8> was created.
+func GetOrderCreationTime(msg *quickfix.Message) (time.Time, error) {
+	return GetUTCTimestamp(msg, OrderCreationTime)
+}
+
+// GetWorkingTime returns when the order of an ExecutionReport<8> appeared on
+// the order book.
+func GetWorkingTime(msg *quickfix.Message) (time.Time, error) {
+	return GetUTCTimestamp(msg, WorkingTime)
+}
+
+// GetTrailingTime returns when the trailing stop order of an
+// ExecutionReport<8> started tracking the price.
+func GetTrailingTime(msg *quickfix.Message) (time.Time, error) {
+	return GetUTCTimestamp(msg, TrailingTime)
+}
@@ -0,0 +1,46 @@
+package binancetag
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/quickfixgo/quickfix"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseUTCTimestamp(t *testing.T) {
+	for _, str := range []string{
+		"20240627-11:17:25",
+		"20240627-11:17:25.223",
+		"20240627-11:17:25.223456",
+		"20240627-11:17:25.223456789",
+	} {
+		ts, err := ParseUTCTimestamp(str)
+		require.NoError(t, err, str)
+		assert.Equal(t, time.UTC, ts.Location())
+		assert.True(t, strings.HasPrefix(str, ts.Format("20060102-15:04:05")), str)
+	}
+
+	_, err := ParseUTCTimestamp("20240627-11:17")
+	assert.Error(t, err)
+}
+
+func TestGetters(t *testing.T) {
+	msg := quickfix.NewMessage()
+	msg.Body.SetString(CumQuoteQty, "50.2")
+	msg.Body.SetString(WorkingTime, "20240627-11:17:25.223456")
+
+	cumQuoteQty, err := GetCumQuoteQty(msg)
+	require.NoError(t, err)
+	assert.Equal(t, 50.2, cumQuoteQty)
+
+	workingTime, err := GetWorkingTime(msg)
+	require.NoError(t, err)
+	assert.Equal(t, 223456000, workingTime.Nanosecond())
+
+	creationTime, err := GetOrderCreationTime(msg)
+	require.NoError(t, err)
+	assert.True(t, creationTime.IsZero())
+}
@@ -0,0 +1,287 @@
+package fix
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/quickfixgo/enum"
+	"github.com/shopspring/decimal"
+)
+
+// BracketSpec describes an entry order protected, once filled, by a
+// take-profit and a stop-loss order canceling each other.
+type BracketSpec struct {
+	Symbol   string
+	Side     enum.Side // Side of the entry, the exits take the other side.
+	Quantity float64
+
+	// EntryPrice of a limit entry, a market entry is sent if zero.
+	EntryPrice float64
+
+	TakeProfitPrice float64 // Price of the LIMIT_MAKER take-profit order.
+	StopLossPrice   float64 // Trigger price of the stop-loss order.
+	// StopLossLimitPrice makes the stop-loss a stop-limit order, it is a stop
+	// market order if zero.
+	StopLossLimitPrice float64
+}
+
+// BracketState is a snapshot of a bracket.
+type BracketState struct {
+	Entry        Order
+	ProtectedQty float64 // Entry quantity covered by exit orders.
+	ExitListID   string  // ClListID<25014> of the exit OCO last placed.
+	Done         bool
+	Err          error // Why the bracket stopped early, if it did.
+}
+
+// Bracket manages an entry order and its exits as a single unit. The fills
+// of the entry are protected by one OCO: as Binance cannot increase the
+// quantity of an order, every new fill cancels it and places a new one sized
+// to the filled quantity it did not close yet.
+type Bracket struct {
+	c    *Client
+	spec BracketSpec
+
+	mu     sync.Mutex
+	state  BracketState
+	exit   string          // ClOrdID of the take-profit of the working exit list.
+	closed decimal.Decimal // Quantity closed by the exit lists already replaced.
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// PlaceBracket places the entry order of spec and starts managing the
+// bracket. The bracket keeps running until the entry order reaches a
+// terminal state and its filled quantity is protected.
+func (c *Client) PlaceBracket(ctx context.Context, spec BracketSpec) (*Bracket, error) {
+	if spec.TakeProfitPrice <= 0 || spec.StopLossPrice <= 0 {
+		return nil, errors.New("bracket needs a take-profit and a stop-loss price")
+	}
+
+	svc := c.NewOrderSingleService().
+		Symbol(spec.Symbol).
+		Side(spec.Side).
+		Quantity(spec.Quantity)
+	if spec.EntryPrice > 0 {
+		svc.Type(enum.OrdType_LIMIT).Price(spec.EntryPrice).TimeInForce(enum.TimeInForce_GOOD_TILL_CANCEL)
+	} else {
+		svc.Type(enum.OrdType_MARKET)
+	}
+
+	entry, err := svc.Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Watch before looking at the tracker so no fill can be missed.
+	runCtx, cancel := context.WithCancel(context.Background())
+	updates := c.OrderWatcher(runCtx, entry.ClientOrderID)
+	if o, ok := c.tracker.Order(entry.ClientOrderID); ok {
+		entry = o
+	}
+
+	b := &Bracket{
+		c:      c,
+		spec:   spec,
+		state:  BracketState{Entry: entry},
+		cancel: cancel,
+		done:   make(chan struct{}),
+	}
+	go b.run(runCtx, updates)
+
+	return b, nil
+}
+
+// State returns a snapshot of the bracket.
+func (b *Bracket) State() BracketState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.state
+}
+
+// Done is closed once the bracket stopped managing its orders.
+func (b *Bracket) Done() <-chan struct{} {
+	return b.done
+}
+
+// Cancel cancels the entry, if still open, and waits for the bracket to
+// protect everything it filled until then. The exit list is left working, it
+// can be canceled with CancelOrder once the position is not wanted anymore.
+// The bracket keeps running if the entry could not be canceled, unless the
+// server rejected the cancel, the entry then being already done, and stops
+// if ctx expires first.
+func (b *Bracket) Cancel(ctx context.Context) error {
+	entry := b.State().Entry
+	if !entry.Status.IsTerminal() {
+		err := RetryCancel(ctx, CancelRetryPolicy{}, func(ctx context.Context) error {
+			_, err := b.c.cancelOrder(ctx, b.spec.Symbol, entry.ClientOrderID)
+			return err
+		})
+		if _, rejected := ClassifyCancelError(err); err != nil && !rejected {
+			return err
+		}
+	}
+
+	select {
+	case <-b.done:
+		return nil
+	case <-ctx.Done():
+		b.cancel()
+		<-b.done
+		return ctx.Err()
+	}
+}
+
+func (b *Bracket) run(ctx context.Context, updates <-chan Order) {
+	defer close(b.done)
+	defer b.cancel()
+
+	// The entry may have been filled before the watch started.
+	entry := b.State().Entry
+	if err := b.protect(ctx, entry); err != nil {
+		b.finish(err)
+		return
+	}
+	if entry.Status.IsTerminal() {
+		b.finish(nil)
+		return
+	}
+
+	for o := range updates {
+		if err := b.protect(ctx, o); err != nil {
+			b.finish(err)
+			return
+		}
+		if o.Status.IsTerminal() {
+			b.finish(nil)
+			return
+		}
+	}
+	b.finish(ctx.Err())
+}
+
+func (b *Bracket) finish(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.state.Done = true
+	if b.state.Err == nil {
+		b.state.Err = err
+	}
+}
+
+// protect resizes the exit list to the quantity filled by the entry. The
+// working list is canceled first, a list which was executed meanwhile cannot
+// be resized and stops the bracket with the cancel reject.
+func (b *Bracket) protect(ctx context.Context, entry Order) error {
+	b.mu.Lock()
+	if entry.CumQty >= b.state.Entry.CumQty {
+		b.state.Entry = entry
+	}
+	filled := b.state.Entry.CumQty
+	protected := b.state.ProtectedQty
+	exit := b.exit
+	b.mu.Unlock()
+
+	if filled <= protected {
+		return nil
+	}
+
+	if exit != "" {
+		var canceled Order
+		err := RetryCancel(ctx, CancelRetryPolicy{}, func(ctx context.Context) error {
+			var err error
+			canceled, err = b.c.cancelOrder(ctx, b.spec.Symbol, exit)
+			return err
+		})
+		if err != nil {
+			b.c.l.Errorw("Failed to cancel bracket exit", "clOrdID", exit, "error", err)
+			return err
+		}
+
+		b.mu.Lock()
+		b.closed = b.closed.Add(decimal.NewFromFloat(canceled.CumQty))
+		b.exit = ""
+		b.state.ExitListID = ""
+		b.mu.Unlock()
+	}
+
+	b.mu.Lock()
+	qty := decimal.NewFromFloat(filled).Sub(b.closed)
+	b.mu.Unlock()
+
+	takeProfit, clListID, err := b.placeExits(ctx, qty)
+	if err != nil {
+		b.c.l.Errorw("Failed to protect bracket fill", "clOrdID", entry.ClientOrderID, "qty", qty, "error", err)
+		return err
+	}
+
+	b.mu.Lock()
+	b.state.ProtectedQty = filled
+	b.state.ExitListID = clListID
+	b.exit = takeProfit
+	b.mu.Unlock()
+	return nil
+}
+
+// placeExits places the exit OCO of qty: a LIMIT_MAKER take-profit, like
+// NewOrderListService.LimitMakerLeg, and a stop-loss.
+func (b *Bracket) placeExits(ctx context.Context, qty decimal.Decimal) (string, string, error) {
+	side, direction := enum.Side_SELL, TriggerDirectionDown
+	if b.spec.Side == enum.Side_SELL {
+		side, direction = enum.Side_BUY, TriggerDirectionUp
+	}
+
+	takeProfitID, err := uuid.NewRandom()
+	if err != nil {
+		return "", "", err
+	}
+	stopLossID, err := uuid.NewRandom()
+	if err != nil {
+		return "", "", err
+	}
+
+	takeProfitPrice := decimal.NewFromFloat(b.spec.TakeProfitPrice)
+	stopLossPrice := decimal.NewFromFloat(b.spec.StopLossPrice)
+	takeProfit := listOrder{
+		clOrdID:   takeProfitID.String(),
+		side:      side,
+		orderType: enum.OrdType_LIMIT,
+		execInst:  execInstParticipateDontInitiate,
+		quantity:  qty,
+		price:     &takeProfitPrice,
+		triggers: []listTrigger{
+			{triggerType: listTriggerTypePartiallyFilled, triggerIndex: 1, action: listTriggerActionCancel},
+		},
+	}
+	stopLoss := listOrder{
+		clOrdID:          stopLossID.String(),
+		side:             side,
+		orderType:        enum.OrdType_STOP,
+		quantity:         qty,
+		triggerPrice:     &stopLossPrice,
+		triggerDirection: direction,
+		triggers: []listTrigger{
+			{triggerType: listTriggerTypePartiallyFilled, triggerIndex: 0, action: listTriggerActionCancel},
+		},
+	}
+	if b.spec.StopLossLimitPrice > 0 {
+		stopLoss.orderType = enum.OrdType_STOP_LIMIT
+		limitPrice := decimal.NewFromFloat(b.spec.StopLossLimitPrice)
+		gtc := enum.TimeInForce_GOOD_TILL_CANCEL
+		stopLoss.price = &limitPrice
+		stopLoss.timeInForce = &gtc
+	}
+
+	list, err := b.c.sendOrderList(
+		ctx, "", b.spec.Symbol, enum.ContingencyType_ONE_CANCELS_THE_OTHER, []listOrder{takeProfit, stopLoss}, nil,
+	)
+	if err != nil {
+		return "", "", err
+	}
+	return takeProfit.clOrdID, list.ClListID, nil
+}
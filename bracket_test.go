@@ -0,0 +1,263 @@
+package fix
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/KyberNetwork/binance_fix_api/binancetag"
+	"github.com/quickfixgo/enum"
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/tag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// bracketExchange answers the requests of a bracket: the entry is acked, the
+// exit lists are placed and every cancel succeeds.
+type bracketExchange struct {
+	c *Client
+
+	mu       sync.Mutex
+	entry    string
+	requests []string            // Type of every request, in order.
+	lists    [][]string          // ClOrdID of the orders of every list.
+	listMsgs []*quickfix.Message // Every NewOrderList<E> received.
+	qty      map[string]float64  // OrderQty of every exit order.
+	cumQty   map[string]float64  // CumQty reported when canceling an order.
+	canceled map[string]struct{} // Canceled orders.
+}
+
+func newBracketExchange() *bracketExchange {
+	x := &bracketExchange{
+		qty:      make(map[string]float64),
+		cumQty:   make(map[string]float64),
+		canceled: make(map[string]struct{}),
+	}
+	x.c = NewWithCaller(CallerFunc(x.call))
+	return x
+}
+
+func (x *bracketExchange) call(_ context.Context, id string, msg *quickfix.Message) (*quickfix.Message, error) {
+	msgType, _ := msg.MsgType()
+
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	x.requests = append(x.requests, msgType)
+
+	switch enum.MsgType(msgType) {
+	case enum.MsgType_ORDER_SINGLE:
+		x.entry = id
+		return newTestReport(id, enum.OrdStatus_NEW), nil
+	case enum.MsgType_ORDER_CANCEL_REQUEST:
+		orig, _ := msg.Body.GetString(tag.OrigClOrdID)
+		x.canceled[orig] = struct{}{}
+		report := newTestReport(id, enum.OrdStatus_CANCELED)
+		report.Body.SetString(tag.OrigClOrdID, orig)
+		report.Body.SetString(tag.ExecType, string(enum.ExecType_CANCELED))
+		report.Body.SetString(tag.CumQty, floatToString(x.cumQty[orig]))
+		return report, nil
+	case enum.MsgType_ORDER_LIST:
+		orders := newListOrderGroup()
+		if err := msg.Body.GetGroup(orders); err != nil {
+			return nil, err
+		}
+		status := newTestMessage(enum.MsgType_LIST_STATUS)
+		status.Body.SetString(tag.Symbol, "BTCUSDT")
+		status.Body.SetString(tag.ListID, "1")
+		status.Body.SetString(binancetag.ClListID, id)
+		status.Body.SetString(tag.ContingencyType, string(enum.ContingencyType_ONE_CANCELS_THE_OTHER))
+		status.Body.SetString(tag.ListStatusType, string(enum.ListStatusType_EXEC_STARTED))
+		status.Body.SetString(tag.ListOrderStatus, string(enum.ListOrderStatus_EXECUTING))
+		group := newListStatusOrderGroup()
+		var ids []string
+		for i := range orders.Len() {
+			clOrdID, _ := orders.Get(i).GetString(tag.ClOrdID)
+			qty, _ := orders.Get(i).GetString(tag.OrderQty)
+			x.qty[clOrdID], _ = strconv.ParseFloat(qty, 64)
+			ids = append(ids, clOrdID)
+
+			g := group.Add()
+			g.SetString(tag.Symbol, "BTCUSDT")
+			g.SetInt(tag.OrderID, 10+i)
+			g.SetString(tag.ClOrdID, clOrdID)
+			// The orders are known once the list is acked.
+			x.c.deliverOrder(Order{Symbol: "BTCUSDT", ClientOrderID: clOrdID, Status: OrderStatusNew})
+		}
+		status.Body.SetGroup(group)
+		x.lists = append(x.lists, ids)
+		x.listMsgs = append(x.listMsgs, msg)
+		return status, nil
+	}
+	return nil, nil
+}
+
+func (x *bracketExchange) fill(cumQty float64, status OrderStatus) {
+	x.mu.Lock()
+	entry := x.entry
+	x.cumQty[entry] = cumQty
+	x.mu.Unlock()
+
+	x.c.deliverOrder(Order{
+		Symbol:        "BTCUSDT",
+		ClientOrderID: entry,
+		Status:        status,
+		ExecType:      ExecTypeTrade,
+		CumQty:        cumQty,
+	})
+}
+
+// exitQty returns the quantity of every exit list placed.
+func (x *bracketExchange) exitQty() []float64 {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	qty := make([]float64, 0, len(x.lists))
+	for _, list := range x.lists {
+		qty = append(qty, x.qty[list[0]])
+	}
+	return qty
+}
+
+func placeTestBracket(t *testing.T, x *bracketExchange) *Bracket {
+	t.Helper()
+	b, err := x.c.PlaceBracket(context.Background(), BracketSpec{
+		Symbol:          "BTCUSDT",
+		Side:            enum.Side_BUY,
+		Quantity:        1,
+		EntryPrice:      100,
+		TakeProfitPrice: 110,
+		StopLossPrice:   90,
+	})
+	require.NoError(t, err)
+	return b
+}
+
+func TestBracketResizesExits(t *testing.T) {
+	x := newBracketExchange()
+	b := placeTestBracket(t, x)
+
+	x.fill(0.4, OrderStatusPartiallyFilled)
+	require.Eventually(t, func() bool { return len(x.exitQty()) == 1 }, time.Second, time.Millisecond)
+	first := b.State().ExitListID
+
+	x.fill(0.7, OrderStatusPartiallyFilled)
+	require.Eventually(t, func() bool { return len(x.exitQty()) == 2 }, time.Second, time.Millisecond)
+
+	// The first list was canceled before the one covering both fills was placed.
+	x.mu.Lock()
+	_, canceled := x.canceled[x.lists[0][0]]
+	x.mu.Unlock()
+	assert.True(t, canceled)
+	assert.Equal(t, []float64{0.4, 0.7}, x.exitQty())
+	require.Eventually(t, func() bool { return b.State().ProtectedQty == 0.7 }, time.Second, time.Millisecond)
+	assert.NotEqual(t, first, b.State().ExitListID)
+
+	x.fill(1, OrderStatusFilled)
+	<-b.Done()
+	state := b.State()
+	assert.NoError(t, state.Err)
+	assert.Equal(t, 1.0, state.ProtectedQty)
+	assert.Equal(t, []float64{0.4, 0.7, 1}, x.exitQty())
+}
+
+func TestBracketResizeKeepsClosedQty(t *testing.T) {
+	x := newBracketExchange()
+	b := placeTestBracket(t, x)
+
+	x.fill(0.4, OrderStatusPartiallyFilled)
+	require.Eventually(t, func() bool { return len(x.exitQty()) == 1 }, time.Second, time.Millisecond)
+
+	// The take-profit closed part of the first fill before being replaced.
+	x.mu.Lock()
+	x.cumQty[x.lists[0][0]] = 0.1
+	x.mu.Unlock()
+
+	x.fill(1, OrderStatusFilled)
+	<-b.Done()
+	assert.Equal(t, []float64{0.4, 0.9}, x.exitQty())
+	assert.Equal(t, 1.0, b.State().ProtectedQty)
+}
+
+func TestBracketCancelProtectsLastFill(t *testing.T) {
+	x := newBracketExchange()
+	b := placeTestBracket(t, x)
+
+	x.fill(0.4, OrderStatusPartiallyFilled)
+	require.Eventually(t, func() bool { return len(x.exitQty()) == 1 }, time.Second, time.Millisecond)
+
+	// The entry filled more right before being canceled.
+	x.mu.Lock()
+	x.cumQty[x.entry] = 0.6
+	x.mu.Unlock()
+
+	require.NoError(t, b.Cancel(context.Background()))
+	state := b.State()
+	assert.True(t, state.Done)
+	assert.NoError(t, state.Err)
+	assert.Equal(t, 0.6, state.ProtectedQty)
+	assert.Equal(t, []float64{0.4, 0.6}, x.exitQty())
+
+	// The entry was canceled first and the working exit list left alone.
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	assert.Equal(t, []string{
+		string(enum.MsgType_ORDER_SINGLE),
+		string(enum.MsgType_ORDER_LIST),
+		string(enum.MsgType_ORDER_CANCEL_REQUEST),
+		string(enum.MsgType_ORDER_CANCEL_REQUEST),
+		string(enum.MsgType_ORDER_LIST),
+	}, x.requests)
+	assert.NotContains(t, x.canceled, x.lists[1][0])
+	assert.Contains(t, x.canceled, x.entry)
+}
+
+func TestBracketExitLegs(t *testing.T) {
+	x := newBracketExchange()
+	b := placeTestBracket(t, x)
+
+	x.fill(0.3, OrderStatusPartiallyFilled)
+	require.Eventually(t, func() bool { return len(x.exitQty()) == 1 }, time.Second, time.Millisecond)
+
+	// The take-profit closed part of the fill before being replaced, leaving
+	// a quantity which float subtraction would not round trip.
+	x.mu.Lock()
+	x.cumQty[x.lists[0][0]] = 0.1
+	x.mu.Unlock()
+
+	x.fill(0.4, OrderStatusCanceled)
+	<-b.Done()
+
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	require.Len(t, x.listMsgs, 2)
+	legs := listLegs(t, x.listMsgs[1])
+	require.Len(t, legs, 2)
+
+	delete(legs[0], tag.ClOrdID)
+	assert.Equal(t, map[quickfix.Tag]string{
+		tag.Symbol:                              "BTCUSDT",
+		tag.Side:                                string(enum.Side_SELL),
+		tag.OrdType:                             string(enum.OrdType_LIMIT),
+		tag.ExecInst:                            execInstParticipateDontInitiate,
+		tag.OrderQty:                            "0.3",
+		tag.Price:                               "110",
+		binancetag.NoListTriggeringInstructions: "2/1/2 ",
+	}, legs[0])
+
+	delete(legs[1], tag.ClOrdID)
+	assert.Equal(t, map[quickfix.Tag]string{
+		tag.Symbol:                              "BTCUSDT",
+		tag.Side:                                string(enum.Side_SELL),
+		tag.OrdType:                             string(enum.OrdType_STOP),
+		tag.OrderQty:                            "0.3",
+		tag.TriggerType:                         triggerTypePriceMovement,
+		tag.TriggerAction:                       triggerActionActivate,
+		tag.TriggerPrice:                        "90",
+		tag.TriggerPriceType:                    triggerPriceTypeLastTrade,
+		tag.TriggerPriceDirection:               string(TriggerDirectionDown),
+		binancetag.NoListTriggeringInstructions: "2/0/2 ",
+	}, legs[1])
+}
@@ -0,0 +1,112 @@
+package fix
+
+import (
+	"sync"
+	"time"
+
+	"github.com/quickfixgo/enum"
+)
+
+// orderMsgTypes are the messages counted against the order limit.
+var orderMsgTypes = map[enum.MsgType]bool{
+	enum.MsgType_ORDER_SINGLE:    true,
+	enum.MsgType_ORDER_LIST:      true,
+	msgType_CANCEL_REPLACE_ORDER: true,
+}
+
+var mappedLimitResolution = map[LimitResolution]time.Duration{
+	LimitResolutionSecond: time.Second,
+	LimitResolutionMinute: time.Minute,
+	LimitResolutionHour:   time.Hour,
+	LimitResolutionDay:    24 * time.Hour,
+}
+
+type limitCounter struct {
+	limit       Limit
+	window      time.Duration // 0 if the limit never resets.
+	windowStart time.Time
+	used        int
+}
+
+func (lc *limitCounter) roll(now time.Time) {
+	if lc.window <= 0 {
+		return
+	}
+	if elapsed := now.Sub(lc.windowStart); elapsed >= lc.window {
+		lc.windowStart = lc.windowStart.Add(elapsed.Truncate(lc.window))
+		lc.used = 0
+	}
+}
+
+// limitBudget estimates the remaining rate limits locally from the last
+// LimitResponse and the messages sent since.
+type limitBudget struct {
+	mu       sync.Mutex
+	counters []*limitCounter
+}
+
+func (b *limitBudget) update(resp LimitResponse, now time.Time) {
+	counters := make([]*limitCounter, 0, len(resp.Limits))
+	for _, limit := range resp.Limits {
+		counters = append(counters, &limitCounter{
+			limit:       limit,
+			window:      time.Duration(limit.LimitResetInterval) * mappedLimitResolution[limit.LimitResetIntervalResolution],
+			windowStart: now,
+			used:        limit.LimitCount,
+		})
+	}
+
+	b.mu.Lock()
+	b.counters = counters
+	b.mu.Unlock()
+}
+
+func (b *limitBudget) recordSent(msgType enum.MsgType, now time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, lc := range b.counters {
+		if lc.limit.LimitType == LimitTypeOrder && !orderMsgTypes[msgType] {
+			continue
+		}
+		lc.roll(now)
+		lc.used++
+	}
+}
+
+// remaining returns the smallest headroom among limits of the given type.
+func (b *limitBudget) remaining(limitType LimitType, now time.Time) (int, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var (
+		remaining int
+		found     bool
+	)
+	for _, lc := range b.counters {
+		if lc.limit.LimitType != limitType {
+			continue
+		}
+		lc.roll(now)
+		left := max(lc.limit.LimitMax-lc.used, 0)
+		if !found || left < remaining {
+			remaining = left
+			found = true
+		}
+	}
+	return remaining, found
+}
+
+// RemainingOrderBudget estimates how many more orders can be placed before
+// hitting the order rate limit. It reports false until the limits have been
+// fetched with NewGetLimitService.
+func (c *Client) RemainingOrderBudget() (int, bool) {
+	return c.budget.remaining(LimitTypeOrder, time.Now())
+}
+
+// RemainingMessageBudget estimates how many more messages can be sent before
+// hitting the message rate limit. It reports false until the limits have been
+// fetched with NewGetLimitService.
+func (c *Client) RemainingMessageBudget() (int, bool) {
+	return c.budget.remaining(LimitTypeMessage, time.Now())
+}
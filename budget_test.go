@@ -0,0 +1,40 @@
+package fix
+
+import (
+	"testing"
+	"time"
+
+	"github.com/quickfixgo/enum"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLimitBudget(t *testing.T) {
+	var b limitBudget
+	now := time.Now()
+
+	_, ok := b.remaining(LimitTypeOrder, now)
+	assert.False(t, ok)
+
+	b.update(LimitResponse{Limits: []Limit{
+		{LimitType: LimitTypeOrder, LimitCount: 2, LimitMax: 10, LimitResetInterval: 10, LimitResetIntervalResolution: LimitResolutionSecond},
+		{LimitType: LimitTypeOrder, LimitCount: 5, LimitMax: 100, LimitResetInterval: 1, LimitResetIntervalResolution: LimitResolutionDay},
+		{LimitType: LimitTypeMessage, LimitCount: 0, LimitMax: 1000, LimitResetInterval: 1, LimitResetIntervalResolution: LimitResolutionMinute},
+	}}, now)
+
+	b.recordSent(enum.MsgType_ORDER_SINGLE, now)
+	b.recordSent(enum.MsgType_HEARTBEAT, now)
+
+	remaining, ok := b.remaining(LimitTypeOrder, now)
+	assert.True(t, ok)
+	assert.Equal(t, 7, remaining)
+
+	remaining, _ = b.remaining(LimitTypeMessage, now)
+	assert.Equal(t, 998, remaining)
+
+	// The 10s window resets, the daily one does not.
+	remaining, _ = b.remaining(LimitTypeOrder, now.Add(11*time.Second))
+	assert.Equal(t, 10, remaining)
+	b.recordSent(enum.MsgType_ORDER_SINGLE, now.Add(11*time.Second))
+	remaining, _ = b.remaining(LimitTypeOrder, now.Add(12*time.Second))
+	assert.Equal(t, 9, remaining)
+}
@@ -0,0 +1,197 @@
+package fix
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/KyberNetwork/binance_fix_api/binancetag"
+	"github.com/google/uuid"
+	"github.com/quickfixgo/enum"
+	"github.com/quickfixgo/field"
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/tag"
+)
+
+/*
+Tag     Name                    Type    Required    Description
+11      ClOrdID                 STRING  Y           ClOrdID of this cancel request.
+41      OrigClOrdID             STRING  N           ClOrdID of the order to cancel.
+37      OrderID                 INT     N           OrderID of the order to cancel.
+55      Symbol                  STRING  Y
+25002   CancelRestrictions      INT     N           1: ONLY_NEW, 2: ONLY_PARTIALLY_FILLED
+Either OrigClOrdID or OrderID must be provided.
+*/
+
+type CancelRestriction int
+
+const (
+	CancelRestrictionOnlyNew             CancelRestriction = 1
+	CancelRestrictionOnlyPartiallyFilled CancelRestriction = 2
+)
+
+// ErrNoOrigOrder is returned when a cancel or an amend names neither the
+// ClOrdID nor the OrderID of the order it applies to.
+var ErrNoOrigOrder = errors.New("request needs an OrigClOrdID or an OrderID")
+
+// OrderCancelService cancels an order with an OrderCancelRequest<F>.
+type OrderCancelService struct {
+	c                  *Client
+	symbol             string
+	origClOrdID        string
+	orderID            *int64
+	cancelRestrictions *CancelRestriction
+	fields             customFields
+}
+
+func (c *Client) NewOrderCancelService() *OrderCancelService {
+	return &OrderCancelService{c: c}
+}
+
+// Symbol set symbol
+func (s *OrderCancelService) Symbol(symbol string) *OrderCancelService {
+	s.symbol = symbol
+	return s
+}
+
+// OrigClientOrderID set the ClOrdID of the order to cancel
+func (s *OrderCancelService) OrigClientOrderID(clOrdID string) *OrderCancelService {
+	s.origClOrdID = clOrdID
+	return s
+}
+
+// OrderID set the OrderID of the order to cancel
+func (s *OrderCancelService) OrderID(orderID int64) *OrderCancelService {
+	s.orderID = &orderID
+	return s
+}
+
+// CancelRestrictions only cancels the order if it is in the given state
+func (s *OrderCancelService) CancelRestrictions(r CancelRestriction) *OrderCancelService {
+	s.cancelRestrictions = &r
+	return s
+}
+
+// SetField sets any body tag, e.g. one newly added by Binance.
+func (s *OrderCancelService) SetField(t quickfix.Tag, value string) *OrderCancelService {
+	s.fields.set(t, value)
+	return s
+}
+
+// Do sends the cancel and returns the execution report of the canceled
+// order. A reject is returned as a *CancelRejectedError.
+func (s *OrderCancelService) Do(ctx context.Context) (Order, error) {
+	if s.origClOrdID == "" && s.orderID == nil {
+		return Order{}, ErrNoOrigOrder
+	}
+	if s.symbol == "" {
+		return Order{}, invalidOrder("Symbol is required")
+	}
+
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return Order{}, err
+	}
+
+	msg := quickfix.NewMessage()
+	msg.Header.Set(field.NewMsgType(enum.MsgType_ORDER_CANCEL_REQUEST))
+	msg.Body.Set(field.NewClOrdID(id.String()))
+	if s.origClOrdID != "" {
+		msg.Body.Set(field.NewOrigClOrdID(s.origClOrdID))
+	}
+	if s.orderID != nil {
+		msg.Body.SetString(tag.OrderID, strconv.FormatInt(*s.orderID, 10))
+	}
+	msg.Body.Set(field.NewSymbol(s.symbol))
+	if s.cancelRestrictions != nil {
+		msg.Body.SetInt(binancetag.CancelRestrictions, int(*s.cancelRestrictions))
+	}
+	s.fields.apply(msg)
+
+	l := s.c.logger(ctx)
+	resp, err := s.c.Call(ctx, id.String(), msg)
+	if err != nil {
+		l.Errorw("Failed to cancel order", "request", msg, "err", err)
+		return Order{}, err
+	}
+
+	order, err := decodeCancelResponse(resp)
+	if err != nil {
+		return Order{}, s.c.escalateDecode("decode cancel response", err)
+	}
+	s.c.attachRaw(&order, resp)
+	order.Metadata = MetadataFromContext(ctx)
+
+	return order, nil
+}
+
+// DoAsync sends the request like Do, without waiting for the response.
+func (s *OrderCancelService) DoAsync(ctx context.Context) *Future[Order] {
+	return doAsync(ctx, s.Do)
+}
+
+// cancelOrder cancels the order placed with origClOrdID.
+func (c *Client) cancelOrder(ctx context.Context, symbol, origClOrdID string) (Order, error) {
+	return c.NewOrderCancelService().Symbol(symbol).OrigClientOrderID(origClOrdID).Do(ctx)
+}
+
+// decodeCancelResponse decodes the answer to a cancel, an ExecutionReport<8>
+// or an OrderCancelReject<9> returned as a *CancelRejectedError.
+func decodeCancelResponse(resp *quickfix.Message) (Order, error) {
+	if resp.IsMsgTypeOf(string(enum.MsgType_ORDER_CANCEL_REJECT)) {
+		rejErr, err := decodeCancelReject(resp)
+		if err != nil {
+			return Order{}, newMessageError(err, resp)
+		}
+		return Order{}, rejErr
+	}
+	order, err := decodeExecutionReport(resp)
+	if err != nil {
+		return order, newMessageError(err, resp)
+	}
+	return order, nil
+}
+
+func decodeCancelReject(msg *quickfix.Message) (*CancelRejectedError, error) {
+	var (
+		e   = CancelRejectedError{CxlRejReason: -1}
+		err error
+	)
+
+	if e.ClOrdID, err = binancetag.GetString(msg, tag.ClOrdID); err != nil {
+		return nil, err
+	}
+	if e.OrigClOrdID, err = binancetag.GetString(msg, tag.OrigClOrdID); err != nil {
+		return nil, err
+	}
+	if msg.Body.Has(tag.OrderID) {
+		orderID, err := binancetag.GetString(msg, tag.OrderID)
+		if err != nil {
+			return nil, err
+		}
+		if e.OrderID, err = strconv.ParseInt(orderID, 10, 64); err != nil {
+			return nil, err
+		}
+	}
+	if e.Symbol, err = binancetag.GetString(msg, tag.Symbol); err != nil {
+		return nil, err
+	}
+	responseTo, err := binancetag.GetString(msg, tag.CxlRejResponseTo)
+	if err != nil {
+		return nil, err
+	}
+	e.CxlRejResponseTo = enum.CxlRejResponseTo(responseTo)
+	if msg.Body.Has(tag.CxlRejReason) {
+		if e.CxlRejReason, err = binancetag.GetInt(msg, tag.CxlRejReason); err != nil {
+			return nil, err
+		}
+	}
+	if e.ErrorCode, err = binancetag.GetErrorCode(msg); err != nil {
+		return nil, err
+	}
+	if e.Text, err = getText(msg); err != nil {
+		return nil, err
+	}
+
+	return &e, nil
+}
@@ -0,0 +1,61 @@
+package fix
+
+import (
+	"context"
+)
+
+// CancelAllResult is the outcome of CancelAllOpenOrders.
+type CancelAllResult struct {
+	// TotalAffectedOrders reported by the server, which includes the orders
+	// placed by other sessions.
+	TotalAffectedOrders int
+	// Canceled holds the ClOrdID<11> of the tracked orders confirmed
+	// canceled.
+	Canceled []string
+	// StillOpen holds the tracked orders of the symbol which are not
+	// confirmed done, e.g. because the cancel raced with a new order.
+	StillOpen []Order
+}
+
+// CancelAllOpenOrders cancels every open order of symbol with a mass cancel,
+// then waits for the execution reports of the orders the tracker knew as open
+// to confirm which ones were canceled. Orders which filled or expired before
+// the cancel applied are neither canceled nor still open.
+func (c *Client) CancelAllOpenOrders(ctx context.Context, symbol string) (CancelAllResult, error) {
+	report, canceled, err := c.NewMassCancelService().Symbol(symbol).do(ctx)
+	if err != nil {
+		return CancelAllResult{}, err
+	}
+
+	return CancelAllResult{
+		TotalAffectedOrders: report.TotalAffectedOrders,
+		Canceled:            canceled,
+		StillOpen:           report.StillOpen,
+	}, ctx.Err()
+}
+
+// awaitDone returns the latest state of the order of w, and whether it is
+// terminal, once it is or ctx is done.
+func (c *Client) awaitDone(ctx context.Context, w *orderWatch) (Order, bool) {
+	o, _ := c.tracker.Order(w.clOrdID)
+	for !o.Status.IsTerminal() {
+		next, err := w.next(ctx)
+		if err != nil {
+			if latest, ok := c.tracker.Order(w.clOrdID); ok {
+				o = latest
+			}
+			return o, o.Status.IsTerminal()
+		}
+		o = next
+	}
+	return o, true
+}
+
+func watched(watches []*orderWatch, clOrdID string) bool {
+	for _, w := range watches {
+		if w.clOrdID == clOrdID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,85 @@
+package fix
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/quickfixgo/enum"
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/tag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAwaitDone(t *testing.T) {
+	c := &Client{tracker: NewOrderTracker()}
+	c.tracker.Apply(OrderEvent{Order: Order{ClientOrderID: "a", Status: OrderStatusNew}})
+
+	w := c.watchers.add("a")
+	defer c.watchers.remove(w)
+	go c.watchers.notify(Order{ClientOrderID: "a", Status: OrderStatusCanceled})
+
+	o, done := c.awaitDone(context.Background(), w)
+	assert.True(t, done)
+	assert.Equal(t, OrderStatusCanceled, o.Status)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	o, done = c.awaitDone(ctx, w)
+	assert.False(t, done)
+	assert.Equal(t, OrderStatusNew, o.Status)
+}
+
+func TestCancelAllOpenOrders(t *testing.T) {
+	g := newTestGateway(t)
+	var clOrdIDs []string
+	g.handle(func(msg *quickfix.Message, sessionID quickfix.SessionID) {
+		clOrdID, _ := msg.Body.GetString(tag.ClOrdID)
+		switch {
+		case msg.IsMsgTypeOf(string(enum.MsgType_ORDER_SINGLE)):
+			clOrdIDs = append(clOrdIDs, clOrdID)
+			report := newTestReport(clOrdID, enum.OrdStatus_NEW)
+			report.Body.SetString(tag.ExecType, string(enum.ExecType_NEW))
+			g.reply(t, sessionID, report)
+		case msg.IsMsgTypeOf(string(enum.MsgType_ORDER_MASS_CANCEL_REQUEST)):
+			report := newTestMessage(enum.MsgType_ORDER_MASS_CANCEL_REPORT)
+			report.Body.SetString(tag.ClOrdID, clOrdID)
+			report.Body.SetString(tag.Symbol, "BTCUSDT")
+			report.Body.SetString(tag.MassCancelResponse, "1")
+			report.Body.SetInt(tag.TotalAffectedOrders, len(clOrdIDs))
+			g.reply(t, sessionID, report)
+
+			// The reports of the canceled orders carry the ClOrdID of the
+			// mass cancel.
+			for _, orig := range clOrdIDs {
+				canceled := newTestReport(clOrdID, enum.OrdStatus_CANCELED)
+				canceled.Body.SetString(tag.ExecType, string(enum.ExecType_CANCELED))
+				canceled.Body.SetString(tag.OrigClOrdID, orig)
+				g.reply(t, sessionID, canceled)
+			}
+		}
+	})
+	c := g.startClient(t)
+
+	ctx := context.Background()
+	for range 2 {
+		_, err := c.NewOrderSingleService().
+			Symbol("BTCUSDT").
+			Side(enum.Side_BUY).
+			Type(enum.OrdType_LIMIT).
+			Quantity(1).
+			Price(1).
+			TimeInForce(enum.TimeInForce_GOOD_TILL_CANCEL).
+			Do(ctx)
+		require.NoError(t, err)
+	}
+	require.Len(t, c.Tracker().OpenOrders(), 2)
+
+	res, err := c.CancelAllOpenOrders(ctx, "BTCUSDT")
+	require.NoError(t, err)
+	assert.Equal(t, 2, res.TotalAffectedOrders)
+	assert.ElementsMatch(t, clOrdIDs, res.Canceled)
+	assert.Empty(t, res.StillOpen)
+	assert.Empty(t, c.Tracker().OpenOrders())
+}
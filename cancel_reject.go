@@ -0,0 +1,157 @@
+package fix
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/quickfixgo/enum"
+)
+
+// Binance error codes relevant to cancels.
+const (
+	errorCodeTooManyRequests = -1003
+	errorCodeTooManyOrders   = -1015
+	errorCodeCancelRejected  = -2011
+)
+
+// CxlRejReason<102> values.
+const (
+	cxlRejReasonTooLateToCancel = 0
+	cxlRejReasonUnknownOrder    = 1
+)
+
+/*
+OrderCancelReject<9> is sent by the server when a cancel request fails.
+
+Tag     Name                    Type    Required    Description
+11      ClOrdID                 STRING  Y           ClOrdID of the cancel request.
+41      OrigClOrdID             STRING  N
+37      OrderID                 INT     N
+55      Symbol                  STRING  Y
+25002   CancelRestrictions      INT     N
+434     CxlRejResponseTo        CHAR    Y           1: ORDER_CANCEL_REQUEST, 2: ORDER_CANCEL_REPLACE_REQUEST
+102     CxlRejReason            INT     N           0: TOO_LATE_TO_CANCEL, 1: UNKNOWN_ORDER, 99: OTHER
+25016   ErrorCode               INT     N
+58      Text                    STRING  N
+*/
+
+// CancelRejectedError is returned when the server rejects a cancel request.
+type CancelRejectedError struct {
+	ClOrdID     string
+	OrigClOrdID string
+	OrderID     int64 // 0 if absent.
+	Symbol      string
+	// CxlRejResponseTo<434> tells whether a cancel or a cancel-replace was
+	// rejected.
+	CxlRejResponseTo enum.CxlRejResponseTo
+	CxlRejReason     int // CxlRejReason<102>, -1 if absent.
+	ErrorCode        int // Binance ErrorCode<25016>, 0 if absent.
+	Text             string
+}
+
+func (e *CancelRejectedError) Error() string {
+	return "cancel rejected: " + e.Text + " (code " + strconv.Itoa(e.ErrorCode) + ")"
+}
+
+type CancelRejectCategory string
+
+const (
+	CancelRejectUnknownOrder  CancelRejectCategory = "UNKNOWN_ORDER"
+	CancelRejectAlreadyFilled CancelRejectCategory = "ALREADY_FILLED"
+	CancelRejectTooLate       CancelRejectCategory = "TOO_LATE"
+	CancelRejectRateLimited   CancelRejectCategory = "RATE_LIMITED"
+	CancelRejectOther         CancelRejectCategory = "OTHER"
+)
+
+// Retryable reports whether sending the same cancel again may succeed.
+// Only rate limited cancels qualify: every other category means the order
+// cannot be canceled anymore, or never existed.
+func (c CancelRejectCategory) Retryable() bool {
+	return c == CancelRejectRateLimited
+}
+
+// Category classifies the reject from its error code, reason and text.
+func (e *CancelRejectedError) Category() CancelRejectCategory {
+	text := strings.ToLower(e.Text)
+	switch {
+	case e.ErrorCode == errorCodeTooManyRequests || e.ErrorCode == errorCodeTooManyOrders ||
+		strings.Contains(text, "too many"):
+		return CancelRejectRateLimited
+	case strings.Contains(text, "filled"):
+		return CancelRejectAlreadyFilled
+	case e.CxlRejReason == cxlRejReasonTooLateToCancel:
+		return CancelRejectTooLate
+	case e.CxlRejReason == cxlRejReasonUnknownOrder || e.ErrorCode == errorCodeCancelRejected:
+		return CancelRejectUnknownOrder
+	default:
+		return CancelRejectOther
+	}
+}
+
+// ClassifyCancelError returns the category of a cancel reject wrapped in err,
+// and false if err is not a cancel reject.
+func ClassifyCancelError(err error) (CancelRejectCategory, bool) {
+	var rejErr *CancelRejectedError
+	if !errors.As(err, &rejErr) {
+		return "", false
+	}
+	return rejErr.Category(), true
+}
+
+type CancelRetryPolicy struct {
+	MaxAttempts int           // Including the first one, defaults to 3.
+	Backoff     time.Duration // Wait before the first retry, doubled after each.
+	// RetryIf also retries the rejects it returns true for, on top of the
+	// retryable ones, e.g. RetryUnknownOrder.
+	RetryIf func(*CancelRejectedError) bool
+}
+
+// RetryUnknownOrder retries UNKNOWN_ORDER rejects. It suits cancels sent
+// right after the order, which the server may not know yet.
+func RetryUnknownOrder(e *CancelRejectedError) bool {
+	return e.Category() == CancelRejectUnknownOrder
+}
+
+// RetryCancel calls cancel until it succeeds, returns an error which is not a
+// retryable cancel reject, or the attempts are exhausted. The last error is
+// returned.
+func RetryCancel(ctx context.Context, policy CancelRetryPolicy, cancel func(ctx context.Context) error) error {
+	attempts := policy.MaxAttempts
+	if attempts <= 0 {
+		attempts = 3
+	}
+	backoff := policy.Backoff
+
+	var err error
+	for i := 0; i < attempts; i++ {
+		if i > 0 {
+			select {
+			case <-time.After(backoff):
+			case <-ctx.Done():
+				return err
+			}
+			backoff *= 2
+		}
+
+		err = cancel(ctx)
+		if err == nil {
+			return nil
+		}
+		if !policy.retry(err) {
+			return err
+		}
+	}
+	return err
+}
+
+// retry reports whether the cancel failing with err is sent again.
+func (p CancelRetryPolicy) retry(err error) bool {
+	var rejErr *CancelRejectedError
+	if !errors.As(err, &rejErr) {
+		return false
+	}
+	return rejErr.Category().Retryable() || (p.RetryIf != nil && p.RetryIf(rejErr))
+}
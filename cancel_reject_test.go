@@ -0,0 +1,147 @@
+package fix
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/KyberNetwork/binance_fix_api/binancetag"
+	"github.com/quickfixgo/enum"
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/tag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestCancelReject returns the UNKNOWN_ORDER reject of the cancel clOrdID.
+func newTestCancelReject(clOrdID, origClOrdID string) *quickfix.Message {
+	msg := newTestMessage(enum.MsgType_ORDER_CANCEL_REJECT)
+	msg.Body.SetString(tag.ClOrdID, clOrdID)
+	msg.Body.SetString(tag.OrigClOrdID, origClOrdID)
+	msg.Body.SetString(tag.CxlRejResponseTo, string(enum.CxlRejResponseTo_ORDER_CANCEL_REQUEST))
+	msg.Body.SetInt(tag.CxlRejReason, cxlRejReasonUnknownOrder)
+	msg.Body.SetInt(binancetag.ErrorCode, errorCodeCancelRejected)
+	msg.Body.SetString(tag.Text, "Unknown order sent.")
+	return msg
+}
+
+// lateOrders answers the first cancel of every order with an UNKNOWN_ORDER
+// reject, as if the order had not reached the server yet, and cancels the
+// order on the next attempt.
+type lateOrders struct {
+	mu       sync.Mutex
+	attempts map[string]int // By OrigClOrdID.
+}
+
+func (o *lateOrders) cancel(id string, msg *quickfix.Message) *quickfix.Message {
+	orig, _ := msg.Body.GetString(tag.OrigClOrdID)
+	o.mu.Lock()
+	if o.attempts == nil {
+		o.attempts = make(map[string]int)
+	}
+	o.attempts[orig]++
+	attempts := o.attempts[orig]
+	o.mu.Unlock()
+
+	if attempts == 1 {
+		return newTestCancelReject(id, orig)
+	}
+	report := newTestReport(id, enum.OrdStatus_CANCELED)
+	report.Body.SetString(tag.OrigClOrdID, orig)
+	report.Body.SetString(tag.ExecType, string(enum.ExecType_CANCELED))
+	return report
+}
+
+// attemptsOf returns the number of cancels of the order origClOrdID.
+func (o *lateOrders) attemptsOf(origClOrdID string) int {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.attempts[origClOrdID]
+}
+
+func TestCancelRejectCategory(t *testing.T) {
+	tests := []struct {
+		err  CancelRejectedError
+		want CancelRejectCategory
+	}{
+		{CancelRejectedError{CxlRejReason: 99, ErrorCode: errorCodeTooManyOrders}, CancelRejectRateLimited},
+		{CancelRejectedError{CxlRejReason: 1, ErrorCode: errorCodeCancelRejected, Text: "Unknown order sent."}, CancelRejectUnknownOrder},
+		{CancelRejectedError{CxlRejReason: 0, Text: "Order was already filled."}, CancelRejectAlreadyFilled},
+		{CancelRejectedError{CxlRejReason: 0}, CancelRejectTooLate},
+		{CancelRejectedError{CxlRejReason: 99}, CancelRejectOther},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, tt.err.Category(), tt.err.Text)
+	}
+}
+
+func TestRetryCancel(t *testing.T) {
+	rateLimited := &CancelRejectedError{CxlRejReason: 99, ErrorCode: errorCodeTooManyRequests}
+	unknown := &CancelRejectedError{CxlRejReason: 1}
+
+	calls := 0
+	err := RetryCancel(context.Background(), CancelRetryPolicy{MaxAttempts: 3}, func(context.Context) error {
+		calls++
+		if calls < 2 {
+			return rateLimited
+		}
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, calls)
+
+	calls = 0
+	err = RetryCancel(context.Background(), CancelRetryPolicy{MaxAttempts: 3}, func(context.Context) error {
+		calls++
+		return unknown
+	})
+	assert.True(t, errors.Is(err, unknown))
+	assert.Equal(t, 1, calls)
+
+	// An order just placed may not be known yet.
+	calls = 0
+	err = RetryCancel(context.Background(), CancelRetryPolicy{MaxAttempts: 3, RetryIf: RetryUnknownOrder}, func(context.Context) error {
+		calls++
+		if calls < 2 {
+			return unknown
+		}
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, calls)
+
+	calls = 0
+	err = RetryCancel(context.Background(), CancelRetryPolicy{MaxAttempts: 3, RetryIf: RetryUnknownOrder}, func(context.Context) error {
+		calls++
+		return unknown
+	})
+	assert.True(t, errors.Is(err, unknown))
+	assert.Equal(t, 3, calls)
+}
+
+func TestDecodeCancelReject(t *testing.T) {
+	msg := newTestMessage(enum.MsgType_ORDER_CANCEL_REJECT)
+	msg.Body.SetString(tag.ClOrdID, "cancel")
+	msg.Body.SetString(tag.OrigClOrdID, "order")
+	msg.Body.SetString(tag.OrderID, "9000000001")
+	msg.Body.SetString(tag.Symbol, "BTCUSDT")
+	msg.Body.SetString(tag.CxlRejResponseTo, string(enum.CxlRejResponseTo_ORDER_CANCEL_REQUEST))
+	msg.Body.SetInt(tag.CxlRejReason, cxlRejReasonUnknownOrder)
+	msg.Body.SetInt(binancetag.ErrorCode, errorCodeCancelRejected)
+	msg.Body.SetString(tag.Text, "Unknown order sent.")
+
+	rejErr, err := decodeCancelReject(msg)
+	require.NoError(t, err)
+	assert.Equal(t, CancelRejectedError{
+		ClOrdID:          "cancel",
+		OrigClOrdID:      "order",
+		OrderID:          9000000001,
+		Symbol:           "BTCUSDT",
+		CxlRejResponseTo: enum.CxlRejResponseTo_ORDER_CANCEL_REQUEST,
+		CxlRejReason:     cxlRejReasonUnknownOrder,
+		ErrorCode:        errorCodeCancelRejected,
+		Text:             "Unknown order sent.",
+	}, *rejErr)
+	assert.Equal(t, CancelRejectUnknownOrder, rejErr.Category())
+}
@@ -0,0 +1,244 @@
+package fix
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/KyberNetwork/binance_fix_api/binancetag"
+	"github.com/google/uuid"
+	"github.com/quickfixgo/field"
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/tag"
+)
+
+/*
+Tag     Name                                    Type    Required    Description
+25033   OrderCancelRequestAndNewOrderSingleMode INT     Y           1: STOP_ON_FAILURE, 2: ALLOW_FAILURE
+25034   CancelClOrdID                           STRING  N           ClOrdID of the cancel.
+41      OrigClOrdID                             STRING  N           ClOrdID of the order to cancel.
+37      OrderID                                 INT     N           OrderID of the order to cancel.
+25002   CancelRestrictions                      INT     N           1: ONLY_NEW, 2: ONLY_PARTIALLY_FILLED
+11      ClOrdID                                 STRING  Y           ClOrdID of the new order.
+The new order takes every field of NewOrderSingle<D>.
+*/
+
+type CancelReplaceMode int
+
+const (
+	// CancelReplaceModeStopOnFailure does not place the new order if the
+	// cancel fails.
+	CancelReplaceModeStopOnFailure CancelReplaceMode = 1
+	// CancelReplaceModeAllowFailure places the new order even if the cancel
+	// fails.
+	CancelReplaceModeAllowFailure CancelReplaceMode = 2
+)
+
+// ErrNewOrderNotAttempted is the NewErr of a cancel-replace whose new order
+// was not placed because the cancel failed.
+var ErrNewOrderNotAttempted = errors.New("new order not attempted")
+
+// CancelReplaceResult holds the outcome of both halves of a cancel-replace.
+type CancelReplaceResult struct {
+	Canceled  Order // Execution report of the canceled order.
+	CancelErr error // A *CancelRejectedError if the cancel was rejected.
+	New       Order // Execution report of the new order.
+	NewErr    error
+}
+
+// CancelReplaceService atomically cancels an order and places a new one with
+// an OrderCancelRequestAndNewOrderSingle<XCN>.
+type CancelReplaceService struct {
+	c                  *Client
+	mode               CancelReplaceMode
+	origClOrdID        string
+	orderID            *int64
+	cancelRestrictions *CancelRestriction
+	order              *NewOrderSingleService
+	fields             customFields
+}
+
+func (c *Client) NewCancelReplaceService() *CancelReplaceService {
+	return &CancelReplaceService{c: c, mode: CancelReplaceModeStopOnFailure}
+}
+
+// Mode set what happens to the new order if the cancel fails, default is
+// CancelReplaceModeStopOnFailure
+func (s *CancelReplaceService) Mode(mode CancelReplaceMode) *CancelReplaceService {
+	s.mode = mode
+	return s
+}
+
+// OrigClientOrderID set the ClOrdID of the order to cancel
+func (s *CancelReplaceService) OrigClientOrderID(clOrdID string) *CancelReplaceService {
+	s.origClOrdID = clOrdID
+	return s
+}
+
+// OrderID set the OrderID of the order to cancel
+func (s *CancelReplaceService) OrderID(orderID int64) *CancelReplaceService {
+	s.orderID = &orderID
+	return s
+}
+
+// CancelRestrictions only cancels the order if it is in the given state
+func (s *CancelReplaceService) CancelRestrictions(r CancelRestriction) *CancelReplaceService {
+	s.cancelRestrictions = &r
+	return s
+}
+
+// NewOrder set the order placed in place of the canceled one. It is built
+// with Client.NewOrderSingleService, and its symbol is also the symbol of
+// the canceled order.
+func (s *CancelReplaceService) NewOrder(order *NewOrderSingleService) *CancelReplaceService {
+	s.order = order
+	return s
+}
+
+// SetField sets any body tag, e.g. one newly added by Binance. Fields set on
+// the new order with its own SetField are sent too.
+func (s *CancelReplaceService) SetField(t quickfix.Tag, value string) *CancelReplaceService {
+	s.fields.set(t, value)
+	return s
+}
+
+// Do sends the cancel-replace and waits for both halves. The error is
+// CancelErr, or NewErr if the cancel succeeded, or the error failing the
+// request as a whole.
+func (s *CancelReplaceService) Do(ctx context.Context) (CancelReplaceResult, error) {
+	if s.origClOrdID == "" && s.orderID == nil {
+		return CancelReplaceResult{}, ErrNoOrigOrder
+	}
+	if s.order == nil {
+		return CancelReplaceResult{}, errors.New("cancel-replace needs a new order")
+	}
+	if err := s.order.validate(); err != nil {
+		return CancelReplaceResult{}, err
+	}
+
+	cancelID, err := uuid.NewRandom()
+	if err != nil {
+		return CancelReplaceResult{}, err
+	}
+	newID, err := s.order.clientOrderID()
+	if err != nil {
+		return CancelReplaceResult{}, err
+	}
+
+	msg := quickfix.NewMessage()
+	msg.Header.Set(field.NewMsgType(msgType_CANCEL_REPLACE_ORDER))
+	msg.Body.SetInt(binancetag.OrderCancelRequestAndNewOrderSingleMode, int(s.mode))
+	msg.Body.SetString(binancetag.CancelClOrdID, cancelID.String())
+	if s.origClOrdID != "" {
+		msg.Body.Set(field.NewOrigClOrdID(s.origClOrdID))
+	}
+	if s.orderID != nil {
+		msg.Body.SetString(tag.OrderID, strconv.FormatInt(*s.orderID, 10))
+	}
+	if s.cancelRestrictions != nil {
+		msg.Body.SetInt(binancetag.CancelRestrictions, int(*s.cancelRestrictions))
+	}
+	msg.Body.Set(field.NewClOrdID(newID))
+	s.order.setOrderFields(msg)
+	s.fields.apply(msg)
+
+	if _, ok := ctx.Deadline(); !ok && s.c.options.callTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.c.options.callTimeout)
+		defer cancel()
+	}
+	// Stops waiting for one half once the other tells it will not come.
+	waitCtx, stopWaiting := context.WithCancel(ctx)
+	defer stopWaiting()
+
+	l := s.c.logger(ctx)
+	cancelCall := s.c.expect(l, cancelID.String(), msg)
+	defer s.c.forgetCall(cancelID.String(), cancelCall.call)
+
+	type response struct {
+		msg *quickfix.Message
+		err error
+	}
+	cancelCh := make(chan response, 1)
+	newCh := make(chan response, 1)
+	go func() {
+		resp, err := s.c.Call(waitCtx, newID, msg)
+		newCh <- response{resp, err}
+	}()
+	go func() {
+		resp, err := cancelCall.wait(waitCtx)
+		cancelCh <- response{resp, err}
+	}()
+
+	var (
+		res                      CancelReplaceResult
+		cancelResp, newResp      response
+		cancelDone, newDone      bool
+		cancelFailed, sendFailed bool
+	)
+	for !cancelDone || !newDone {
+		select {
+		case cancelResp = <-cancelCh:
+			cancelDone = true
+			if cancelResp.err == nil {
+				res.Canceled, res.CancelErr = decodeCancelResponse(cancelResp.msg)
+				res.CancelErr = s.c.escalateDecode("decode cancel response", res.CancelErr)
+				if res.CancelErr == nil {
+					s.c.attachRaw(&res.Canceled, cancelResp.msg)
+				}
+			} else {
+				res.CancelErr = cancelResp.err
+			}
+			cancelFailed = res.CancelErr != nil
+			if cancelFailed && s.mode == CancelReplaceModeStopOnFailure && !newDone {
+				stopWaiting()
+			}
+		case newResp = <-newCh:
+			newDone = true
+			if newResp.err == nil {
+				res.New, res.NewErr = decodeNewOrderResponse(newResp.msg)
+				res.NewErr = s.c.escalateDecode("decode execution report", res.NewErr)
+				s.c.attachRaw(&res.New, newResp.msg)
+			} else {
+				res.NewErr = newResp.err
+				// Without a response, the request did not make it.
+				sendFailed = ctx.Err() == nil && waitCtx.Err() == nil
+				if sendFailed && !cancelDone {
+					stopWaiting()
+				}
+			}
+		}
+	}
+
+	switch {
+	case sendFailed:
+		res.CancelErr = res.NewErr
+	case cancelFailed && s.mode == CancelReplaceModeStopOnFailure && errors.Is(res.NewErr, context.Canceled) && ctx.Err() == nil:
+		res.NewErr = ErrNewOrderNotAttempted
+	}
+
+	md := MetadataFromContext(ctx)
+	res.Canceled.Metadata, res.New.Metadata = md, md
+	if res.CancelErr != nil {
+		l.Errorw("Failed to cancel order of cancel-replace", "request", msg, "err", res.CancelErr)
+		return res, res.CancelErr
+	}
+	if res.NewErr != nil {
+		l.Errorw("Failed to place new order of cancel-replace", "request", msg, "err", res.NewErr)
+		return res, res.NewErr
+	}
+	return res, nil
+}
+
+// DoAsync sends the request like Do, without waiting for the response.
+func (s *CancelReplaceService) DoAsync(ctx context.Context) *Future[CancelReplaceResult] {
+	return doAsync(ctx, s.Do)
+}
+
+func decodeNewOrderResponse(resp *quickfix.Message) (Order, error) {
+	order, err := decodeExecutionReport(resp)
+	if err != nil {
+		return order, newMessageError(err, resp)
+	}
+	return order, nil
+}
@@ -0,0 +1,132 @@
+package fix
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/KyberNetwork/binance_fix_api/binancetag"
+	"github.com/quickfixgo/enum"
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/tag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// handleCancelReplace answers every cancel-replace with the report of its
+// cancel, or a reject if rejectCancel is set, then the report of its new
+// order unless the cancel was rejected in STOP_ON_FAILURE mode.
+func handleCancelReplace(t *testing.T, g *testGateway, rejectCancel bool) {
+	g.handle(func(msg *quickfix.Message, sessionID quickfix.SessionID) {
+		if !msg.IsMsgTypeOf(string(msgType_CANCEL_REPLACE_ORDER)) {
+			return
+		}
+		cancelID, _ := msg.Body.GetString(binancetag.CancelClOrdID)
+		origID, _ := msg.Body.GetString(tag.OrigClOrdID)
+		newID, _ := msg.Body.GetString(tag.ClOrdID)
+		mode, _ := msg.Body.GetInt(binancetag.OrderCancelRequestAndNewOrderSingleMode)
+
+		if rejectCancel {
+			reject := newTestMessage(enum.MsgType_ORDER_CANCEL_REJECT)
+			reject.Body.SetString(tag.ClOrdID, cancelID)
+			reject.Body.SetString(tag.OrigClOrdID, origID)
+			reject.Body.SetString(tag.Symbol, "BTCUSDT")
+			reject.Body.SetString(tag.CxlRejResponseTo, string(enum.CxlRejResponseTo_ORDER_CANCEL_REQUEST))
+			reject.Body.SetInt(tag.CxlRejReason, cxlRejReasonUnknownOrder)
+			reject.Body.SetInt(binancetag.ErrorCode, errorCodeCancelRejected)
+			reject.Body.SetString(tag.Text, "Unknown order sent.")
+			g.reply(t, sessionID, reject)
+			if CancelReplaceMode(mode) == CancelReplaceModeStopOnFailure {
+				return
+			}
+		} else {
+			canceled := newTestReport(cancelID, enum.OrdStatus_CANCELED)
+			canceled.Body.SetString(tag.OrigClOrdID, origID)
+			canceled.Body.SetString(tag.ExecType, string(enum.ExecType_CANCELED))
+			g.reply(t, sessionID, canceled)
+		}
+
+		report := newTestReport(newID, enum.OrdStatus_NEW)
+		report.Body.SetString(tag.ExecType, string(enum.ExecType_NEW))
+		g.reply(t, sessionID, report)
+	})
+}
+
+func newTestCancelReplace(c *Client) *CancelReplaceService {
+	return c.NewCancelReplaceService().OrigClientOrderID("order").
+		NewOrder(c.NewOrderSingleService().Symbol("BTCUSDT").Side(enum.Side_BUY).Type(enum.OrdType_MARKET).
+			Quantity(1))
+}
+
+func TestCancelReplace(t *testing.T) {
+	g := newTestGateway(t)
+	handleCancelReplace(t, g, false)
+	c := g.startClient(t)
+
+	res, err := newTestCancelReplace(c).Do(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, OrderStatusCanceled, res.Canceled.Status)
+	assert.NoError(t, res.CancelErr)
+	assert.NotEmpty(t, res.New.ClientOrderID)
+	assert.Equal(t, OrderStatusNew, res.New.Status)
+	assert.NoError(t, res.NewErr)
+}
+
+func TestCancelReplaceCancelRejected(t *testing.T) {
+	g := newTestGateway(t)
+	handleCancelReplace(t, g, true)
+	c := g.startClient(t)
+
+	// The new order is not placed.
+	res, err := newTestCancelReplace(c).Do(context.Background())
+	var rejErr *CancelRejectedError
+	require.ErrorAs(t, err, &rejErr)
+	assert.Equal(t, "order", rejErr.OrigClOrdID)
+	assert.Equal(t, err, res.CancelErr)
+	assert.ErrorIs(t, res.NewErr, ErrNewOrderNotAttempted)
+
+	// The new order is placed anyway.
+	res, err = newTestCancelReplace(c).Mode(CancelReplaceModeAllowFailure).Do(context.Background())
+	require.ErrorAs(t, err, &rejErr)
+	assert.NoError(t, res.NewErr)
+	assert.NotEmpty(t, res.New.ClientOrderID)
+	assert.Equal(t, OrderStatusNew, res.New.Status)
+}
+
+func TestCancelReplaceNeedsOrders(t *testing.T) {
+	c := &Client{}
+	_, err := c.NewCancelReplaceService().NewOrder(c.NewOrderSingleService()).Do(context.Background())
+	assert.ErrorIs(t, err, ErrNoOrigOrder)
+	_, err = c.NewCancelReplaceService().OrigClientOrderID("order").Do(context.Background())
+	assert.Error(t, err)
+}
+
+func TestCancelReplaceSetField(t *testing.T) {
+	const custom, orderCustom quickfix.Tag = 29999, 29998
+
+	g := newTestGateway(t)
+	received := make(chan *quickfix.Message, 1)
+	// The gateway never answers.
+	g.handle(func(msg *quickfix.Message, _ quickfix.SessionID) { received <- msg })
+	c := g.startClient(t, WithCallTimeout(100*time.Millisecond))
+
+	order := c.NewOrderSingleService().Symbol("BTCUSDT").Side(enum.Side_BUY).Type(enum.OrdType_MARKET).Quantity(1).
+		SetField(orderCustom, "order")
+	_, _ = c.NewCancelReplaceService().OrigClientOrderID("order").NewOrder(order).
+		SetField(custom, "cancel-replace").
+		SetField(binancetag.OrderCancelRequestAndNewOrderSingleMode, "2").
+		Do(context.Background())
+	sent := <-received
+
+	// The fields of the new order are sent along the ones of the request,
+	// which override the ones set by the builder.
+	for key, want := range map[quickfix.Tag]string{
+		custom:      "cancel-replace",
+		orderCustom: "order",
+		binancetag.OrderCancelRequestAndNewOrderSingleMode: "2",
+	} {
+		value, err := sent.Body.GetString(key)
+		require.NoError(t, err)
+		assert.Equal(t, want, value)
+	}
+}
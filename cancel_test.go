@@ -0,0 +1,82 @@
+package fix
+
+import (
+	"context"
+	"testing"
+
+	"github.com/KyberNetwork/binance_fix_api/binancetag"
+	"github.com/quickfixgo/enum"
+	"github.com/quickfixgo/field"
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/tag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrderCancelService(t *testing.T) {
+	g := newTestGateway(t)
+	received := make(chan *quickfix.Message, 2)
+	// The gateway cancels the first order and rejects every other cancel.
+	canceled := false
+	g.handle(func(msg *quickfix.Message, sessionID quickfix.SessionID) {
+		received <- msg
+		clOrdID, _ := msg.Body.GetString(tag.ClOrdID)
+		orig, _ := msg.Body.GetString(tag.OrigClOrdID)
+		if !canceled {
+			canceled = true
+			report := newTestReport(clOrdID, enum.OrdStatus_CANCELED)
+			report.Body.SetString(tag.OrigClOrdID, orig)
+			report.Body.SetString(tag.ExecType, string(enum.ExecType_CANCELED))
+			g.reply(t, sessionID, report)
+			return
+		}
+
+		reject := quickfix.NewMessage()
+		reject.Header.Set(field.NewMsgType(enum.MsgType_ORDER_CANCEL_REJECT))
+		reject.Body.SetString(tag.ClOrdID, clOrdID)
+		reject.Body.SetString(tag.OrigClOrdID, orig)
+		reject.Body.SetInt(tag.CxlRejReason, cxlRejReasonUnknownOrder)
+		reject.Body.SetInt(binancetag.ErrorCode, errorCodeCancelRejected)
+		reject.Body.SetString(tag.Text, "Unknown order sent.")
+		g.reply(t, sessionID, reject)
+	})
+	c := g.startClient(t)
+	ctx := context.Background()
+
+	order, err := c.NewOrderCancelService().Symbol("BTCUSDT").OrigClientOrderID("a").OrderID(42).
+		CancelRestrictions(CancelRestrictionOnlyNew).Do(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, OrderStatusCanceled, order.Status)
+
+	msg := <-received
+	assert.True(t, msg.IsMsgTypeOf(string(enum.MsgType_ORDER_CANCEL_REQUEST)))
+	for k, want := range map[quickfix.Tag]string{
+		tag.OrigClOrdID:               "a",
+		tag.OrderID:                   "42",
+		tag.Symbol:                    "BTCUSDT",
+		binancetag.CancelRestrictions: "1",
+	} {
+		value, err := msg.Body.GetString(k)
+		require.NoError(t, err)
+		assert.Equal(t, want, value, "tag %d", k)
+	}
+	clOrdID, err := msg.Body.GetString(tag.ClOrdID)
+	require.NoError(t, err)
+	assert.NotEmpty(t, clOrdID)
+
+	_, err = c.NewOrderCancelService().Symbol("BTCUSDT").OrigClientOrderID("b").Do(ctx)
+	var rejErr *CancelRejectedError
+	require.ErrorAs(t, err, &rejErr)
+	assert.Equal(t, "b", rejErr.OrigClOrdID)
+	assert.Equal(t, cxlRejReasonUnknownOrder, rejErr.CxlRejReason)
+	assert.Equal(t, errorCodeCancelRejected, rejErr.ErrorCode)
+	assert.Equal(t, "Unknown order sent.", rejErr.Text)
+	assert.Equal(t, CancelRejectUnknownOrder, rejErr.Category())
+
+	msg = <-received
+	assert.False(t, msg.Body.Has(tag.OrderID))
+	assert.False(t, msg.Body.Has(binancetag.CancelRestrictions))
+
+	_, err = c.NewOrderCancelService().Symbol("BTCUSDT").Do(ctx)
+	assert.ErrorIs(t, err, ErrNoOrigOrder)
+}
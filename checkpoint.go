@@ -0,0 +1,240 @@
+package fix
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"sort"
+	"sync"
+	"time"
+)
+
+// PendingCall is the minimal state of an in-flight call kept by a
+// CallCheckpoint.
+type PendingCall struct {
+	ID      string // ClOrdID<11> or ReqID<6136> of the request.
+	MsgType string
+	SentAt  time.Time
+}
+
+// CallCheckpoint persists the calls which have been sent but not answered,
+// so that a restarted client knows which requests were outstanding when the
+// previous process died.
+type CallCheckpoint interface {
+	Save(call PendingCall) error
+	Delete(id string) error
+	// Load returns the saved calls which have not been deleted.
+	Load() ([]PendingCall, error)
+}
+
+// MemoryCallCheckpoint keeps calls in memory. It is mostly useful for tests.
+type MemoryCallCheckpoint struct {
+	mu    sync.Mutex
+	calls map[string]PendingCall
+}
+
+func NewMemoryCallCheckpoint() *MemoryCallCheckpoint {
+	return &MemoryCallCheckpoint{calls: make(map[string]PendingCall)}
+}
+
+func (cp *MemoryCallCheckpoint) Save(call PendingCall) error {
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+
+	cp.calls[call.ID] = call
+	return nil
+}
+
+func (cp *MemoryCallCheckpoint) Delete(id string) error {
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+
+	delete(cp.calls, id)
+	return nil
+}
+
+func (cp *MemoryCallCheckpoint) Load() ([]PendingCall, error) {
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+
+	return sortedCalls(cp.calls), nil
+}
+
+func sortedCalls(calls map[string]PendingCall) []PendingCall {
+	out := make([]PendingCall, 0, len(calls))
+	for _, call := range calls {
+		out = append(out, call)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].SentAt.Before(out[j].SentAt) })
+	return out
+}
+
+// checkpointRecord is a line of the FileCallCheckpoint journal.
+type checkpointRecord struct {
+	Deleted bool `json:",omitempty"`
+	Call    PendingCall
+}
+
+// FileCallCheckpoint journals calls as JSON lines in a single file. The
+// journal is compacted when opened.
+type FileCallCheckpoint struct {
+	mu    sync.Mutex
+	file  *os.File
+	sync  bool
+	calls map[string]PendingCall
+}
+
+// NewFileCallCheckpoint opens, or creates, the checkpoint at path. With sync
+// every write is flushed to disk before returning.
+func NewFileCallCheckpoint(path string, sync bool) (*FileCallCheckpoint, error) {
+	calls, err := readCheckpoint(path)
+	if err != nil {
+		return nil, err
+	}
+
+	// Rewrite the journal with the outstanding calls only.
+	tmp := path + ".tmp"
+	file, err := os.OpenFile(tmp, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	cp := &FileCallCheckpoint{file: file, sync: sync, calls: make(map[string]PendingCall)}
+	for _, call := range sortedCalls(calls) {
+		if err := cp.write(checkpointRecord{Call: call}); err != nil {
+			file.Close()
+			return nil, err
+		}
+	}
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return nil, err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		file.Close()
+		return nil, err
+	}
+	cp.calls = calls
+
+	return cp, nil
+}
+
+func readCheckpoint(path string) (map[string]PendingCall, error) {
+	calls := make(map[string]PendingCall)
+
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return calls, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var r checkpointRecord
+		if err := json.Unmarshal(scanner.Bytes(), &r); err != nil {
+			// A torn last line is expected after a crash.
+			continue
+		}
+		if r.Deleted {
+			delete(calls, r.Call.ID)
+		} else {
+			calls[r.Call.ID] = r.Call
+		}
+	}
+	return calls, scanner.Err()
+}
+
+func (cp *FileCallCheckpoint) write(r checkpointRecord) error {
+	data, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	if _, err := cp.file.Write(append(data, '\n')); err != nil {
+		return err
+	}
+	if cp.sync {
+		return cp.file.Sync()
+	}
+	return nil
+}
+
+func (cp *FileCallCheckpoint) Save(call PendingCall) error {
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+
+	cp.calls[call.ID] = call
+	return cp.write(checkpointRecord{Call: call})
+}
+
+func (cp *FileCallCheckpoint) Delete(id string) error {
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+
+	if _, ok := cp.calls[id]; !ok {
+		return nil
+	}
+	delete(cp.calls, id)
+	return cp.write(checkpointRecord{Deleted: true, Call: PendingCall{ID: id}})
+}
+
+func (cp *FileCallCheckpoint) Load() ([]PendingCall, error) {
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+
+	return sortedCalls(cp.calls), nil
+}
+
+// Close closes the underlying file.
+func (cp *FileCallCheckpoint) Close() error {
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+
+	return cp.file.Close()
+}
+
+// WithCallCheckpointOpt saves every call in cp until its response arrives.
+// Calls failed by a disconnect or a timeout stay saved, as their outcome is
+// unknown. Calls found in cp on startup are reported by OutstandingCalls.
+func WithCallCheckpointOpt(cp CallCheckpoint) NewClientOption {
+	return func(o *Options) {
+		o.callCheckpoint = cp
+	}
+}
+
+// OutstandingCalls returns the checkpointed calls which have not been
+// answered yet, including those left over by a previous process. A call is
+// resolved by its response, by an execution report carrying its ClOrdID, or
+// by ResolveCall.
+func (c *Client) OutstandingCalls() ([]PendingCall, error) {
+	if c.options.callCheckpoint == nil {
+		return nil, nil
+	}
+	return c.options.callCheckpoint.Load()
+}
+
+// ResolveCall removes a call from the checkpoint, e.g. once its order has
+// been reconciled out of band.
+func (c *Client) ResolveCall(id string) error {
+	if c.options.callCheckpoint == nil {
+		return nil
+	}
+	return c.options.callCheckpoint.Delete(id)
+}
+
+func (c *Client) checkpointCall(id string, msgType string, sentAt time.Time) {
+	if c.options.callCheckpoint == nil {
+		return
+	}
+	call := PendingCall{ID: id, MsgType: msgType, SentAt: sentAt}
+	if err := c.options.callCheckpoint.Save(call); err != nil {
+		c.l.Errorw("Failed to checkpoint call", "id", id, "error", err)
+	}
+}
+
+func (c *Client) resolveCheckpoint(id string) {
+	if err := c.ResolveCall(id); err != nil {
+		c.l.Errorw("Failed to resolve checkpointed call", "id", id, "error", err)
+	}
+}
@@ -0,0 +1,50 @@
+package fix
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileCallCheckpoint(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "calls.log")
+	now := time.Now().UTC().Truncate(time.Millisecond)
+
+	cp, err := NewFileCallCheckpoint(path, false)
+	require.NoError(t, err)
+	calls := []PendingCall{
+		{ID: "a", MsgType: "D", SentAt: now},
+		{ID: "b", MsgType: "D", SentAt: now.Add(time.Second)},
+		{ID: "c", MsgType: "F", SentAt: now.Add(2 * time.Second)},
+	}
+	for _, call := range calls {
+		require.NoError(t, cp.Save(call))
+	}
+	require.NoError(t, cp.Delete("b"))
+	require.NoError(t, cp.Delete("unknown"))
+	require.NoError(t, cp.Close())
+
+	// Simulate a crash in the middle of a write.
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0o644)
+	require.NoError(t, err)
+	_, err = file.WriteString(`{"Call":{"ID":"d"`)
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+
+	cp, err = NewFileCallCheckpoint(path, false)
+	require.NoError(t, err)
+	defer cp.Close()
+
+	loaded, err := cp.Load()
+	require.NoError(t, err)
+	assert.Equal(t, []PendingCall{calls[0], calls[2]}, loaded)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, 2, strings.Count(string(data), "\n"))
+}
@@ -12,6 +12,9 @@ import (
 	"github.com/quickfixgo/enum"
 	"github.com/quickfixgo/field"
 	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/quickfix/config"
+	"github.com/quickfixgo/quickfix/store/file"
+	"github.com/quickfixgo/tag"
 	"go.uber.org/zap"
 )
 
@@ -21,19 +24,143 @@ type Config struct {
 	APIKey             string
 	PrivateKeyFilePath string
 	Settings           *quickfix.Settings
+
+	// DataDictionaryPath points to a Binance FIX data dictionary. When set,
+	// outgoing messages are validated against it before being sent.
+	DataDictionaryPath string
+
+	// TimestampPrecision of SendingTime and the logon signature. When empty,
+	// the TimeStampPrecision quickfix setting is used, defaulting to MILLIS.
+	TimestampPrecision TimestampPrecision
+
+	// FileStore persists sequence numbers and sent messages on disk so they
+	// survive process restarts. The in-memory store is used when nil. It is
+	// ignored if WithMessageStoreFactoryOpt is set.
+	FileStore *FileStoreConfig
+}
+
+type FileStoreConfig struct {
+	Path string // Directory holding the store files.
+	Sync bool   // Fsync every write to the store.
+}
+
+// timestampPrecision resolves the timestamp precision from the config and the
+// quickfix settings, making sure both agree.
+func (conf Config) timestampPrecision() (quickfix.TimestampPrecision, error) {
+	globalSettings := conf.Settings.GlobalSettings()
+	precision := conf.TimestampPrecision
+	if precision == "" {
+		precision = TimestampPrecisionMillis
+		if globalSettings.HasSetting(config.TimeStampPrecision) {
+			setting, err := globalSettings.Setting(config.TimeStampPrecision)
+			if err != nil {
+				return 0, err
+			}
+			precision = TimestampPrecision(setting)
+		}
+	}
+
+	p, ok := mappedTimestampPrecision[precision]
+	if !ok {
+		return 0, ErrInvalidTimestampPrecision
+	}
+	globalSettings.Set(config.TimeStampPrecision, string(precision))
+
+	return p, nil
+}
+
+// messageStoreFactory returns the quickfix store factory described by the
+// config. The file store settings are written into a copy of the quickfix
+// settings, Config.Settings is left as it is.
+func (conf Config) messageStoreFactory() (quickfix.MessageStoreFactory, error) {
+	if conf.FileStore == nil {
+		return quickfix.NewMemoryStoreFactory(), nil
+	}
+
+	fsync := "N"
+	if conf.FileStore.Sync {
+		fsync = "Y"
+	}
+	settings := quickfix.NewSettings()
+	for _, session := range conf.Settings.SessionSettings() {
+		session.Set(config.FileStorePath, conf.FileStore.Path)
+		session.Set(config.FileStoreSync, fsync)
+		if _, err := settings.AddSession(session); err != nil {
+			return nil, err
+		}
+	}
+
+	return file.NewStoreFactory(settings), nil
 }
 
 type Options struct {
 	messageHandling MessageHandling
 	responseMode    ResponseMode
 	fixLogFactory   quickfix.LogFactory
+
+	replayPolicy       ReplayPolicy
+	maxReplayDuration  time.Duration
+	replayAlertHandler ReplayAlertHandler
+
+	eventBacklogSize int
+	eventLog         EventLog
+	replayFromSeq    uint64 // 0 disables replaying the event log on startup.
+
+	executionReportHandlers []ExecutionReportHandler
+
+	callTimeout time.Duration
+
+	outboundInterceptors []OutboundInterceptor
+	wireTap              WireTap
+
+	dispatcher *DispatcherConfig
+
+	outboundQueue    bool
+	outboundPriority PriorityFunc
+
+	maintenance *MaintenanceConfig
+
+	redundantPeer *Client
+
+	compensateClockSkew bool
+
+	callCheckpoint CallCheckpoint
+
+	escalationPolicy EscalationPolicy
+
+	alertSink       AlertSink
+	alertThresholds AlertThresholds
+
+	logDedupInterval time.Duration
+
+	selfTradePreventionMode SelfTradePreventionMode
+
+	recvWindow time.Duration
+
+	unknownEnumHandler UnknownEnumHandler
+
+	rawMessages bool
+
+	reconnectPolicy ReconnectPolicy
+
+	keepSeqNums         bool // Logon with ResetSeqNumFlag<141> N.
+	messageStoreFactory quickfix.MessageStoreFactory
+
+	endpoints []Endpoint
+
+	maintenanceWindows     []MaintenanceWindow
+	maintenancePauseBefore time.Duration
+
+	missedHeartbeats int // 0 disables the heartbeat watchdog.
 }
 
 func defaultOpts() Options {
 	return Options{
-		messageHandling: MessageHandlingSequential,
-		responseMode:    ResponseModeEverything,
-		fixLogFactory:   quickfix.NewNullLogFactory(),
+		messageHandling:  MessageHandlingSequential,
+		responseMode:     ResponseModeEverything,
+		fixLogFactory:    quickfix.NewNullLogFactory(),
+		replayPolicy:     ReplayPolicyDeliver,
+		escalationPolicy: EscalationReturnError,
 	}
 }
 
@@ -57,24 +184,231 @@ func WithZapLogFactory(logger *zap.SugaredLogger) NewClientOption {
 	}
 }
 
+// WithResetSeqNumFlagOpt sets the ResetSeqNumFlag<141> of the logon, Y by
+// default as Binance requires it at the time of writing. Without the reset,
+// the sequence numbers of the store carry over to the next logon so that the
+// messages missed while disconnected are resent. The store must then persist
+// them across sessions, see Config.FileStore and WithMessageStoreFactoryOpt.
+func WithResetSeqNumFlagOpt(reset bool) NewClientOption {
+	return func(o *Options) {
+		o.keepSeqNums = !reset
+	}
+}
+
+// WithMessageStoreFactoryOpt makes the session store its sequence numbers
+// and sent messages with factory, e.g. in a database, instead of the store
+// set up by Config.FileStore.
+func WithMessageStoreFactoryOpt(factory quickfix.MessageStoreFactory) NewClientOption {
+	return func(o *Options) {
+		o.messageStoreFactory = factory
+	}
+}
+
+// WithRawMessageOpt sets Order.Raw on every order decoded from a message. It
+// is off by default as the tracker, the backlog and the event log all keep
+// their copy of every order.
+func WithRawMessageOpt() NewClientOption {
+	return func(o *Options) {
+		o.rawMessages = true
+	}
+}
+
+// WithReplayPolicyOpt controls how resent (PossDupFlag=Y) application
+// messages are delivered to subscribers.
+func WithReplayPolicyOpt(p ReplayPolicy) NewClientOption {
+	return func(o *Options) {
+		o.replayPolicy = p
+	}
+}
+
+// WithReplayAlertOpt calls handler once the session has been replaying resent
+// messages for longer than maxDuration.
+func WithReplayAlertOpt(maxDuration time.Duration, handler ReplayAlertHandler) NewClientOption {
+	return func(o *Options) {
+		o.maxReplayDuration = maxDuration
+		o.replayAlertHandler = handler
+	}
+}
+
+// WithEventBacklogOpt keeps the last size execution reports in memory so late
+// subscribers can replay them.
+func WithEventBacklogOpt(size int) NewClientOption {
+	return func(o *Options) {
+		o.eventBacklogSize = size
+	}
+}
+
+// WithEventLogOpt records every decoded order event into log. The order
+// tracker is rebuilt from the log when the client is created.
+func WithEventLogOpt(log EventLog) NewClientOption {
+	return func(o *Options) {
+		o.eventLog = log
+	}
+}
+
+// WithEventLogReplayOpt replays the events of the event log starting at
+// fromSeq through the subscribers, marked as Replayed, before the client
+// connects. Subscribers must be registered with WithExecutionReportHandlerOpt.
+func WithEventLogReplayOpt(fromSeq uint64) NewClientOption {
+	return func(o *Options) {
+		o.replayFromSeq = fromSeq
+	}
+}
+
+// WithExecutionReportHandlerOpt subscribes handler to execution reports
+// before the client connects, so it receives every event from the start.
+func WithExecutionReportHandlerOpt(handler ExecutionReportHandler) NewClientOption {
+	return func(o *Options) {
+		o.executionReportHandlers = append(o.executionReportHandlers, handler)
+	}
+}
+
+// WithCallTimeout sets a default deadline on every Call whose context does not
+// already carry one.
+func WithCallTimeout(d time.Duration) NewClientOption {
+	return func(o *Options) {
+		o.callTimeout = d
+	}
+}
+
+// OutboundInterceptor is called on every message sent by the client, after
+// the common headers are set and right before it is handed to quickfix.
+// Returning an error aborts the send.
+type OutboundInterceptor func(msg *quickfix.Message) error
+
+// WithOutboundInterceptor adds an interceptor. Interceptors run in the order
+// they were added.
+func WithOutboundInterceptor(interceptor OutboundInterceptor) NewClientOption {
+	return func(o *Options) {
+		o.outboundInterceptors = append(o.outboundInterceptors, interceptor)
+	}
+}
+
+// WithWireTap delivers the raw bytes of every inbound message to tap as soon
+// as quickfix has read them, whichever log factory is configured.
+func WithWireTap(tap WireTap) NewClientOption {
+	return func(o *Options) {
+		o.wireTap = tap
+	}
+}
+
+// WithDispatcherOpt moves response matching and subscription dispatch off the
+// quickfix session goroutine onto a dedicated one configured by conf. With a
+// dispatcher, inbound application messages are no longer rejected at the
+// session level when they lack a request ID, they are only logged.
+func WithDispatcherOpt(conf DispatcherConfig) NewClientOption {
+	return func(o *Options) {
+		o.dispatcher = &conf
+	}
+}
+
+// WithOutboundQueueOpt sends every outbound message through a queue ordered by
+// priority, DefaultPriority if nil. Sending waits for the message to leave the
+// queue, which holds messages back while the rate limit budget estimated from
+// NewGetLimitService is exhausted.
+func WithOutboundQueueOpt(priority PriorityFunc) NewClientOption {
+	return func(o *Options) {
+		o.outboundQueue = true
+		o.outboundPriority = priority
+	}
+}
+
 type Client struct {
 	l           *zap.SugaredLogger
 	mu          sync.Mutex
+	startMu     sync.Mutex // Serializes starting and stopping the initiator.
 	isConnected atomic.Bool
 	initiator   *quickfix.Initiator
 	pending     map[string]*call
+	validator   *messageValidator
 	emitter     *emission.Emitter
+	replay      replayState
+	loggedOut   chan struct{} // Closed by OnLogout, renewed on every logon.
+	logon       *logonSignal  // Resolved by OnLogon or OnLogout, see Start.
+	logoutText  string        // Text<58> of the last Logout<5> received.
+	store       *syncStore
+	nextSeqNums seqNumOverride // Set while stopped, applied to the next store.
+	seqResetMu  sync.Mutex     // Serializes SendSequenceReset.
+	seqReset    atomic.Pointer[quickfix.Message]
+	backlog     *orderBacklog
+	tracker     *OrderTracker
+	stats       callStats
+	budget      limitBudget
+	watchers    orderWatchers
+	eventSeq    atomic.Uint64
+	eventsMu    sync.Mutex // Serializes order events, see OpenOrdersSnapshot.
+
+	settings     *quickfix.Settings
+	storeFactory quickfix.MessageStoreFactory
+	testRequests map[string]chan struct{} // Keyed by TestReqID<112>.
+
+	lastProcessedSeqNum atomic.Int64 // MsgSeqNum<34> of the last inbound message.
+	lastActivity        atomic.Int64 // Unix nanoseconds of the last inbound message.
+
+	watchdogStop chan struct{} // Closed by OnLogout, see WithHeartbeatWatchdogOpt.
+
+	dispatcher atomic.Pointer[dispatcher]    // Nil unless WithDispatcherOpt is set.
+	outbound   atomic.Pointer[outboundQueue] // Nil unless WithOutboundQueueOpt is set.
+
+	maintenance  atomic.Bool   // Set while new orders are paused, see WithMaintenanceModeOpt.
+	downtimeStop chan struct{} // Closed by Stop, see WithMaintenanceWindowsOpt.
+
+	closing      atomic.Bool   // Set by Stop and Logout, the session must stay down.
+	stopped      chan struct{} // Closed by Stop, see stopSignal.
+	reconnecting atomic.Bool
+	shuttingDown atomic.Bool // Set by Shutdown until the next Start.
+
+	stateMu      sync.Mutex
+	state        ConnState
+	stateChanges chan ConnState // Nil until StateChanges is called.
+
+	dedup *reportDedup // Nil unless WithRedundantSessionOpt is set.
+
+	endpoints *endpointSet // Nil unless WithEndpointsOpt is set.
+
+	skew clockSkew
+
+	expiries orderExpiries
+
+	alerts alerts
+
+	metadata orderMetadata
+
+	lists listLegRejects
 
 	apiKey       string
 	privateKey   ed25519.PrivateKey
 	beginString  string
 	targetCompID string
 	senderCompID string
+	sessionID    quickfix.SessionID // Including the SessionQualifier, if any.
+
+	caller Caller // Set by NewWithCaller, replaces the session.
+
+	timestampPrecision quickfix.TimestampPrecision
 
 	options Options
 }
 
+// NewClient creates a client with New and connects it with Connect.
 func NewClient(ctx context.Context, l *zap.SugaredLogger, conf Config, opts ...NewClientOption) (*Client, error) {
+	client, err := New(l, conf, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	// Init session and logon to Binance FIX API server.
+	if err := client.Connect(ctx); err != nil {
+		client.l.Errorw("Failed to start fix connection", "error", err)
+		return nil, err
+	}
+
+	return client, nil
+}
+
+// New creates a client without connecting it, see Connect. Handlers can be
+// subscribed before the session is established.
+func New(l *zap.SugaredLogger, conf Config, opts ...NewClientOption) (*Client, error) {
 	// Get BeginString, TargetCompID and SenderCompID from settings.
 	if conf.Settings == nil {
 		return nil, errors.New("empty quickfix settings")
@@ -97,6 +431,12 @@ func NewClient(ctx context.Context, l *zap.SugaredLogger, conf Config, opts ...N
 		return nil, err
 	}
 
+	timestampPrecision, err := conf.timestampPrecision()
+	if err != nil {
+		l.Errorw("Failed to read TimeStampPrecision", "error", err)
+		return nil, err
+	}
+
 	privateKey, err := GetEd25519PrivateKeyFromFile(conf.PrivateKeyFilePath)
 	if err != nil {
 		l.Errorw("Failed to GetEd25519PrivateKeyFromFile", "error", err)
@@ -107,44 +447,149 @@ func NewClient(ctx context.Context, l *zap.SugaredLogger, conf Config, opts ...N
 	for _, opt := range opts {
 		opt(&options)
 	}
+	storeFactory := options.messageStoreFactory
+	if storeFactory == nil {
+		storeFactory, err = conf.messageStoreFactory()
+		if err != nil {
+			l.Errorw("Failed to create the message store factory", "error", err)
+			return nil, err
+		}
+	}
+	if options.keepSeqNums && conf.FileStore == nil && options.messageStoreFactory == nil {
+		l.Warnw("Sequence numbers are kept on logon but not persisted, they restart from 1 with every new session")
+	}
+	if options.logDedupInterval > 0 {
+		l = dedupLogger(l, options.logDedupInterval)
+	}
+	if options.wireTap != nil {
+		options.fixLogFactory = wireTapLogFactory{
+			LogFactory: options.fixLogFactory,
+			tap:        options.wireTap,
+		}
+	}
 
 	// Create a new Client object.
 	client := &Client{
 		l:            l,
 		pending:      make(map[string]*call),
 		emitter:      emission.NewEmitter(),
+		loggedOut:    make(chan struct{}),
+		settings:     conf.Settings,
+		storeFactory: storeFactory,
+		testRequests: make(map[string]chan struct{}),
 		apiKey:       conf.APIKey,
 		privateKey:   privateKey,
 		beginString:  beginString,
 		targetCompID: targetCompID,
 		senderCompID: senderCompID,
+		sessionID:    sessionIDOf(conf.Settings, beginString, senderCompID, targetCompID),
 		options:      options,
+
+		timestampPrecision: timestampPrecision,
 	}
 
-	// Init session and logon to Binance FIX API server.
-	client.initiator, err = quickfix.NewInitiator(
-		client,
-		quickfix.NewMemoryStoreFactory(),
-		conf.Settings,
-		options.fixLogFactory,
-	)
-	if err != nil {
-		client.l.Errorw("Failed to create new initiator", "error", err)
-		return nil, err
+	client.tracker = NewOrderTracker()
+	if options.eventLog != nil {
+		if err := client.tracker.Rebuild(options.eventLog); err != nil {
+			l.Errorw("Failed to rebuild order tracker from event log", "error", err)
+			return nil, err
+		}
+		client.eventSeq.Store(client.tracker.LastSeq())
 	}
 
-	err = client.Start(ctx)
-	if err != nil {
-		client.l.Errorw("Failed to start fix connection", "error", err)
-		return nil, err
+	if len(options.endpoints) > 0 {
+		client.endpoints = newEndpointSet(options.endpoints)
+	}
+
+	if options.eventBacklogSize > 0 {
+		client.backlog = newOrderBacklog(options.eventBacklogSize)
+	}
+
+	if conf.DataDictionaryPath != "" {
+		client.validator, err = newMessageValidator(conf.DataDictionaryPath)
+		if err != nil {
+			l.Errorw("Failed to parse data dictionary", "path", conf.DataDictionaryPath, "error", err)
+			return nil, err
+		}
+	}
+
+	for _, handler := range options.executionReportHandlers {
+		client.SubscribeToExecutionReport(handler)
+	}
+	if peer := options.redundantPeer; peer != nil {
+		client.dedup = newReportDedup()
+		peer.SubscribeToExecutionReport(func(o *Order) {
+			client.deliverOrder(*o)
+		})
+	}
+	if options.eventLog != nil && options.replayFromSeq > 0 {
+		if err := client.ReplayEventLog(options.replayFromSeq); err != nil {
+			l.Errorw("Failed to replay event log", "error", err)
+			return nil, err
+		}
 	}
 
 	return client, nil
 }
 
+// sessionIDOf returns the ID of the session of settings, which messages are
+// sent to. quickfix.Send would ignore its SessionQualifier.
+func sessionIDOf(settings *quickfix.Settings, beginString, senderCompID, targetCompID string) quickfix.SessionID {
+	sessions := settings.SessionSettings()
+	if len(sessions) == 1 {
+		for id := range sessions {
+			return id
+		}
+	}
+	return quickfix.SessionID{BeginString: beginString, SenderCompID: senderCompID, TargetCompID: targetCompID}
+}
+
+// closeTimeout bounds the wait of Close for pending calls and the logout.
+const closeTimeout = 5 * time.Second
+
+// Connect connects and logs on to the server, see Start.
+func (c *Client) Connect(ctx context.Context) error {
+	return c.Start(ctx)
+}
+
+// Close shuts the client down gracefully, waiting up to 5 seconds for the
+// pending calls and the logout, see Shutdown.
+func (c *Client) Close() error {
+	ctx, cancel := context.WithTimeout(context.Background(), closeTimeout)
+	defer cancel()
+
+	return c.Shutdown(ctx)
+}
+
+// Start connects and logs on to the server. A fresh initiator is created if
+// the client was never started or has been stopped, so Start may be called
+// again after Stop. If the client is already started, Start only waits for
+// the logon. Start fails with ErrLogonFailed, and stops the client, as soon
+// as the session ends before logging on. With WithEndpointsOpt, Start moves
+// on to the next endpoint whenever connecting to one fails otherwise.
 func (c *Client) Start(ctx context.Context) error {
-	if err := c.initiator.Start(); err != nil {
-		c.l.Errorw("Failed to initialize initiator", "error", err)
+	c.closing.Store(false)
+	c.shuttingDown.Store(false)
+	c.startMaintenanceSchedule()
+	err := c.start(ctx)
+	for attempt := 1; attempt < c.endpoints.len() && err != nil && !errors.Is(err, ErrLogonFailed); attempt++ {
+		if ctx.Err() != nil {
+			break
+		}
+		c.endpointFailed(err)
+		c.stop()
+		err = c.start(ctx)
+	}
+	if errors.Is(err, ErrLogonFailed) {
+		// Retrying a rejected logon would most likely fail the same way.
+		c.Stop()
+	}
+	return err
+}
+
+func (c *Client) start(ctx context.Context) error {
+	logon, err := c.startInitiator()
+	if err != nil {
 		return err
 	}
 
@@ -152,75 +597,476 @@ func (c *Client) Start(ctx context.Context) error {
 	timeoutCtx, cancel := context.WithTimeout(ctx, logonTimeout)
 	defer cancel()
 
-	for {
-		select {
-		case <-timeoutCtx.Done():
-			return errors.New("logon timed out")
-		default:
-			if c.IsConnected() {
-				return nil
-			}
-			time.Sleep(10 * time.Millisecond)
+	select {
+	case <-logon.done:
+		return logon.err
+	case <-timeoutCtx.Done():
+		return errors.New("logon timed out")
+	}
+}
+
+// startInitiator creates and starts the initiator unless the client is
+// already started, and returns the signal of the logon. c.mu is not held
+// while the initiator is created since quickfix creates the message store,
+// see clientStoreFactory, and may call back into the client right away.
+func (c *Client) startInitiator() (*logonSignal, error) {
+	c.startMu.Lock()
+	defer c.startMu.Unlock()
+
+	// Stop may have been called while reconnecting, after closing was checked.
+	if c.closing.Load() {
+		return nil, ErrClosed
+	}
+
+	c.mu.Lock()
+	started := c.initiator != nil
+	c.mu.Unlock()
+	if !started {
+		settings, err := c.initiatorSettings()
+		if err != nil {
+			c.l.Errorw("Failed to set the endpoint", "error", err)
+			return nil, err
+		}
+		initiator, err := quickfix.NewInitiator(
+			c,
+			clientStoreFactory{factory: c.storeFactory, c: c},
+			settings,
+			c.options.fixLogFactory,
+		)
+		if err != nil {
+			c.l.Errorw("Failed to create new initiator", "error", err)
+			return nil, err
+		}
+
+		c.mu.Lock()
+		c.logon = newLogonSignal()
+		c.initiator = initiator
+		c.mu.Unlock()
+		c.startDispatcher()
+		c.startOutboundQueue()
+		c.setState(ConnStateConnecting)
+		if err := initiator.Start(); err != nil {
+			c.mu.Lock()
+			c.initiator = nil
+			c.mu.Unlock()
+			c.stopOutboundQueue()
+			c.stopDispatcher()
+			c.setState(ConnStateDisconnected)
+			c.l.Errorw("Failed to initialize initiator", "error", err)
+			return nil, err
 		}
 	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.logonSignalLocked(), nil
 }
 
 func (c *Client) IsConnected() bool {
 	return c.isConnected.Load()
 }
 
-// Stop closes underlying connection.
+// Stop closes underlying connection. The client can be started again.
 func (c *Client) Stop() {
-	c.initiator.Stop()
+	c.closing.Store(true)
+	c.mu.Lock()
+	if c.stopped != nil {
+		close(c.stopped)
+		c.stopped = nil
+	}
+	c.mu.Unlock()
+	c.stopMaintenanceSchedule()
+	c.stop()
+}
+
+// stopSignal returns a channel closed by the next call to Stop.
+func (c *Client) stopSignal() <-chan struct{} {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.stopped == nil {
+		c.stopped = make(chan struct{})
+	}
+	return c.stopped
+}
+
+func (c *Client) stop() {
+	c.startMu.Lock()
+	defer c.startMu.Unlock()
+
+	c.mu.Lock()
+	initiator := c.initiator
+	c.initiator = nil
+	c.mu.Unlock()
+
+	c.stopOutboundQueue()
+	if initiator != nil {
+		initiator.Stop()
+	}
+	c.stopDispatcher()
+	c.setState(ConnStateDisconnected)
+}
+
+func (c *Client) startOutboundQueue() {
+	if !c.options.outboundQueue {
+		return
+	}
+	q := newOutboundQueue(c.options.outboundPriority, &c.budget, func(msg *quickfix.Message) error {
+		return quickfix.SendToTarget(msg, c.sessionID)
+	})
+	q.start()
+	c.outbound.Store(q)
+}
+
+// stopOutboundQueue fails the messages still queued with ErrClosed.
+func (c *Client) stopOutboundQueue() {
+	if q := c.outbound.Swap(nil); q != nil {
+		q.close()
+	}
+}
+
+func (c *Client) startDispatcher() {
+	if c.options.dispatcher == nil {
+		return
+	}
+	d := newDispatcher(*c.options.dispatcher, c.processApp)
+	d.start()
+	c.dispatcher.Store(d)
+}
+
+// stopDispatcher drains and stops the dispatcher. The initiator must be
+// stopped first so that nothing is enqueued anymore.
+func (c *Client) stopDispatcher() {
+	if d := c.dispatcher.Swap(nil); d != nil {
+		d.close()
+	}
+}
+
+// Logout sends a Logout<5> carrying text as the reason, waits for the server
+// to confirm it and then stops the underlying connection so that it is not
+// re-established. The connection is stopped even if ctx expires first.
+func (c *Client) Logout(ctx context.Context, text string) error {
+	c.mu.Lock()
+	loggedOut := c.loggedOut
+	c.mu.Unlock()
+
+	msg := quickfix.NewMessage()
+	msg.Header.Set(field.NewMsgType(enum.MsgType_LOGOUT))
+	if text != "" {
+		msg.Body.Set(field.NewText(text))
+	}
+	c.closing.Store(true)
+	c.setState(ConnStateLoggingOut)
+	if err := c.sendAdmin(msg); err != nil {
+		c.closing.Store(false)
+		if c.IsConnected() {
+			c.setState(ConnStateLoggedOn)
+		}
+		return err
+	}
+
+	defer c.Stop()
+	select {
+	case <-loggedOut:
+		return nil
+	case <-ctx.Done():
+		c.l.Warnw("Logout was not confirmed in time", "error", ctx.Err())
+		return ctx.Err()
+	}
+}
+
+// RelogonOptions holds the logon settings which can be changed by Relogon.
+// Zero values keep the current setting.
+type RelogonOptions struct {
+	MessageHandling MessageHandling
+	ResponseMode    ResponseMode
+}
+
+// Relogon logs out and logs on again with the MessageHandling<25035> and
+// ResponseMode<25036> of opts. Calls pending during the logout fail with
+// ErrClosed.
+func (c *Client) Relogon(ctx context.Context, opts RelogonOptions) error {
+	if err := c.Logout(ctx, "Relogon"); err != nil {
+		return err
+	}
+
+	// The session is stopped, ToAdmin cannot read the options concurrently.
+	c.mu.Lock()
+	if opts.MessageHandling != 0 {
+		c.options.messageHandling = opts.MessageHandling
+	}
+	if opts.ResponseMode != 0 {
+		c.options.responseMode = opts.ResponseMode
+	}
+	c.mu.Unlock()
+
+	return c.Start(ctx)
 }
 
 // Call initiates a FIX call and wait for the response.
 func (c *Client) Call(
 	ctx context.Context, id string, msg *quickfix.Message,
 ) (*quickfix.Message, error) {
-	call, err := c.send(id, msg)
+	if _, ok := ctx.Deadline(); !ok && c.options.callTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.options.callTimeout)
+		defer cancel()
+	}
+
+	if err := c.setRecvWindow(ctx, msg); err != nil {
+		return nil, err
+	}
+
+	if c.caller != nil {
+		c.keepMetadata(ctx, id, msg)
+		return c.callCaller(ctx, id, msg)
+	}
+
+	if peer := c.options.redundantPeer; peer != nil && !c.IsConnected() && peer.IsConnected() {
+		if msgType, err := msg.MsgType(); err == nil && redundantMsgTypes[enum.MsgType(msgType)] {
+			c.logger(ctx).Infow("Session down, sending through the redundant session", "id", id)
+			c.keepMetadata(ctx, id, msg)
+			return peer.Call(ctx, id, msg)
+		}
+	}
+
+	call, err := c.send(ctx, c.logger(ctx), id, msg)
+	notifySent(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	return call.wait(ctx)
+	resp, err := call.wait(ctx)
+	if err != nil && ctx.Err() != nil {
+		c.forgetCall(id, call.call)
+	}
+
+	return resp, err
+}
+
+// expect registers a pending call matching the response identified by id to
+// request, for requests answered by several messages carrying different IDs.
+// It must be called before the request is sent.
+func (c *Client) expect(l *zap.SugaredLogger, id string, request *quickfix.Message) waiter {
+	cc := &call{l: l, request: request, sentAt: time.Now(), done: make(chan error, 1)}
+	c.mu.Lock()
+	c.pending[id] = cc
+	c.mu.Unlock()
+
+	w := waiter{call: cc}
+	if c.options.dispatcher != nil {
+		w.spin = c.options.dispatcher.SpinDuration
+	}
+	return w
+}
+
+// forgetCall removes cc from the pending calls once nobody is waiting for its
+// response anymore.
+func (c *Client) forgetCall(id string, cc *call) {
+	c.mu.Lock()
+	if c.pending[id] == cc {
+		delete(c.pending, id)
+	}
+	c.mu.Unlock()
 }
 
 func (c *Client) addCommonHeaders(msg *quickfix.Message) {
 	msg.Header.Set(field.NewBeginString(c.beginString))
 	msg.Header.Set(field.NewTargetCompID(c.targetCompID))
 	msg.Header.Set(field.NewSenderCompID(c.senderCompID))
-	msg.Header.Set(field.NewSendingTime(time.Now().UTC()))
+	msg.Header.Set(field.NewSendingTimeWithPrecision(c.now().UTC(), c.timestampPrecision))
+}
+
+func (c *Client) intercept(msg *quickfix.Message) error {
+	for _, interceptor := range c.options.outboundInterceptors {
+		if err := interceptor(msg); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func (c *Client) send(
-	id string, msg *quickfix.Message,
+	ctx context.Context, l *zap.SugaredLogger, id string, msg *quickfix.Message,
 ) (waiter, error) {
+	if c.shuttingDown.Load() {
+		return waiter{}, ErrShutdown
+	}
 	if !c.isConnected.Load() {
 		return waiter{}, ErrClosed
 	}
 
+	if c.maintenance.Load() {
+		if msgType, err := msg.MsgType(); err == nil && orderMsgTypes[enum.MsgType(msgType)] {
+			return waiter{}, ErrMaintenance
+		}
+	}
+
 	c.addCommonHeaders(msg)
-	cc := &call{request: msg, done: make(chan error, 1)}
+	if c.validator != nil {
+		if err := c.validator.validate(msg); err != nil {
+			return waiter{}, err
+		}
+	}
+	if err := c.intercept(msg); err != nil {
+		l.Warnw("Outbound message rejected by interceptor", "msg", msg, "error", err)
+		return waiter{}, err
+	}
+
+	cc := &call{l: l, request: msg, sentAt: time.Now(), done: make(chan error, 1)}
+	c.mu.Lock()
+	if _, ok := c.pending[id]; ok {
+		c.mu.Unlock()
+		return waiter{}, ErrDuplicateID
+	}
 	c.pending[id] = cc
+	c.mu.Unlock()
+	// Only now that id is known not to be a duplicate.
+	c.keepMetadata(ctx, id, msg)
 
-	if err := quickfix.Send(msg); err != nil {
+	msgType, _ := msg.MsgType()
+	c.checkpointCall(id, msgType, cc.sentAt)
+	if err := c.transmit(ctx, msg); err != nil {
 		c.mu.Lock()
 		delete(c.pending, id)
 		c.mu.Unlock()
+		c.metadata.delete(id)
+		c.resolveCheckpoint(id)
+		l.Errorw("Failed to send message", "id", id, "error", err)
 		return waiter{}, err
 	}
 
-	return waiter{cc}, nil
+	// Remember the sequence number so a session Reject<3> can be matched.
+	if seqNum, err := msg.Header.GetInt(tag.MsgSeqNum); err == nil {
+		c.mu.Lock()
+		cc.seqNum = seqNum
+		c.mu.Unlock()
+	}
+
+	w := waiter{call: cc}
+	if c.options.dispatcher != nil {
+		w.spin = c.options.dispatcher.SpinDuration
+	}
+	return w, nil
+}
+
+// handleSessionReject fails the pending call whose request was rejected by
+// the server with Reject<3>.
+func (c *Client) handleSessionReject(msg *quickfix.Message) {
+	rejErr, refSeqNum, err := decodeSessionReject(msg)
+	if err != nil {
+		_ = c.escalate("decode session reject", newMessageError(err, msg))
+		return
+	}
+	c.recordRejectForAlert()
+
+	var (
+		rejected   *call
+		rejectedID string
+	)
+	c.mu.Lock()
+	for id, call := range c.pending {
+		if call.seqNum == refSeqNum {
+			rejected, rejectedID = call, id
+			delete(c.pending, id)
+			break
+		}
+	}
+	c.mu.Unlock()
+
+	if rejected == nil {
+		c.l.Warnw("Received session reject", "error", rejErr, "refSeqNum", refSeqNum)
+		return
+	}
+	c.resolveCheckpoint(rejectedID)
+
+	rejected.l.Warnw("Request rejected by session", "error", rejErr, "request", rejected.request)
+	rejected.done <- rejErr
+	close(rejected.done)
+}
+
+// Tracker returns the tracker holding the latest state of every order seen
+// by the client.
+func (c *Client) Tracker() *OrderTracker {
+	return c.tracker
+}
+
+// recordOrderEvent appends order to the event log, if any, and applies it to
+// the tracker.
+func (c *Client) recordOrderEvent(order Order) {
+	c.eventsMu.Lock()
+	defer c.eventsMu.Unlock()
+
+	e := OrderEvent{
+		Seq:     c.eventSeq.Add(1),
+		Version: orderEventVersion,
+		Time:    time.Now(),
+		Order:   order,
+	}
+	if c.options.eventLog != nil {
+		if err := c.options.eventLog.Append(e); err != nil {
+			c.l.Errorw("Failed to append order event", "seq", e.Seq, "error", err)
+		}
+	}
+	c.tracker.Apply(e)
 }
 
 func (c *Client) handleSubscriptions(msgType string, msg *quickfix.Message) {
+	if enum.MsgType(msgType) == enum.MsgType_NEWS {
+		c.handleNews(msg)
+		return
+	}
+	if enum.MsgType(msgType) == enum.MsgType_ORDER_CANCEL_REJECT {
+		c.recordRejectForAlert()
+		return
+	}
+	if enum.MsgType(msgType) == enum.MsgType_LIST_STATUS {
+		c.handleListStatus(msg)
+		return
+	}
 	if enum.MsgType(msgType) == enum.MsgType_EXECUTION_REPORT {
+		c.recordLegReject(msg)
 		order, err := decodeExecutionReport(msg)
-		if err != nil {
-			c.l.Errorw("Failed to decodeExecutionReport", "err", err, "msg", msg)
+		var rejErr *OrderRejectedError
+		if err != nil && !errors.As(err, &rejErr) {
+			_ = c.escalate("decode execution report", newMessageError(err, msg))
 			return
 		}
-		c.emitter.Emit(ExecutionReportTopic, &order)
+		c.reportUnknownValues(&order)
+		c.attachRaw(&order, msg)
+		c.deliverOrder(order)
+	}
+}
+
+// deliverOrder records a decoded execution report and dispatches it to the
+// watchers and subscribers.
+func (c *Client) deliverOrder(order Order) {
+	if c.dedup != nil && !c.dedup.firstSeen(&order) {
+		return
+	}
+	c.resolveCheckpoint(order.ClientOrderID)
+	id := order.trackedID()
+	if orig := order.OrigClientOrderID; orig != "" && orig != id && order.ExecType == ExecTypeReplaced {
+		c.metadata.rename(orig, id)
+	}
+	order.Metadata = c.metadata.get(id)
+	if order.Status.IsTerminal() {
+		c.expiries.stop(id)
+		c.metadata.delete(id)
+	}
+
+	if order.Status == OrderStatusRejected {
+		c.recordRejectForAlert()
+	}
+
+	c.recordOrderEvent(order)
+	if dropped := c.watchers.notify(order); dropped {
+		c.l.Warnw("Dropped order update for a slow watcher", "clOrdID", order.ClientOrderID)
+	}
+	if c.backlog != nil {
+		c.backlog.mu.Lock()
+		c.backlog.add(&order)
+		c.backlog.mu.Unlock()
 	}
+	c.emitter.Emit(ExecutionReportTopic, &order)
 }
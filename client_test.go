@@ -0,0 +1,238 @@
+package fix
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/quickfixgo/enum"
+	"github.com/quickfixgo/field"
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/quickfix/config"
+	"github.com/quickfixgo/tag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestLogout(t *testing.T) {
+	g := newTestGateway(t)
+	texts := make(chan string, 1)
+	g.handleAdmin(func(msg *quickfix.Message, _ quickfix.SessionID) {
+		if msg.IsMsgTypeOf(string(enum.MsgType_LOGOUT)) {
+			text, _ := msg.Body.GetString(tag.Text)
+			texts <- text
+		}
+	})
+	c := g.startClient(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	require.NoError(t, c.Logout(ctx, "maintenance handover"))
+	assert.Equal(t, "maintenance handover", <-texts)
+	assert.False(t, c.IsConnected())
+
+	// The connection is not re-established.
+	assert.Never(t, c.IsConnected, 2*time.Second, 100*time.Millisecond)
+}
+
+func TestStartAgainstGateway(t *testing.T) {
+	g := newTestGateway(t)
+	c := g.startClient(t)
+	assert.True(t, c.IsConnected())
+	assert.Positive(t, c.NextSenderMsgSeqNum())
+
+	c.Stop()
+	assert.False(t, c.IsConnected())
+
+	// The client can be started again after Stop.
+	require.NoError(t, c.Start(context.Background()))
+	assert.True(t, c.IsConnected())
+}
+
+func TestCallTimeout(t *testing.T) {
+	g := newTestGateway(t)
+	received := make(chan *quickfix.Message, 2)
+	// The gateway never answers.
+	g.handle(func(msg *quickfix.Message, _ quickfix.SessionID) { received <- msg })
+	c := g.startClient(t, WithCallTimeout(100*time.Millisecond))
+
+	newOrder := func(clOrdID string) *quickfix.Message {
+		msg := quickfix.NewMessage()
+		msg.Header.Set(field.NewMsgType(enum.MsgType_ORDER_SINGLE))
+		msg.Body.Set(field.NewClOrdID(clOrdID))
+		return msg
+	}
+
+	start := time.Now()
+	_, err := c.Call(context.Background(), "a", newOrder("a"))
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), time.Second)
+	<-received
+	assert.Zero(t, c.Stats().PendingCalls)
+
+	// A deadline of the caller is kept, even if it is later.
+	ctx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+	defer cancel()
+	start = time.Now()
+	_, err = c.Call(ctx, "b", newOrder("b"))
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.GreaterOrEqual(t, time.Since(start), 300*time.Millisecond)
+}
+
+func TestOutboundInterceptor(t *testing.T) {
+	const custom quickfix.Tag = 29999
+
+	g := newTestGateway(t)
+	received := make(chan *quickfix.Message, 1)
+	// The gateway never answers.
+	g.handle(func(msg *quickfix.Message, _ quickfix.SessionID) { received <- msg })
+
+	errBlocked := errors.New("blocked")
+	var order []string
+	c := g.startClient(t,
+		WithCallTimeout(100*time.Millisecond),
+		WithOutboundInterceptor(func(msg *quickfix.Message) error {
+			order = append(order, "first")
+			// The common headers are already set.
+			sender, err := msg.Header.GetString(tag.SenderCompID)
+			require.NoError(t, err)
+			assert.Equal(t, "EXAMPLE", sender)
+			msg.Body.SetString(custom, "intercepted")
+			return nil
+		}),
+		WithOutboundInterceptor(func(msg *quickfix.Message) error {
+			order = append(order, "second")
+			if clOrdID, _ := msg.Body.GetString(tag.ClOrdID); clOrdID == "blocked" {
+				return errBlocked
+			}
+			return nil
+		}),
+	)
+
+	newOrder := func(clOrdID string) *quickfix.Message {
+		msg := quickfix.NewMessage()
+		msg.Header.Set(field.NewMsgType(enum.MsgType_ORDER_SINGLE))
+		msg.Body.Set(field.NewClOrdID(clOrdID))
+		return msg
+	}
+
+	_, err := c.Call(context.Background(), "a", newOrder("a"))
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	value, err := (<-received).Body.GetString(custom)
+	require.NoError(t, err)
+	assert.Equal(t, "intercepted", value)
+	assert.Equal(t, []string{"first", "second"}, order)
+
+	_, err = c.Call(context.Background(), "blocked", newOrder("blocked"))
+	assert.ErrorIs(t, err, errBlocked)
+	assert.Zero(t, c.Stats().PendingCalls)
+	select {
+	case msg := <-received:
+		t.Fatalf("blocked message was sent: %v", msg)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestRelogon(t *testing.T) {
+	type logonModes struct{ messageHandling, responseMode int }
+
+	g := newTestGateway(t)
+	logons := make(chan logonModes, 2)
+	g.handleAdmin(func(msg *quickfix.Message, _ quickfix.SessionID) {
+		if msg.IsMsgTypeOf(string(enum.MsgType_LOGON)) {
+			var m logonModes
+			m.messageHandling, _ = msg.Body.GetInt(tagMessageHandling)
+			m.responseMode, _ = msg.Body.GetInt(tagResponseMode)
+			logons <- m
+		}
+	})
+	c := g.startClient(t)
+	assert.Equal(t, logonModes{int(MessageHandlingSequential), int(ResponseModeEverything)}, <-logons)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	require.NoError(t, c.Relogon(ctx, RelogonOptions{ResponseMode: ResponseModeOnlyAcks}))
+	assert.True(t, c.IsConnected())
+	// The message handling is kept as it was not given.
+	assert.Equal(t, logonModes{int(MessageHandlingSequential), int(ResponseModeOnlyAcks)}, <-logons)
+}
+
+func TestNewDoesNotConnect(t *testing.T) {
+	settings, err := LoadQuickfixSettings("./sample/fix.conf")
+	require.NoError(t, err)
+
+	c, err := New(zap.NewNop().Sugar(), Config{
+		APIKey:             "key",
+		PrivateKeyFilePath: "./sample/ed25519.pem",
+		Settings:           settings,
+	})
+	require.NoError(t, err)
+	assert.False(t, c.IsConnected())
+	assert.NoError(t, c.Close())
+}
+
+func TestWithMessageStoreFactoryOpt(t *testing.T) {
+	settings, err := LoadQuickfixSettings("./sample/fix.conf")
+	require.NoError(t, err)
+	factory := quickfix.NewMemoryStoreFactory()
+
+	c, err := New(zap.NewNop().Sugar(), Config{
+		APIKey:             "key",
+		PrivateKeyFilePath: "./sample/ed25519.pem",
+		Settings:           settings,
+		FileStore:          &FileStoreConfig{Path: t.TempDir()},
+	}, WithMessageStoreFactoryOpt(factory))
+	require.NoError(t, err)
+	assert.Equal(t, factory, c.storeFactory)
+}
+
+func TestConfigMessageStoreFactory(t *testing.T) {
+	settings, err := LoadQuickfixSettings("./sample/fix.conf")
+	require.NoError(t, err)
+	conf := Config{Settings: settings}
+
+	factory, err := conf.messageStoreFactory()
+	require.NoError(t, err)
+	assert.Equal(t, quickfix.NewMemoryStoreFactory(), factory)
+
+	conf.FileStore = &FileStoreConfig{Path: t.TempDir()}
+	factory, err = conf.messageStoreFactory()
+	require.NoError(t, err)
+	for sessionID, session := range settings.SessionSettings() {
+		// The settings of the caller are left as they were.
+		assert.False(t, session.HasSetting(config.FileStorePath))
+		assert.False(t, session.HasSetting(config.FileStoreSync))
+
+		store, err := factory.Create(sessionID)
+		require.NoError(t, err)
+		require.NoError(t, store.SetNextSenderMsgSeqNum(5))
+		require.NoError(t, store.Close())
+
+		entries, err := os.ReadDir(conf.FileStore.Path)
+		require.NoError(t, err)
+		assert.NotEmpty(t, entries)
+		store, err = factory.Create(sessionID)
+		require.NoError(t, err)
+		assert.Equal(t, 5, store.NextSenderMsgSeqNum())
+		require.NoError(t, store.Close())
+	}
+}
+
+func TestLogonResetSeqNumFlag(t *testing.T) {
+	privateKey, err := GetEd25519PrivateKeyFromFile("./sample/ed25519.pem")
+	require.NoError(t, err)
+
+	for _, reset := range []bool{true, false} {
+		c := &Client{l: zap.NewNop().Sugar(), privateKey: privateKey}
+		WithResetSeqNumFlagOpt(reset)(&c.options)
+
+		logon := newTestMessage(enum.MsgType_LOGON)
+		c.ToAdmin(logon, quickfix.SessionID{})
+		flag, err := logon.Body.GetBool(tag.ResetSeqNumFlag)
+		require.NoError(t, err)
+		assert.Equal(t, reset, flag)
+	}
+}
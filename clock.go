@@ -0,0 +1,101 @@
+package fix
+
+import (
+	"sync"
+	"time"
+
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/tag"
+)
+
+// clockSkewSamples is the number of recent inbound messages the clock skew
+// is estimated from.
+const clockSkewSamples = 64
+
+// clockSkew estimates the offset of the server clock from the local one from
+// the SendingTime<52> of inbound messages. Every sample is lowered by the
+// one-way latency of its message, so the largest recent sample is the best
+// estimate.
+type clockSkew struct {
+	mu      sync.Mutex
+	samples [clockSkewSamples]time.Duration
+	n       int // Number of samples recorded, up to clockSkewSamples.
+	next    int
+	last    time.Duration
+}
+
+func (s *clockSkew) record(sample time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.samples[s.next] = sample
+	s.next = (s.next + 1) % clockSkewSamples
+	s.n = min(s.n+1, clockSkewSamples)
+	s.last = sample
+}
+
+// estimate returns the estimated skew, false until a sample is recorded.
+func (s *clockSkew) estimate() (time.Duration, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.n == 0 {
+		return 0, false
+	}
+	skew := s.samples[0]
+	for _, sample := range s.samples[1:s.n] {
+		skew = max(skew, sample)
+	}
+	return skew, true
+}
+
+func (s *clockSkew) lastSample() time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.last
+}
+
+// WithClockSkewCompensationOpt shifts the SendingTime<52> of outgoing
+// messages by the estimated clock skew, so that they stay within the exchange
+// tolerance when the local clock drifts.
+func WithClockSkewCompensationOpt() NewClientOption {
+	return func(o *Options) {
+		o.compensateClockSkew = true
+	}
+}
+
+// recordClockSkew samples the clock skew from an inbound message.
+func (c *Client) recordClockSkew(msg *quickfix.Message) {
+	if isPossDup(msg) {
+		return
+	}
+	sendingTime, err := msg.Header.GetTime(tag.SendingTime)
+	if err != nil {
+		return
+	}
+	receivedAt := msg.ReceiveTime
+	if receivedAt.IsZero() {
+		receivedAt = time.Now()
+	}
+	c.skew.record(sendingTime.Sub(receivedAt))
+}
+
+// now returns the time to put in outgoing messages.
+func (c *Client) now() time.Time {
+	now := time.Now()
+	if !c.options.compensateClockSkew {
+		return now
+	}
+	if skew, ok := c.skew.estimate(); ok {
+		now = now.Add(skew)
+	}
+	return now
+}
+
+// stampSendingTime overrides the SendingTime<52> quickfix set on msg when
+// clock skew compensation is enabled.
+func (c *Client) stampSendingTime(msg *quickfix.Message) {
+	if c.options.compensateClockSkew {
+		msg.Header.SetString(tag.SendingTime, formatUTCTimestamp(c.now(), c.timestampPrecision))
+	}
+}
@@ -0,0 +1,59 @@
+package fix
+
+import (
+	"testing"
+	"time"
+
+	"github.com/quickfixgo/enum"
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/tag"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClockSkewEstimate(t *testing.T) {
+	var s clockSkew
+	_, ok := s.estimate()
+	assert.False(t, ok)
+
+	s.record(-time.Second)
+	s.record(2 * time.Second)
+	s.record(time.Second)
+	skew, ok := s.estimate()
+	assert.True(t, ok)
+	assert.Equal(t, 2*time.Second, skew)
+	assert.Equal(t, time.Second, s.lastSample())
+
+	// Only the recent samples count.
+	for i := 0; i < clockSkewSamples; i++ {
+		s.record(time.Millisecond)
+	}
+	skew, _ = s.estimate()
+	assert.Equal(t, time.Millisecond, skew)
+}
+
+func TestClockSkewCompensation(t *testing.T) {
+	inbound := func(c *Client, sendingTime time.Time, possDup bool) {
+		msg := newTestMessage(enum.MsgType_HEARTBEAT)
+		msg.Header.SetString(tag.SendingTime, formatUTCTimestamp(sendingTime, quickfix.Micros))
+		msg.Header.SetBool(tag.PossDupFlag, possDup)
+		msg.ReceiveTime = time.Now()
+		c.recordClockSkew(msg)
+	}
+
+	c := &Client{}
+	WithClockSkewCompensationOpt()(&c.options)
+	inbound(c, time.Now().Add(2*time.Second), false)
+	// Resent messages carry their original SendingTime.
+	inbound(c, time.Now().Add(time.Hour), true)
+
+	stats := c.Stats()
+	assert.InDelta(t, 2*time.Second, stats.ClockSkew, float64(100*time.Millisecond))
+	assert.Equal(t, stats.ClockSkew, stats.LastClockSkew)
+	assert.InDelta(t, 2*time.Second, time.Until(c.now()), float64(100*time.Millisecond))
+
+	// Without compensation, the skew is only measured.
+	c = &Client{}
+	inbound(c, time.Now().Add(2*time.Second), false)
+	assert.InDelta(t, 2*time.Second, c.Stats().ClockSkew, float64(100*time.Millisecond))
+	assert.InDelta(t, 0, time.Until(c.now()), float64(100*time.Millisecond))
+}
@@ -0,0 +1,85 @@
+// Command fixrouterd shares one FIX order entry session between services: it
+// logs a client on and serves the OrderRouter gRPC API of package routerpb,
+// placing and canceling orders and streaming execution reports through it.
+//
+//	fixrouterd -config fix.yaml -listen :50051
+//
+// The config file is a fix.FileConfig, in YAML or JSON.
+package main
+
+import (
+	"context"
+	"flag"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	fix "github.com/KyberNetwork/binance_fix_api"
+	"github.com/KyberNetwork/binance_fix_api/cmd/fixrouterd/routerpb"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+)
+
+// shutdownTimeout bounds the wait for the orders in flight and the logout
+// once the daemon is told to stop.
+const shutdownTimeout = 10 * time.Second
+
+func main() {
+	configPath := flag.String("config", "fix.yaml", "client config file, YAML or JSON")
+	listen := flag.String("listen", ":50051", "address to serve gRPC on")
+	flag.Parse()
+
+	logger, err := zap.NewProduction()
+	if err != nil {
+		panic(err)
+	}
+	l := logger.Sugar()
+	defer func() { _ = l.Sync() }()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	if err := run(ctx, l, *configPath, *listen); err != nil {
+		l.Fatalw("Order router failed", "error", err)
+	}
+}
+
+// run serves the order router on listen until ctx is done.
+func run(ctx context.Context, l *zap.SugaredLogger, configPath, listen string) error {
+	conf, opts, err := fix.LoadConfigFile(configPath)
+	if err != nil {
+		return err
+	}
+	client, err := fix.New(l, conf, append(opts, fix.WithZapLogFactory(l))...)
+	if err != nil {
+		return err
+	}
+	if err := client.Start(ctx); err != nil {
+		return err
+	}
+	defer func() {
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		if err := client.Shutdown(shutdownCtx); err != nil {
+			l.Warnw("Failed to shut the client down", "error", err)
+		}
+	}()
+
+	lis, err := net.Listen("tcp", listen)
+	if err != nil {
+		return err
+	}
+	router := newServer(client)
+	srv := grpc.NewServer()
+	routerpb.RegisterOrderRouterServer(srv, router)
+	go func() {
+		<-ctx.Done()
+		// Streams only end when their callers cancel them otherwise.
+		router.close()
+		srv.GracefulStop()
+	}()
+
+	l.Infow("Serving order router", "address", lis.Addr().String())
+	return srv.Serve(lis)
+}
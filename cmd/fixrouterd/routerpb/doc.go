@@ -0,0 +1,5 @@
+// Package routerpb holds the gRPC API of fixrouterd, generated from
+// router.proto with protoc-gen-go and protoc-gen-go-grpc.
+package routerpb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative router.proto
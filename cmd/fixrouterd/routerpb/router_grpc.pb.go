@@ -0,0 +1,230 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.4.0
+// - protoc             (unknown)
+// source: router.proto
+
+package routerpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.62.0 or later.
+const _ = grpc.SupportPackageIsVersion8
+
+const (
+	OrderRouter_PlaceOrder_FullMethodName       = "/fixrouter.v1.OrderRouter/PlaceOrder"
+	OrderRouter_Cancel_FullMethodName           = "/fixrouter.v1.OrderRouter/Cancel"
+	OrderRouter_StreamExecutions_FullMethodName = "/fixrouter.v1.OrderRouter/StreamExecutions"
+)
+
+// OrderRouterClient is the client API for OrderRouter service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// OrderRouter places and cancels orders through the FIX session of the
+// router, shared by all its callers.
+type OrderRouterClient interface {
+	// PlaceOrder sends a NewOrderSingle<D> and returns the order as of the
+	// first ExecutionReport<8> received for it.
+	PlaceOrder(ctx context.Context, in *PlaceOrderRequest, opts ...grpc.CallOption) (*Order, error)
+	// Cancel sends an OrderCancelRequest<F> and returns the canceled order.
+	Cancel(ctx context.Context, in *CancelRequest, opts ...grpc.CallOption) (*Order, error)
+	// StreamExecutions streams the ExecutionReport<8> received from the time
+	// of the call, of every caller's orders.
+	StreamExecutions(ctx context.Context, in *StreamExecutionsRequest, opts ...grpc.CallOption) (OrderRouter_StreamExecutionsClient, error)
+}
+
+type orderRouterClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewOrderRouterClient(cc grpc.ClientConnInterface) OrderRouterClient {
+	return &orderRouterClient{cc}
+}
+
+func (c *orderRouterClient) PlaceOrder(ctx context.Context, in *PlaceOrderRequest, opts ...grpc.CallOption) (*Order, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Order)
+	err := c.cc.Invoke(ctx, OrderRouter_PlaceOrder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderRouterClient) Cancel(ctx context.Context, in *CancelRequest, opts ...grpc.CallOption) (*Order, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Order)
+	err := c.cc.Invoke(ctx, OrderRouter_Cancel_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderRouterClient) StreamExecutions(ctx context.Context, in *StreamExecutionsRequest, opts ...grpc.CallOption) (OrderRouter_StreamExecutionsClient, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &OrderRouter_ServiceDesc.Streams[0], OrderRouter_StreamExecutions_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &orderRouterStreamExecutionsClient{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type OrderRouter_StreamExecutionsClient interface {
+	Recv() (*Order, error)
+	grpc.ClientStream
+}
+
+type orderRouterStreamExecutionsClient struct {
+	grpc.ClientStream
+}
+
+func (x *orderRouterStreamExecutionsClient) Recv() (*Order, error) {
+	m := new(Order)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// OrderRouterServer is the server API for OrderRouter service.
+// All implementations must embed UnimplementedOrderRouterServer
+// for forward compatibility
+//
+// OrderRouter places and cancels orders through the FIX session of the
+// router, shared by all its callers.
+type OrderRouterServer interface {
+	// PlaceOrder sends a NewOrderSingle<D> and returns the order as of the
+	// first ExecutionReport<8> received for it.
+	PlaceOrder(context.Context, *PlaceOrderRequest) (*Order, error)
+	// Cancel sends an OrderCancelRequest<F> and returns the canceled order.
+	Cancel(context.Context, *CancelRequest) (*Order, error)
+	// StreamExecutions streams the ExecutionReport<8> received from the time
+	// of the call, of every caller's orders.
+	StreamExecutions(*StreamExecutionsRequest, OrderRouter_StreamExecutionsServer) error
+	mustEmbedUnimplementedOrderRouterServer()
+}
+
+// UnimplementedOrderRouterServer must be embedded to have forward compatible implementations.
+type UnimplementedOrderRouterServer struct {
+}
+
+func (UnimplementedOrderRouterServer) PlaceOrder(context.Context, *PlaceOrderRequest) (*Order, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PlaceOrder not implemented")
+}
+func (UnimplementedOrderRouterServer) Cancel(context.Context, *CancelRequest) (*Order, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Cancel not implemented")
+}
+func (UnimplementedOrderRouterServer) StreamExecutions(*StreamExecutionsRequest, OrderRouter_StreamExecutionsServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamExecutions not implemented")
+}
+func (UnimplementedOrderRouterServer) mustEmbedUnimplementedOrderRouterServer() {}
+
+// UnsafeOrderRouterServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to OrderRouterServer will
+// result in compilation errors.
+type UnsafeOrderRouterServer interface {
+	mustEmbedUnimplementedOrderRouterServer()
+}
+
+func RegisterOrderRouterServer(s grpc.ServiceRegistrar, srv OrderRouterServer) {
+	s.RegisterService(&OrderRouter_ServiceDesc, srv)
+}
+
+func _OrderRouter_PlaceOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PlaceOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderRouterServer).PlaceOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderRouter_PlaceOrder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderRouterServer).PlaceOrder(ctx, req.(*PlaceOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderRouter_Cancel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderRouterServer).Cancel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderRouter_Cancel_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderRouterServer).Cancel(ctx, req.(*CancelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderRouter_StreamExecutions_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamExecutionsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(OrderRouterServer).StreamExecutions(m, &orderRouterStreamExecutionsServer{ServerStream: stream})
+}
+
+type OrderRouter_StreamExecutionsServer interface {
+	Send(*Order) error
+	grpc.ServerStream
+}
+
+type orderRouterStreamExecutionsServer struct {
+	grpc.ServerStream
+}
+
+func (x *orderRouterStreamExecutionsServer) Send(m *Order) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// OrderRouter_ServiceDesc is the grpc.ServiceDesc for OrderRouter service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var OrderRouter_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "fixrouter.v1.OrderRouter",
+	HandlerType: (*OrderRouterServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "PlaceOrder",
+			Handler:    _OrderRouter_PlaceOrder_Handler,
+		},
+		{
+			MethodName: "Cancel",
+			Handler:    _OrderRouter_Cancel_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamExecutions",
+			Handler:       _OrderRouter_StreamExecutions_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "router.proto",
+}
@@ -0,0 +1,275 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	fix "github.com/KyberNetwork/binance_fix_api"
+	"github.com/KyberNetwork/binance_fix_api/cmd/fixrouterd/routerpb"
+	"github.com/quickfixgo/enum"
+	"github.com/shopspring/decimal"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// executionStreamBuffer is the number of execution reports queued per stream
+// before the stream is ended for falling behind.
+const executionStreamBuffer = 1024
+
+var (
+	sides = map[fix.SideType]enum.Side{
+		fix.SideTypeBuy:  enum.Side_BUY,
+		fix.SideTypeSell: enum.Side_SELL,
+	}
+	orderTypes = map[fix.OrderType]enum.OrdType{
+		fix.OrderTypeMarket:    enum.OrdType_MARKET,
+		fix.OrderTypeLimit:     enum.OrdType_LIMIT,
+		fix.OrderTypeStop:      enum.OrdType_STOP,
+		fix.OrderTypeStopLimit: enum.OrdType_STOP_LIMIT,
+	}
+	timesInForce = map[fix.TimeInForce]enum.TimeInForce{
+		fix.TimeInForceGTC: enum.TimeInForce_GOOD_TILL_CANCEL,
+		fix.TimeInForceIOC: enum.TimeInForce_IMMEDIATE_OR_CANCEL,
+		fix.TimeInForceFOK: enum.TimeInForce_FILL_OR_KILL,
+	}
+	triggerDirections = map[string]fix.TriggerDirection{
+		"UP":   fix.TriggerDirectionUp,
+		"DOWN": fix.TriggerDirectionDown,
+	}
+)
+
+// server serves the OrderRouter API with a single client, whose session is
+// shared by all the callers.
+type server struct {
+	routerpb.UnimplementedOrderRouterServer
+	client fix.OrderEntryClient
+
+	mu      sync.Mutex
+	streams map[*executionStream]struct{}
+
+	done      chan struct{} // Closed by close.
+	closeOnce sync.Once
+}
+
+// executionStream is a StreamExecutions call being served.
+type executionStream struct {
+	symbol string // Empty for all symbols.
+	orders chan *routerpb.Order
+	lagged chan struct{} // Closed once an order could not be queued.
+}
+
+func newServer(client fix.OrderEntryClient) *server {
+	s := &server{
+		client:  client,
+		streams: make(map[*executionStream]struct{}),
+		done:    make(chan struct{}),
+	}
+	client.SubscribeToExecutionReport(s.publish)
+	return s
+}
+
+func (s *server) PlaceOrder(ctx context.Context, req *routerpb.PlaceOrderRequest) (*routerpb.Order, error) {
+	svc := s.client.NewOrderSingleService().Symbol(req.Symbol)
+	if req.ClientOrderId != "" {
+		svc.ClOrdID(req.ClientOrderId)
+	}
+
+	side, ok := sides[fix.SideType(req.Side)]
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "unknown side %q", req.Side)
+	}
+	svc.Side(side)
+	orderType, ok := orderTypes[fix.OrderType(req.Type)]
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "unknown type %q", req.Type)
+	}
+	svc.Type(orderType)
+	if req.TimeInForce != "" {
+		timeInForce, ok := timesInForce[fix.TimeInForce(req.TimeInForce)]
+		if !ok {
+			return nil, status.Errorf(codes.InvalidArgument, "unknown time in force %q", req.TimeInForce)
+		}
+		svc.TimeInForce(timeInForce)
+	}
+
+	for _, a := range []struct {
+		name  string
+		value string
+		set   func(decimal.Decimal)
+	}{
+		{"quantity", req.Quantity, func(d decimal.Decimal) { svc.QuantityDecimal(d) }},
+		{"quote quantity", req.QuoteQuantity, func(d decimal.Decimal) { svc.CashOrderQtyDecimal(d) }},
+		{"price", req.Price, func(d decimal.Decimal) { svc.PriceDecimal(d) }},
+	} {
+		if a.value == "" {
+			continue
+		}
+		d, err := decimal.NewFromString(a.value)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid %s %q", a.name, a.value)
+		}
+		a.set(d)
+	}
+	if req.TriggerPrice != "" {
+		price, err := decimal.NewFromString(req.TriggerPrice)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid trigger price %q", req.TriggerPrice)
+		}
+		direction, ok := triggerDirections[req.TriggerDirection]
+		if !ok {
+			return nil, status.Errorf(codes.InvalidArgument, "unknown trigger direction %q", req.TriggerDirection)
+		}
+		svc.TriggerPriceDecimal(price, direction)
+	}
+
+	order, err := svc.Do(ctx)
+	if err != nil {
+		return nil, statusOf(err)
+	}
+	return orderOf(order), nil
+}
+
+func (s *server) Cancel(ctx context.Context, req *routerpb.CancelRequest) (*routerpb.Order, error) {
+	svc := s.client.NewOrderCancelService().Symbol(req.Symbol)
+	if req.OrigClientOrderId != "" {
+		svc.OrigClientOrderID(req.OrigClientOrderId)
+	}
+	if req.OrderId != 0 {
+		svc.OrderID(req.OrderId)
+	}
+
+	order, err := svc.Do(ctx)
+	if err != nil {
+		return nil, statusOf(err)
+	}
+	return orderOf(order), nil
+}
+
+// StreamExecutions sends the execution reports received until the call is
+// canceled. A stream which falls behind by executionStreamBuffer reports is
+// ended with ResourceExhausted rather than holding the session back, the
+// caller can reconcile with the orders it missed and stream again.
+func (s *server) StreamExecutions(req *routerpb.StreamExecutionsRequest, stream routerpb.OrderRouter_StreamExecutionsServer) error {
+	st := &executionStream{
+		symbol: req.Symbol,
+		orders: make(chan *routerpb.Order, executionStreamBuffer),
+		lagged: make(chan struct{}),
+	}
+	s.mu.Lock()
+	s.streams[st] = struct{}{}
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		delete(s.streams, st)
+		s.mu.Unlock()
+	}()
+
+	ctx := stream.Context()
+	for {
+		select {
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		case <-s.done:
+			return status.Error(codes.Unavailable, "order router is shutting down")
+		case <-st.lagged:
+			return status.Error(codes.ResourceExhausted, "stream fell behind the execution reports")
+		case order := <-st.orders:
+			if err := stream.Send(order); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// close ends the streams being served, and those started afterwards.
+func (s *server) close() {
+	s.closeOnce.Do(func() { close(s.done) })
+}
+
+// publish queues o to the streams of its symbol, ending those which are full.
+func (s *server) publish(o *fix.Order) {
+	order := orderOf(*o)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for st := range s.streams {
+		if st.symbol != "" && st.symbol != o.Symbol {
+			continue
+		}
+		select {
+		case st.orders <- order:
+		default:
+			delete(s.streams, st)
+			close(st.lagged)
+		}
+	}
+}
+
+// statusOf returns the gRPC status of an error of the client.
+func statusOf(err error) error {
+	var (
+		orderRejected  *fix.OrderRejectedError
+		cancelRejected *fix.CancelRejectedError
+	)
+	switch {
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return status.FromContextError(err).Err()
+	case errors.Is(err, fix.ErrInvalidOrder), errors.Is(err, fix.ErrNoOrigOrder):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, fix.ErrRateLimited):
+		return status.Error(codes.ResourceExhausted, err.Error())
+	case errors.As(err, &orderRejected), errors.As(err, &cancelRejected):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, fix.ErrClosed), errors.Is(err, fix.ErrMaintenance), errors.Is(err, fix.ErrShutdown):
+		return status.Error(codes.Unavailable, err.Error())
+	default:
+		return status.Error(codes.Unknown, err.Error())
+	}
+}
+
+func orderOf(o fix.Order) *routerpb.Order {
+	a := exactAmounts(o)
+	order := &routerpb.Order{
+		Symbol:            o.Symbol,
+		OrderId:           o.OrderID,
+		ClientOrderId:     o.ClientOrderID,
+		OrigClientOrderId: o.OrigClientOrderID,
+		Status:            string(o.Status),
+		ExecType:          string(o.ExecType),
+		ExecId:            o.ExecID,
+		Side:              string(o.Side),
+		Type:              string(o.Type),
+		TimeInForce:       string(o.TimeInForce),
+		Price:             a.Price.String(),
+		OrderQty:          a.OrderQty.String(),
+		CashOrderQty:      a.CashOrderQty.String(),
+		CumQty:            a.CumQty.String(),
+		CumQuoteQty:       a.CumQuoteQty.String(),
+		LastPx:            a.LastPx.String(),
+		LastQty:           a.LastQty.String(),
+	}
+	if !o.TransactTime.IsZero() {
+		order.TransactTime = timestamppb.New(o.TransactTime)
+	}
+	return order
+}
+
+// exactAmounts returns the amounts of o as sent by the server, or from its
+// float64 fields if o was not decoded from a message.
+func exactAmounts(o fix.Order) fix.ExactAmounts {
+	if o.Exact != nil {
+		return *o.Exact
+	}
+	return fix.ExactAmounts{
+		Price:        decimal.NewFromFloat(o.Price),
+		OrderQty:     decimal.NewFromFloat(o.OrderQty),
+		CashOrderQty: decimal.NewFromFloat(o.CashOrderQty),
+		CumQty:       decimal.NewFromFloat(o.CumQty),
+		CumQuoteQty:  decimal.NewFromFloat(o.CumQuoteQty),
+		LastPx:       decimal.NewFromFloat(o.LastPx),
+		LastQty:      decimal.NewFromFloat(o.LastQty),
+	}
+}
@@ -0,0 +1,205 @@
+package main
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	fix "github.com/KyberNetwork/binance_fix_api"
+	"github.com/KyberNetwork/binance_fix_api/cmd/fixrouterd/routerpb"
+	"github.com/quickfixgo/enum"
+	"github.com/quickfixgo/field"
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/tag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// exchange is a fake exchange accepting every order and cancel.
+type exchange struct {
+	mu       sync.Mutex
+	requests []*quickfix.Message
+}
+
+func (e *exchange) call(_ context.Context, _ string, msg *quickfix.Message) (*quickfix.Message, error) {
+	e.mu.Lock()
+	e.requests = append(e.requests, msg)
+	e.mu.Unlock()
+
+	report := quickfix.NewMessage()
+	report.Header.Set(field.NewMsgType(enum.MsgType_EXECUTION_REPORT))
+	for _, t := range []quickfix.Tag{tag.ClOrdID, tag.OrigClOrdID, tag.Symbol, tag.Side, tag.OrdType, tag.OrderQty, tag.Price} {
+		if v, err := msg.Body.GetString(t); err == nil {
+			report.Body.SetString(t, v)
+		}
+	}
+	report.Body.SetString(tag.OrderID, "1")
+	if msg.IsMsgTypeOf(string(enum.MsgType_ORDER_CANCEL_REQUEST)) {
+		report.Body.SetString(tag.Side, string(enum.Side_BUY))
+		report.Body.SetString(tag.OrdType, string(enum.OrdType_LIMIT))
+		report.Body.SetString(tag.OrdStatus, string(enum.OrdStatus_CANCELED))
+		report.Body.SetString(tag.ExecType, string(enum.ExecType_CANCELED))
+	} else {
+		report.Body.SetString(tag.OrdStatus, string(enum.OrdStatus_NEW))
+		report.Body.SetString(tag.ExecType, string(enum.ExecType_NEW))
+	}
+	return report, nil
+}
+
+// lastRequest returns the last message sent to the exchange.
+func (e *exchange) lastRequest(t *testing.T) *quickfix.Message {
+	t.Helper()
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	require.NotEmpty(t, e.requests)
+	return e.requests[len(e.requests)-1]
+}
+
+// newTestRouter serves a router backed by a client of ex and returns a
+// client of the router.
+func newTestRouter(t *testing.T, ex *exchange) (*server, routerpb.OrderRouterClient) {
+	t.Helper()
+
+	router := newServer(fix.NewWithCaller(fix.CallerFunc(ex.call)))
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer()
+	routerpb.RegisterOrderRouterServer(srv, router)
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return router, routerpb.NewOrderRouterClient(conn)
+}
+
+func TestPlaceOrder(t *testing.T) {
+	ex := &exchange{}
+	_, client := newTestRouter(t, ex)
+	ctx := context.Background()
+
+	order, err := client.PlaceOrder(ctx, &routerpb.PlaceOrderRequest{
+		ClientOrderId: "a",
+		Symbol:        "BTCUSDT",
+		Side:          "BUY",
+		Type:          "LIMIT",
+		TimeInForce:   "GOOD_TILL_CANCEL",
+		Quantity:      "0.1",
+		Price:         "42000.5",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "a", order.ClientOrderId)
+	assert.Equal(t, string(fix.OrderStatusNew), order.Status)
+	assert.Equal(t, "0.1", order.OrderQty)
+	assert.Equal(t, "42000.5", order.Price)
+
+	msg := ex.lastRequest(t)
+	assert.True(t, msg.IsMsgTypeOf(string(enum.MsgType_ORDER_SINGLE)))
+	for k, want := range map[quickfix.Tag]string{
+		tag.ClOrdID:     "a",
+		tag.Symbol:      "BTCUSDT",
+		tag.Side:        string(enum.Side_BUY),
+		tag.OrdType:     string(enum.OrdType_LIMIT),
+		tag.TimeInForce: string(enum.TimeInForce_GOOD_TILL_CANCEL),
+		tag.OrderQty:    "0.1",
+		tag.Price:       "42000.5",
+	} {
+		value, err := msg.Body.GetString(k)
+		require.NoError(t, err)
+		assert.Equal(t, want, value, "tag %d", k)
+	}
+
+	for _, req := range []*routerpb.PlaceOrderRequest{
+		{Symbol: "BTCUSDT", Side: "HOLD", Type: "MARKET", Quantity: "1"},
+		{Symbol: "BTCUSDT", Side: "BUY", Type: "MARKET", Quantity: "one"},
+		{Symbol: "BTCUSDT", Side: "BUY", Type: "STOP", Quantity: "1", TriggerPrice: "40000"},
+		{Side: "BUY", Type: "MARKET", Quantity: "1"},
+	} {
+		_, err := client.PlaceOrder(ctx, req)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err), "%v", req)
+	}
+}
+
+func TestCancel(t *testing.T) {
+	ex := &exchange{}
+	_, client := newTestRouter(t, ex)
+	ctx := context.Background()
+
+	order, err := client.Cancel(ctx, &routerpb.CancelRequest{Symbol: "BTCUSDT", OrigClientOrderId: "a"})
+	require.NoError(t, err)
+	assert.Equal(t, string(fix.OrderStatusCanceled), order.Status)
+	orig, err := ex.lastRequest(t).Body.GetString(tag.OrigClOrdID)
+	require.NoError(t, err)
+	assert.Equal(t, "a", orig)
+
+	_, err = client.Cancel(ctx, &routerpb.CancelRequest{Symbol: "BTCUSDT"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestStreamExecutions(t *testing.T) {
+	ex := &exchange{}
+	router, client := newTestRouter(t, ex)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stream, err := client.StreamExecutions(ctx, &routerpb.StreamExecutionsRequest{Symbol: "BTCUSDT"})
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		router.mu.Lock()
+		defer router.mu.Unlock()
+		return len(router.streams) == 1
+	}, time.Second, time.Millisecond)
+
+	for _, symbol := range []string{"ETHUSDT", "BTCUSDT"} {
+		_, err := client.PlaceOrder(ctx, &routerpb.PlaceOrderRequest{
+			ClientOrderId: symbol,
+			Symbol:        symbol,
+			Side:          "SELL",
+			Type:          "MARKET",
+			Quantity:      "1",
+		})
+		require.NoError(t, err)
+	}
+
+	// Only the order of the symbol streamed is received.
+	order, err := stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, "BTCUSDT", order.ClientOrderId)
+
+	router.close()
+	_, err = stream.Recv()
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+}
+
+func TestStreamExecutionsLagging(t *testing.T) {
+	router := newServer(fix.NewWithCaller(fix.CallerFunc((&exchange{}).call)))
+	st := &executionStream{
+		orders: make(chan *routerpb.Order, 1),
+		lagged: make(chan struct{}),
+	}
+	router.streams[st] = struct{}{}
+
+	router.publish(&fix.Order{ClientOrderID: "a"})
+	router.publish(&fix.Order{ClientOrderID: "b"})
+
+	// The stream is ended instead of holding back the session.
+	assert.Empty(t, router.streams)
+	select {
+	case <-st.lagged:
+	default:
+		t.Fatal("stream not ended")
+	}
+	assert.Equal(t, "a", (<-st.orders).ClientOrderId)
+}
@@ -0,0 +1,156 @@
+package fix
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/quickfix/config"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	defaultBeginString  = quickfix.BeginStringFIX44
+	defaultTargetCompID = "SPOT"
+	defaultHeartBtInt   = 30
+)
+
+// FileConfig describes a client in YAML or JSON, as an alternative to a
+// quickfix INI file. Settings holds any other quickfix setting by name, e.g.
+// "SocketTimeout".
+type FileConfig struct {
+	Host         string `json:"host" yaml:"host"`
+	Port         int    `json:"port" yaml:"port"`
+	BeginString  string `json:"begin_string" yaml:"begin_string"`     // FIX.4.4 if empty.
+	SenderCompID string `json:"sender_comp_id" yaml:"sender_comp_id"` // Required.
+	TargetCompID string `json:"target_comp_id" yaml:"target_comp_id"` // SPOT if empty.
+	HeartBtInt   int    `json:"heart_bt_int" yaml:"heart_bt_int"`     // 30 if zero.
+	UseSSL       *bool  `json:"use_ssl" yaml:"use_ssl"`               // True if unset.
+	CAFile       string `json:"ca_file" yaml:"ca_file"`
+
+	APIKey             string             `json:"api_key" yaml:"api_key"`
+	PrivateKeyFilePath string             `json:"private_key_file_path" yaml:"private_key_file_path"`
+	DataDictionaryPath string             `json:"data_dictionary_path" yaml:"data_dictionary_path"`
+	TimestampPrecision TimestampPrecision `json:"timestamp_precision" yaml:"timestamp_precision"`
+	FileStorePath      string             `json:"file_store_path" yaml:"file_store_path"` // In-memory store if empty.
+	FileStoreSync      bool               `json:"file_store_sync" yaml:"file_store_sync"`
+
+	MessageHandling MessageHandling `json:"message_handling" yaml:"message_handling"` // Sequential if zero.
+	ResponseMode    ResponseMode    `json:"response_mode" yaml:"response_mode"`       // Everything if zero.
+
+	Settings map[string]string `json:"settings" yaml:"settings"`
+}
+
+// ParseConfigJSON parses a JSON FileConfig into a Config and the matching
+// client options.
+func ParseConfigJSON(data []byte) (Config, []NewClientOption, error) {
+	var fc FileConfig
+	if err := json.Unmarshal(data, &fc); err != nil {
+		return Config{}, nil, err
+	}
+	return fc.Build()
+}
+
+// ParseConfigYAML parses a YAML FileConfig into a Config and the matching
+// client options.
+func ParseConfigYAML(data []byte) (Config, []NewClientOption, error) {
+	var fc FileConfig
+	if err := yaml.Unmarshal(data, &fc); err != nil {
+		return Config{}, nil, err
+	}
+	return fc.Build()
+}
+
+// LoadConfigFile reads a FileConfig from a .json, .yaml or .yml file.
+func LoadConfigFile(path string) (Config, []NewClientOption, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Config{}, nil, err
+	}
+
+	switch ext := filepath.Ext(path); ext {
+	case ".json":
+		return ParseConfigJSON(data)
+	case ".yaml", ".yml":
+		return ParseConfigYAML(data)
+	default:
+		return Config{}, nil, fmt.Errorf("unsupported config file extension %q", ext)
+	}
+}
+
+// Build converts the file config into a Config and client options.
+func (fc FileConfig) Build() (Config, []NewClientOption, error) {
+	settings, err := fc.settings()
+	if err != nil {
+		return Config{}, nil, err
+	}
+
+	conf := Config{
+		APIKey:             fc.APIKey,
+		PrivateKeyFilePath: fc.PrivateKeyFilePath,
+		Settings:           settings,
+		DataDictionaryPath: fc.DataDictionaryPath,
+		TimestampPrecision: fc.TimestampPrecision,
+	}
+	if fc.FileStorePath != "" {
+		conf.FileStore = &FileStoreConfig{Path: fc.FileStorePath, Sync: fc.FileStoreSync}
+	}
+
+	var opts []NewClientOption
+	if fc.MessageHandling != 0 {
+		opts = append(opts, WithMessageHandlingOpt(fc.MessageHandling))
+	}
+	if fc.ResponseMode != 0 {
+		opts = append(opts, WithResponseModeOpt(fc.ResponseMode))
+	}
+
+	return conf, opts, nil
+}
+
+func (fc FileConfig) settings() (*quickfix.Settings, error) {
+	if fc.Host == "" || fc.Port == 0 {
+		return nil, errors.New("missing host or port")
+	}
+	if fc.SenderCompID == "" {
+		return nil, errors.New("missing sender comp id")
+	}
+
+	settings := quickfix.NewSettings()
+	global := settings.GlobalSettings()
+	for name, value := range fc.Settings {
+		global.Set(name, value)
+	}
+
+	global.Set(config.BeginString, valueOr(fc.BeginString, defaultBeginString))
+	global.Set(config.SenderCompID, fc.SenderCompID)
+	global.Set(config.TargetCompID, valueOr(fc.TargetCompID, defaultTargetCompID))
+	global.Set(config.SocketConnectHost, fc.Host)
+	global.Set(config.SocketConnectPort, strconv.Itoa(fc.Port))
+	global.Set(config.HeartBtInt, strconv.Itoa(valueOr(fc.HeartBtInt, defaultHeartBtInt)))
+	if fc.UseSSL == nil || *fc.UseSSL {
+		global.Set(config.SocketUseSSL, "Y")
+	} else {
+		global.Set(config.SocketUseSSL, "N")
+	}
+	if fc.CAFile != "" {
+		global.Set(config.SocketCAFile, fc.CAFile)
+	}
+
+	if _, err := settings.AddSession(quickfix.NewSessionSettings()); err != nil {
+		return nil, err
+	}
+
+	return settings, nil
+}
+
+func valueOr[T comparable](v, fallback T) T {
+	var zero T
+	if v == zero {
+		return fallback
+	}
+	return v
+}
@@ -0,0 +1,66 @@
+package fix
+
+import (
+	"testing"
+
+	"github.com/quickfixgo/quickfix/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseConfig(t *testing.T) {
+	yamlConf := []byte(`
+host: fix-oe.binance.com
+port: 9000
+sender_comp_id: EXAMPLE
+api_key: key
+private_key_file_path: key.pem
+file_store_path: /tmp/store
+response_mode: 2
+settings:
+  SocketTimeout: 5s
+`)
+	jsonConf := []byte(`{
+	"host": "fix-oe.binance.com",
+	"port": 9000,
+	"sender_comp_id": "EXAMPLE",
+	"api_key": "key",
+	"private_key_file_path": "key.pem",
+	"file_store_path": "/tmp/store",
+	"response_mode": 2,
+	"settings": {"SocketTimeout": "5s"}
+}`)
+
+	for name, parse := range map[string]func() (Config, []NewClientOption, error){
+		"yaml": func() (Config, []NewClientOption, error) { return ParseConfigYAML(yamlConf) },
+		"json": func() (Config, []NewClientOption, error) { return ParseConfigJSON(jsonConf) },
+	} {
+		conf, opts, err := parse()
+		require.NoError(t, err, name)
+
+		assert.Equal(t, "key", conf.APIKey, name)
+		assert.Equal(t, "key.pem", conf.PrivateKeyFilePath, name)
+		assert.Equal(t, &FileStoreConfig{Path: "/tmp/store"}, conf.FileStore, name)
+		assert.Len(t, opts, 1, name)
+
+		global := conf.Settings.GlobalSettings()
+		for setting, want := range map[string]string{
+			config.BeginString:       "FIX.4.4",
+			config.SenderCompID:      "EXAMPLE",
+			config.TargetCompID:      "SPOT",
+			config.SocketConnectHost: "fix-oe.binance.com",
+			config.SocketConnectPort: "9000",
+			config.HeartBtInt:        "30",
+			config.SocketUseSSL:      "Y",
+			"SocketTimeout":          "5s",
+		} {
+			got, err := global.Setting(setting)
+			assert.NoError(t, err, name)
+			assert.Equal(t, want, got, name)
+		}
+		assert.Len(t, conf.Settings.SessionSettings(), 1, name)
+	}
+
+	_, _, err := ParseConfigJSON([]byte(`{"host": "fix-oe.binance.com", "port": 9000}`))
+	assert.Error(t, err)
+}
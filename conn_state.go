@@ -0,0 +1,60 @@
+package fix
+
+// ConnState is a stage of the session lifecycle.
+type ConnState int
+
+const (
+	ConnStateDisconnected ConnState = iota
+	ConnStateConnecting
+	ConnStateLoggedOn
+	ConnStateLoggingOut
+)
+
+func (s ConnState) String() string {
+	switch s {
+	case ConnStateDisconnected:
+		return "DISCONNECTED"
+	case ConnStateConnecting:
+		return "CONNECTING"
+	case ConnStateLoggedOn:
+		return "LOGGED_ON"
+	case ConnStateLoggingOut:
+		return "LOGGING_OUT"
+	}
+	return "UNKNOWN"
+}
+
+// stateChangesSize is the capacity of the channel returned by StateChanges.
+const stateChangesSize = 64
+
+// StateChanges returns a channel receiving every change of the session
+// state. Changes are dropped while the channel is full, so it must be
+// drained continuously.
+func (c *Client) StateChanges() <-chan ConnState {
+	c.stateMu.Lock()
+	defer c.stateMu.Unlock()
+
+	if c.stateChanges == nil {
+		c.stateChanges = make(chan ConnState, stateChangesSize)
+	}
+	return c.stateChanges
+}
+
+// setState records the session state, publishing it if it changed.
+func (c *Client) setState(s ConnState) {
+	c.stateMu.Lock()
+	defer c.stateMu.Unlock()
+
+	if s == c.state {
+		return
+	}
+	c.state = s
+	if c.stateChanges == nil {
+		return
+	}
+	select {
+	case c.stateChanges <- s:
+	default:
+		c.l.Warnw("Dropped connection state change for a slow reader", "state", s)
+	}
+}
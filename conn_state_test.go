@@ -0,0 +1,29 @@
+package fix
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestStateChanges(t *testing.T) {
+	c := &Client{l: zap.NewNop().Sugar()}
+	c.setState(ConnStateConnecting) // Not published before StateChanges is called.
+
+	changes := c.StateChanges()
+	c.setState(ConnStateLoggedOn)
+	c.setState(ConnStateLoggedOn)
+	c.setState(ConnStateDisconnected)
+
+	assert.Equal(t, ConnStateLoggedOn, <-changes)
+	assert.Equal(t, ConnStateDisconnected, <-changes)
+	assert.Empty(t, changes)
+
+	// A full channel never blocks the session.
+	for i := 0; i < stateChangesSize+1; i++ {
+		c.setState(ConnState(i % 2))
+	}
+	assert.Len(t, changes, stateChangesSize)
+	assert.Equal(t, "LOGGED_ON", ConnStateLoggedOn.String())
+}
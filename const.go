@@ -1,38 +1,50 @@
 package fix
 
 import (
+	"github.com/KyberNetwork/binance_fix_api/binancetag"
 	"github.com/quickfixgo/enum"
 	"github.com/quickfixgo/quickfix"
 	"github.com/quickfixgo/tag"
 )
 
 const (
-	tagMessageHandling quickfix.Tag = 25035
-	tagResponseMode    quickfix.Tag = 25036
-	tagGetLimitReqID   quickfix.Tag = 6136
-
-	tagNoLimitIndicators            quickfix.Tag = 25003
-	tagLimitType                    quickfix.Tag = 25004
-	tagLimitCount                   quickfix.Tag = 25005
-	tagLimitMax                     quickfix.Tag = 25006
-	tagLimitResetInterval           quickfix.Tag = 25007
-	tagLimitResetIntervalResolution quickfix.Tag = 25008
-
-	tagCumQuoteQty       quickfix.Tag = 25017
-	tagOrderCreationTime quickfix.Tag = 25018
-	tagWorkingTime       quickfix.Tag = 25023
-
-	ExecutionReportTopic = "ExecutionReport<8>"
+	tagMessageHandling = binancetag.MessageHandling
+	tagResponseMode    = binancetag.ResponseMode
+	tagGetLimitReqID   = binancetag.ReqID
+
+	tagNoLimitIndicators            = binancetag.NoLimitIndicators
+	tagLimitType                    = binancetag.LimitType
+	tagLimitCount                   = binancetag.LimitCount
+	tagLimitMax                     = binancetag.LimitMax
+	tagLimitResetInterval           = binancetag.LimitResetInterval
+	tagLimitResetIntervalResolution = binancetag.LimitResetIntervalResolution
+
+	tagCumQuoteQty       = binancetag.CumQuoteQty
+	tagOrderCreationTime = binancetag.OrderCreationTime
+	tagWorkingTime       = binancetag.WorkingTime
+
+	ExecutionReportTopic   = "ExecutionReport<8>"
+	NewsTopic              = "News<B>"
+	ListStatusTopic        = "ListStatus<N>"
+	InternalErrorTopic     = "InternalError"
+	ScheduledDowntimeTopic = "ScheduledDowntime"
 )
 
 const (
-	msgType_LIMIT_REQUEST  enum.MsgType = "XLQ"
-	msgType_LIMIT_RESPONSE enum.MsgType = "XLR"
+	msgType_LIMIT_REQUEST        enum.MsgType = "XLQ"
+	msgType_LIMIT_RESPONSE       enum.MsgType = "XLR"
+	msgType_CANCEL_REPLACE_ORDER enum.MsgType = "XCN"
+	msgType_AMEND_KEEP_PRIORITY  enum.MsgType = "XAK"
+	msgType_AMEND_REJECT         enum.MsgType = "XAR"
 )
 
 var mappedMsgTypeTag = map[enum.MsgType]quickfix.Tag{
-	msgType_LIMIT_RESPONSE:        tagGetLimitReqID,
-	enum.MsgType_EXECUTION_REPORT: tag.ClOrdID,
+	msgType_LIMIT_RESPONSE:                tagGetLimitReqID,
+	enum.MsgType_EXECUTION_REPORT:         tag.ClOrdID,
+	enum.MsgType_ORDER_CANCEL_REJECT:      tag.ClOrdID,
+	enum.MsgType_LIST_STATUS:              binancetag.ClListID,
+	enum.MsgType_ORDER_MASS_CANCEL_REPORT: tag.ClOrdID,
+	msgType_AMEND_REJECT:                  tag.ClOrdID,
 }
 
 func getReqIDTagFromMsgType(msgType enum.MsgType) (quickfix.Tag, error) {
@@ -68,8 +80,22 @@ const (
 	OrderStatusRejected        OrderStatus = "REJECTED"
 	OrderStatusPendingNew      OrderStatus = "PENDING_NEW"
 	OrderStatusExpired         OrderStatus = "EXPIRED"
+
+	// OrderStatusUnknown is an OrdStatus<39> this package does not know yet.
+	OrderStatusUnknown OrderStatus = "UNKNOWN"
 )
 
+// IsTerminal reports whether no further updates are expected for an order in
+// this status.
+func (s OrderStatus) IsTerminal() bool {
+	switch s {
+	case OrderStatusFilled, OrderStatusCanceled, OrderStatusRejected, OrderStatusExpired:
+		return true
+	default:
+		return false
+	}
+}
+
 var mappedOrderStatus = map[enum.OrdStatus]OrderStatus{
 	enum.OrdStatus_NEW:              OrderStatusNew,
 	enum.OrdStatus_PARTIALLY_FILLED: OrderStatusPartiallyFilled,
@@ -81,12 +107,37 @@ var mappedOrderStatus = map[enum.OrdStatus]OrderStatus{
 	enum.OrdStatus_EXPIRED:          OrderStatusExpired,
 }
 
+// ExecType is the ExecType<150> of an execution report: what happened to
+// the order, as opposed to the state it is in.
+type ExecType string
+
+const (
+	ExecTypeNew      ExecType = "NEW"
+	ExecTypeCanceled ExecType = "CANCELED"
+	ExecTypeReplaced ExecType = "REPLACED"
+	ExecTypeRejected ExecType = "REJECTED"
+	ExecTypeTrade    ExecType = "TRADE"
+	ExecTypeExpired  ExecType = "EXPIRED"
+	ExecTypeUnknown  ExecType = "UNKNOWN"
+)
+
+var mappedExecType = map[enum.ExecType]ExecType{
+	enum.ExecType_NEW:      ExecTypeNew,
+	enum.ExecType_CANCELED: ExecTypeCanceled,
+	enum.ExecType_REPLACED: ExecTypeReplaced,
+	enum.ExecType_REJECTED: ExecTypeRejected,
+	enum.ExecType_TRADE:    ExecTypeTrade,
+	enum.ExecType_EXPIRED:  ExecTypeExpired,
+}
+
 type TimeInForce string
 
 const (
 	TimeInForceGTC TimeInForce = "GOOD_TILL_CANCEL"
 	TimeInForceIOC TimeInForce = "IMMEDIATE_OR_CANCEL"
 	TimeInForceFOK TimeInForce = "FILL_OR_KILL"
+
+	TimeInForceUnknown TimeInForce = "UNKNOWN"
 )
 
 var mappedTimeInForce = map[enum.TimeInForce]TimeInForce{
@@ -102,6 +153,7 @@ const (
 	OrderTypeLimit     OrderType = "LIMIT"
 	OrderTypeStop      OrderType = "STOP"
 	OrderTypeStopLimit OrderType = "STOP_LIMIT"
+	OrderTypeUnknown   OrderType = "UNKNOWN"
 )
 
 var mappedOrderType = map[enum.OrdType]OrderType{
@@ -116,9 +168,44 @@ type SideType string
 const (
 	SideTypeBuy  SideType = "BUY"
 	SideTypeSell SideType = "SELL"
+
+	SideTypeUnknown SideType = "UNKNOWN"
 )
 
 var mappedSideType = map[enum.Side]SideType{
 	enum.Side_BUY:  SideTypeBuy,
 	enum.Side_SELL: SideTypeSell,
 }
+
+type TimestampPrecision string
+
+const (
+	TimestampPrecisionSeconds TimestampPrecision = "SECONDS"
+	TimestampPrecisionMillis  TimestampPrecision = "MILLIS"
+	TimestampPrecisionMicros  TimestampPrecision = "MICROS"
+	TimestampPrecisionNanos   TimestampPrecision = "NANOS"
+)
+
+var mappedTimestampPrecision = map[TimestampPrecision]quickfix.TimestampPrecision{
+	TimestampPrecisionSeconds: quickfix.Seconds,
+	TimestampPrecisionMillis:  quickfix.Millis,
+	TimestampPrecisionMicros:  quickfix.Micros,
+	TimestampPrecisionNanos:   quickfix.Nanos,
+}
+
+// WorkingFloor<25021> tells where an order potentially having allocations is
+// working.
+type WorkingFloor string
+
+const (
+	WorkingFloorExchange WorkingFloor = "EXCHANGE"
+	WorkingFloorBroker   WorkingFloor = "BROKER"
+	WorkingFloorSOR      WorkingFloor = "SOR"
+	WorkingFloorUnknown  WorkingFloor = "UNKNOWN"
+)
+
+var mappedWorkingFloor = map[string]WorkingFloor{
+	"1": WorkingFloorExchange,
+	"2": WorkingFloorBroker,
+	"3": WorkingFloorSOR,
+}
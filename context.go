@@ -0,0 +1,118 @@
+package fix
+
+import (
+	"context"
+	"sync"
+
+	"github.com/quickfixgo/enum"
+	"github.com/quickfixgo/quickfix"
+	"go.uber.org/zap"
+)
+
+type loggerCtxKey struct{}
+
+// ContextWithLogger returns a copy of ctx carrying l. Service calls made with
+// the returned context log through l instead of the client logger, so request
+// scoped fields such as correlation IDs end up in every related log entry.
+func ContextWithLogger(ctx context.Context, l *zap.SugaredLogger) context.Context {
+	return context.WithValue(ctx, loggerCtxKey{}, l)
+}
+
+// logger returns the logger attached to ctx, falling back to the client logger.
+func (c *Client) logger(ctx context.Context) *zap.SugaredLogger {
+	if l, ok := ctx.Value(loggerCtxKey{}).(*zap.SugaredLogger); ok && l != nil {
+		return l
+	}
+	return c.l
+}
+
+type metadataCtxKey struct{}
+
+// Metadata labels an order for attribution, e.g. with a trace ID or the desk
+// and strategy it belongs to.
+type Metadata map[string]string
+
+// ContextWithMetadata returns a copy of ctx carrying md, merged over the
+// metadata ctx already carries. Orders placed with the returned context carry
+// the metadata on the Order returned by the service and on every execution
+// report delivered for them, until they reach a terminal state.
+func ContextWithMetadata(ctx context.Context, md Metadata) context.Context {
+	merged := make(Metadata, len(md))
+	for k, v := range MetadataFromContext(ctx) {
+		merged[k] = v
+	}
+	for k, v := range md {
+		merged[k] = v
+	}
+	return context.WithValue(ctx, metadataCtxKey{}, merged)
+}
+
+// MetadataFromContext returns the metadata attached to ctx, if any.
+func MetadataFromContext(ctx context.Context) Metadata {
+	md, _ := ctx.Value(metadataCtxKey{}).(Metadata)
+	return md
+}
+
+// metadataMsgTypes are the requests whose ClOrdID<11> an order is known by
+// afterwards. The metadata of other requests is not kept.
+var metadataMsgTypes = map[enum.MsgType]bool{
+	enum.MsgType_ORDER_SINGLE:    true,
+	msgType_CANCEL_REPLACE_ORDER: true,
+	msgType_AMEND_KEEP_PRIORITY:  true,
+}
+
+// orderMetadata holds the metadata of the open orders, keyed by ClOrdID.
+type orderMetadata struct {
+	mu sync.Mutex
+	m  map[string]Metadata
+}
+
+func (om *orderMetadata) set(clOrdID string, md Metadata) {
+	om.mu.Lock()
+	defer om.mu.Unlock()
+
+	if om.m == nil {
+		om.m = make(map[string]Metadata)
+	}
+	om.m[clOrdID] = md
+}
+
+func (om *orderMetadata) get(clOrdID string) Metadata {
+	om.mu.Lock()
+	defer om.mu.Unlock()
+
+	return om.m[clOrdID]
+}
+
+// rename moves the metadata of an order which took the ClOrdID to, unless the
+// request for to carried its own.
+func (om *orderMetadata) rename(from, to string) {
+	om.mu.Lock()
+	defer om.mu.Unlock()
+
+	if md, ok := om.m[from]; ok {
+		if _, ok := om.m[to]; !ok {
+			om.m[to] = md
+		}
+		delete(om.m, from)
+	}
+}
+
+func (om *orderMetadata) delete(clOrdID string) {
+	om.mu.Lock()
+	defer om.mu.Unlock()
+
+	delete(om.m, clOrdID)
+}
+
+// keepMetadata records the metadata of ctx, if any, for the order placed by
+// msg with the ClOrdID<11> id, see metadataMsgTypes.
+func (c *Client) keepMetadata(ctx context.Context, id string, msg *quickfix.Message) {
+	md := MetadataFromContext(ctx)
+	if md == nil {
+		return
+	}
+	if msgType, _ := msg.MsgType(); metadataMsgTypes[enum.MsgType(msgType)] {
+		c.metadata.set(id, md)
+	}
+}
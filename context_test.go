@@ -0,0 +1,92 @@
+package fix
+
+import (
+	"context"
+	"testing"
+
+	"github.com/quickfixgo/enum"
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/tag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestContextWithLogger(t *testing.T) {
+	core, logs := observer.New(zap.InfoLevel)
+	l := zap.New(core).Sugar().With("correlation_id", "abc")
+
+	// The client is not started, so the order fails right away.
+	c := &Client{l: zap.NewNop().Sugar()}
+	assert.Same(t, c.l, c.logger(context.Background()))
+
+	ctx := ContextWithLogger(context.Background(), l)
+	_, err := c.NewOrderSingleService().
+		Symbol("BTCUSDT").Side(enum.Side_BUY).Type(enum.OrdType_MARKET).Quantity(1).
+		Do(ctx)
+	require.ErrorIs(t, err, ErrClosed)
+
+	entries := logs.FilterMessage("Failed to create new order").All()
+	require.Len(t, entries, 1)
+	assert.Equal(t, "abc", entries[0].ContextMap()["correlation_id"])
+}
+
+func TestContextWithMetadata(t *testing.T) {
+	assert.Nil(t, MetadataFromContext(context.Background()))
+
+	parent := ContextWithMetadata(context.Background(), Metadata{"desk": "spot", "trace_id": "1"})
+	ctx := ContextWithMetadata(parent, Metadata{"trace_id": "2"})
+
+	assert.Equal(t, Metadata{"desk": "spot", "trace_id": "2"}, MetadataFromContext(ctx))
+	assert.Equal(t, Metadata{"desk": "spot", "trace_id": "1"}, MetadataFromContext(parent))
+}
+
+func TestOrderMetadataLifetime(t *testing.T) {
+	c := NewWithCaller(CallerFunc(func(_ context.Context, id string, msg *quickfix.Message) (*quickfix.Message, error) {
+		if !msg.IsMsgTypeOf(string(enum.MsgType_ORDER_CANCEL_REQUEST)) {
+			return newTestReport(id, enum.OrdStatus_NEW), nil
+		}
+		orig, _ := msg.Body.GetString(tag.OrigClOrdID)
+		report := newTestReport(id, enum.OrdStatus_CANCELED)
+		report.Body.SetString(tag.OrigClOrdID, orig)
+		report.Body.SetString(tag.ExecType, string(enum.ExecType_CANCELED))
+		return report, nil
+	}))
+	ctx := ContextWithMetadata(context.Background(), Metadata{"desk": "spot"})
+
+	order, err := c.NewOrderSingleService().ClOrdID("a").Symbol("BTCUSDT").
+		Side(enum.Side_BUY).Type(enum.OrdType_MARKET).Quantity(1).Do(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, Metadata{"desk": "spot"}, order.Metadata)
+	assert.Equal(t, Metadata{"desk": "spot"}, c.metadata.get("a"))
+
+	canceled, err := c.NewOrderCancelService().Symbol("BTCUSDT").OrigClientOrderID("a").Do(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, OrderStatusCanceled, canceled.Status)
+	assert.Empty(t, c.metadata.m)
+}
+
+func TestOrderMetadataFollowsAmend(t *testing.T) {
+	c := NewWithCaller(nil)
+	c.metadata.set("a", Metadata{"desk": "spot"})
+
+	var got *Order
+	c.SubscribeToExecutionReport(func(o *Order) { got = o })
+	c.deliverOrder(Order{ClientOrderID: "b", OrigClientOrderID: "a", ExecType: ExecTypeReplaced, Status: OrderStatusNew})
+	require.NotNil(t, got)
+	assert.Equal(t, Metadata{"desk": "spot"}, got.Metadata)
+	assert.Equal(t, map[string]Metadata{"b": {"desk": "spot"}}, c.metadata.m)
+}
+
+func TestDuplicateCallKeepsMetadata(t *testing.T) {
+	c := NewWithCaller(nil)
+	c.isConnected.Store(true)
+	c.pending["a"] = &call{done: make(chan error, 1)}
+	c.metadata.set("a", Metadata{"desk": "spot"})
+
+	ctx := ContextWithMetadata(context.Background(), Metadata{"desk": "margin"})
+	_, err := c.Call(ctx, "a", newTestMessage(enum.MsgType_ORDER_SINGLE))
+	assert.ErrorIs(t, err, ErrDuplicateID)
+	assert.Equal(t, Metadata{"desk": "spot"}, c.metadata.get("a"))
+}
@@ -0,0 +1,424 @@
+package fix
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/quickfixgo/enum"
+	"github.com/quickfixgo/field"
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/quickfix/config"
+	"github.com/quickfixgo/tag"
+)
+
+type DiagnosticStage string
+
+const (
+	DiagnosticStageDNS         DiagnosticStage = "DNS"
+	DiagnosticStageTCP         DiagnosticStage = "TCP"
+	DiagnosticStageTLS         DiagnosticStage = "TLS"
+	DiagnosticStageLogon       DiagnosticStage = "LOGON"
+	DiagnosticStageTestRequest DiagnosticStage = "TEST_REQUEST"
+)
+
+type DiagnosticResult struct {
+	Stage    DiagnosticStage
+	Duration time.Duration
+	Err      error
+}
+
+// DiagnosticReport lists the stages run by Diagnose in order. Stages after a
+// failed one are not run.
+type DiagnosticReport struct {
+	Results []DiagnosticResult
+}
+
+// Err returns the error of the failed stage, if any.
+func (r DiagnosticReport) Err() error {
+	for _, res := range r.Results {
+		if res.Err != nil {
+			return res.Err
+		}
+	}
+	return nil
+}
+
+// Diagnose checks connectivity to the FIX gateway stage by stage: DNS
+// resolution, TCP connect, TLS handshake, logon and a TestRequest<1> round
+// trip. A client which was not started logs on with a bare session over the
+// connection of the first stages, logged out right after, which leaves the
+// message store, the sequence numbers and the client state untouched. A
+// started client is checked through its running session instead.
+func (c *Client) Diagnose(ctx context.Context) DiagnosticReport {
+	var (
+		report DiagnosticReport
+		addrs  []string
+		conn   net.Conn
+	)
+	run := func(stage DiagnosticStage, fn func() error) bool {
+		start := time.Now()
+		err := fn()
+		report.Results = append(report.Results, DiagnosticResult{
+			Stage:    stage,
+			Duration: time.Since(start),
+			Err:      err,
+		})
+		if err != nil {
+			c.l.Warnw("Diagnostic stage failed", "stage", stage, "error", err)
+		}
+		return err == nil
+	}
+
+	globalSettings := c.settings.GlobalSettings()
+	host, port, err := c.diagnosticAddress()
+	if err != nil {
+		run(DiagnosticStageDNS, func() error { return err })
+		return report
+	}
+
+	if !run(DiagnosticStageDNS, func() (err error) {
+		addrs, err = net.DefaultResolver.LookupHost(ctx, host)
+		return err
+	}) {
+		return report
+	}
+
+	if !run(DiagnosticStageTCP, func() (err error) {
+		var d net.Dialer
+		conn, err = d.DialContext(ctx, "tcp", net.JoinHostPort(addrs[0], port))
+		return err
+	}) {
+		return report
+	}
+	defer func() { conn.Close() }()
+
+	useSSL, _ := globalSettings.BoolSetting(config.SocketUseSSL)
+	if useSSL {
+		if !run(DiagnosticStageTLS, func() error {
+			tlsConfig, err := c.diagnosticTLSConfig(host)
+			if err != nil {
+				return err
+			}
+			tlsConn := tls.Client(conn, tlsConfig)
+			conn = tlsConn
+			return tlsConn.HandshakeContext(ctx)
+		}) {
+			return report
+		}
+	}
+
+	c.mu.Lock()
+	started := c.initiator != nil
+	c.mu.Unlock()
+	if started {
+		if !run(DiagnosticStageLogon, func() error {
+			if !c.IsConnected() {
+				return ErrClosed
+			}
+			return nil
+		}) {
+			return report
+		}
+		run(DiagnosticStageTestRequest, func() error {
+			_, err := c.Ping(ctx)
+			return err
+		})
+		return report
+	}
+
+	s := newDiagnosticSession(ctx, c, conn)
+	defer s.close()
+	if !run(DiagnosticStageLogon, s.logon) {
+		return report
+	}
+	defer s.logout()
+	run(DiagnosticStageTestRequest, s.testRequest)
+
+	return report
+}
+
+// diagnosticSession is the bare FIX session of Diagnose, which only speaks
+// enough of the protocol to log on, answer a test request and log out.
+type diagnosticSession struct {
+	ctx    context.Context
+	c      *Client
+	conn   net.Conn
+	r      *bufio.Reader
+	seqNum int
+	stop   func() bool // Stops interrupting the connection once ctx is done.
+}
+
+func newDiagnosticSession(ctx context.Context, c *Client, conn net.Conn) *diagnosticSession {
+	return &diagnosticSession{
+		ctx:  ctx,
+		c:    c,
+		conn: conn,
+		r:    bufio.NewReader(conn),
+		stop: context.AfterFunc(ctx, func() {
+			_ = conn.SetDeadline(time.Now())
+		}),
+	}
+}
+
+func (s *diagnosticSession) close() {
+	s.stop()
+}
+
+func (s *diagnosticSession) logon() error {
+	sendingTime := formatUTCTimestamp(s.c.now(), s.c.timestampPrecision)
+	rawData := GetLogonRawData(s.c.privateKey, s.c.senderCompID, s.c.targetCompID, sendingTime)
+
+	msg := quickfix.NewMessage()
+	msg.Header.Set(field.NewMsgType(enum.MsgType_LOGON))
+	msg.Header.SetString(tag.SendingTime, sendingTime)
+	msg.Body.Set(field.NewEncryptMethod(enum.EncryptMethod_NONE_OTHER))
+	msg.Body.Set(field.NewHeartBtInt(int(s.c.heartbeatInterval() / time.Second)))
+	msg.Body.Set(field.NewRawDataLength(len(rawData)))
+	msg.Body.Set(field.NewRawData(rawData))
+	msg.Body.Set(field.NewUsername(s.c.apiKey))
+	msg.Body.Set(field.NewResetSeqNumFlag(true))
+	msg.Body.SetInt(tagMessageHandling, int(s.c.options.messageHandling))
+	msg.Body.SetInt(tagResponseMode, int(s.c.options.responseMode))
+	if err := s.send(msg); err != nil {
+		return err
+	}
+
+	_, err := s.receive(func(msg *quickfix.Message) bool {
+		return msg.IsMsgTypeOf(string(enum.MsgType_LOGON))
+	}, ErrLogonFailed)
+	return err
+}
+
+func (s *diagnosticSession) testRequest() error {
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return err
+	}
+
+	msg := quickfix.NewMessage()
+	msg.Header.Set(field.NewMsgType(enum.MsgType_TEST_REQUEST))
+	msg.Body.Set(field.NewTestReqID(id.String()))
+	if err := s.send(msg); err != nil {
+		return err
+	}
+
+	_, err = s.receive(func(msg *quickfix.Message) bool {
+		testReqID, err := msg.Body.GetString(tag.TestReqID)
+		return msg.IsMsgTypeOf(string(enum.MsgType_HEARTBEAT)) && err == nil && testReqID == id.String()
+	}, ErrClosed)
+	return err
+}
+
+// logout logs the session out, waiting for the Logout<5> of the server up to
+// closeTimeout.
+func (s *diagnosticSession) logout() {
+	_ = s.conn.SetDeadline(time.Now().Add(closeTimeout))
+	msg := quickfix.NewMessage()
+	msg.Header.Set(field.NewMsgType(enum.MsgType_LOGOUT))
+	if err := s.send(msg); err != nil {
+		return
+	}
+	_, _ = s.receive(func(msg *quickfix.Message) bool {
+		return msg.IsMsgTypeOf(string(enum.MsgType_LOGOUT))
+	}, nil)
+}
+
+func (s *diagnosticSession) send(msg *quickfix.Message) error {
+	s.seqNum++
+	msg.Header.SetString(tag.BeginString, s.c.beginString)
+	msg.Header.SetString(tag.SenderCompID, s.c.senderCompID)
+	msg.Header.SetString(tag.TargetCompID, s.c.targetCompID)
+	msg.Header.SetInt(tag.MsgSeqNum, s.seqNum)
+	if !msg.Header.Has(tag.SendingTime) {
+		msg.Header.SetString(tag.SendingTime, formatUTCTimestamp(s.c.now(), s.c.timestampPrecision))
+	}
+
+	_, err := io.WriteString(s.conn, msg.String())
+	return s.err(err)
+}
+
+// receive reads messages until one matching want. A Logout<5> from the server
+// fails with loggedOut and the reason it gives, a Reject<3> with its reason.
+func (s *diagnosticSession) receive(want func(*quickfix.Message) bool, loggedOut error) (*quickfix.Message, error) {
+	for {
+		msg, err := readFIXMessage(s.r)
+		if err != nil {
+			return nil, s.err(err)
+		}
+		if want(msg) {
+			return msg, nil
+		}
+
+		text, _ := msg.Body.GetString(tag.Text)
+		switch {
+		case msg.IsMsgTypeOf(string(enum.MsgType_LOGOUT)):
+			return nil, fmt.Errorf("%w: %s", loggedOut, text)
+		case msg.IsMsgTypeOf(string(enum.MsgType_REJECT)):
+			return nil, fmt.Errorf("message rejected: %s", text)
+		}
+	}
+}
+
+// err returns the error of ctx instead of err if the connection was
+// interrupted because ctx is done.
+func (s *diagnosticSession) err(err error) error {
+	if err != nil && s.ctx.Err() != nil {
+		return s.ctx.Err()
+	}
+	return err
+}
+
+// readFIXMessage reads the next message from r, delimited by its
+// BodyLength<9> and CheckSum<10>.
+func readFIXMessage(r *bufio.Reader) (*quickfix.Message, error) {
+	beginString, err := r.ReadString('\x01')
+	if err != nil {
+		return nil, err
+	}
+	bodyLength, err := r.ReadString('\x01')
+	if err != nil {
+		return nil, err
+	}
+	n, err := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(bodyLength, "9="), "\x01"))
+	if err != nil || !strings.HasPrefix(bodyLength, "9=") {
+		return nil, fmt.Errorf("invalid BodyLength<9> %q", bodyLength)
+	}
+	rest := make([]byte, n+len("10=000\x01"))
+	if _, err := io.ReadFull(r, rest); err != nil {
+		return nil, err
+	}
+
+	msg := quickfix.NewMessage()
+	if err := quickfix.ParseMessage(msg, bytes.NewBufferString(beginString+bodyLength+string(rest))); err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
+
+// diagnosticAddress returns the host and port the client connects to.
+func (c *Client) diagnosticAddress() (string, string, error) {
+	if e, ok := c.CurrentEndpoint(); ok {
+		return e.Host, strconv.Itoa(e.Port), nil
+	}
+
+	globalSettings := c.settings.GlobalSettings()
+	host, err := globalSettings.Setting(config.SocketConnectHost)
+	if err != nil {
+		return "", "", err
+	}
+	port, err := globalSettings.Setting(config.SocketConnectPort)
+	if err != nil {
+		return "", "", err
+	}
+	return host, port, nil
+}
+
+func (c *Client) diagnosticTLSConfig(host string) (*tls.Config, error) {
+	globalSettings := c.settings.GlobalSettings()
+	tlsConfig := &tls.Config{ServerName: host}
+
+	if globalSettings.HasSetting(config.SocketServerName) {
+		serverName, err := globalSettings.Setting(config.SocketServerName)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.ServerName = serverName
+	}
+
+	if globalSettings.HasSetting(config.SocketInsecureSkipVerify) {
+		skipVerify, err := globalSettings.BoolSetting(config.SocketInsecureSkipVerify)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.InsecureSkipVerify = skipVerify
+	}
+
+	if globalSettings.HasSetting(config.SocketCAFile) {
+		caFile, err := globalSettings.Setting(config.SocketCAFile)
+		if err != nil {
+			return nil, err
+		}
+		pem, err := os.ReadFile(caFile)
+		if err != nil {
+			return nil, err
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, errors.New("no certificate found in " + caFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	return tlsConfig, nil
+}
+
+// Ping sends a TestRequest<1> with a unique TestReqID<112> and waits for the
+// Heartbeat<0> echoing it, returning the round trip time. It fails with
+// ErrClosed if the session is not logged on or drops before the answer.
+func (c *Client) Ping(ctx context.Context) (time.Duration, error) {
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return 0, err
+	}
+
+	done := make(chan struct{})
+	c.mu.Lock()
+	c.testRequests[id.String()] = done
+	loggedOut := c.loggedOut
+	c.mu.Unlock()
+	defer func() {
+		c.mu.Lock()
+		delete(c.testRequests, id.String())
+		c.mu.Unlock()
+	}()
+
+	msg := quickfix.NewMessage()
+	msg.Header.Set(field.NewMsgType(enum.MsgType_TEST_REQUEST))
+	msg.Body.Set(field.NewTestReqID(id.String()))
+
+	start := time.Now()
+	if err := c.sendAdmin(msg); err != nil {
+		return 0, err
+	}
+
+	select {
+	case <-done:
+		return time.Since(start), nil
+	case <-loggedOut:
+		return 0, ErrClosed
+	case <-ctx.Done():
+		return 0, ctx.Err()
+	}
+}
+
+// handleHeartbeat resolves the test request answered by a Heartbeat<0>.
+func (c *Client) handleHeartbeat(msg *quickfix.Message) {
+	if !msg.Body.Has(tag.TestReqID) {
+		return
+	}
+	id, err := msg.Body.GetString(tag.TestReqID)
+	if err != nil {
+		return
+	}
+
+	c.mu.Lock()
+	done, ok := c.testRequests[id]
+	delete(c.testRequests, id)
+	c.mu.Unlock()
+
+	if ok {
+		close(done)
+	}
+}
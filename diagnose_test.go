@@ -0,0 +1,100 @@
+package fix
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/quickfixgo/enum"
+	"github.com/quickfixgo/quickfix"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestPingNotConnected(t *testing.T) {
+	c := &Client{
+		l:            zap.NewNop().Sugar(),
+		loggedOut:    make(chan struct{}),
+		testRequests: make(map[string]chan struct{}),
+	}
+
+	_, err := c.Ping(context.Background())
+	assert.ErrorIs(t, err, ErrClosed)
+	assert.Empty(t, c.testRequests)
+}
+
+func TestDiagnoseBareSession(t *testing.T) {
+	g := newTestGateway(t)
+	received := make(chan string, 3)
+	g.handleAdmin(func(msg *quickfix.Message, _ quickfix.SessionID) {
+		msgType, _ := msg.MsgType()
+		received <- msgType
+	})
+	c := g.newClient(t)
+
+	report := c.Diagnose(context.Background())
+	require.NoError(t, report.Err())
+	assert.Len(t, report.Results, 4)
+	assert.Equal(t, DiagnosticStageTestRequest, report.Results[3].Stage)
+	for _, want := range []enum.MsgType{enum.MsgType_LOGON, enum.MsgType_TEST_REQUEST, enum.MsgType_LOGOUT} {
+		assert.Equal(t, string(want), <-received)
+	}
+
+	// The client was never started, nor was its message store created.
+	assert.False(t, c.IsConnected())
+	c.mu.Lock()
+	assert.Nil(t, c.initiator)
+	assert.Nil(t, c.store)
+	c.mu.Unlock()
+}
+
+func TestDiagnoseCanceled(t *testing.T) {
+	// A gateway which accepts the connection but never answers the logon.
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { l.Close() })
+	go func() {
+		conn, err := l.Accept()
+		if err == nil {
+			t.Cleanup(func() { conn.Close() })
+		}
+	}()
+	settings, err := quickfix.ParseSettings(strings.NewReader(fmt.Sprintf(`
+[DEFAULT]
+BeginString=FIX.4.4
+SenderCompID=EXAMPLE
+TargetCompID=SPOT
+SocketConnectHost=127.0.0.1
+SocketConnectPort=%d
+HeartBtInt=30
+
+[SESSION]
+`, l.Addr().(*net.TCPAddr).Port)))
+	require.NoError(t, err)
+	c, err := New(zap.NewNop().Sugar(), Config{
+		APIKey:             "key",
+		PrivateKeyFilePath: "./sample/ed25519.pem",
+		Settings:           settings,
+	})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	report := c.Diagnose(ctx)
+	require.Len(t, report.Results, 3)
+	assert.Equal(t, DiagnosticStageLogon, report.Results[2].Stage)
+	assert.ErrorIs(t, report.Err(), context.DeadlineExceeded)
+}
+
+func TestDiagnoseKeepsStartedClient(t *testing.T) {
+	g := newTestGateway(t)
+	c := g.startClient(t)
+
+	require.NoError(t, c.Diagnose(context.Background()).Err())
+	assert.True(t, c.IsConnected())
+}
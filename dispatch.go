@@ -0,0 +1,145 @@
+package fix
+
+import (
+	"context"
+	"runtime"
+	"sync/atomic"
+	"time"
+
+	"github.com/quickfixgo/quickfix"
+)
+
+const (
+	defaultDispatchQueueSize = 1024
+	drainPollInterval        = time.Millisecond
+)
+
+// DispatcherConfig configures the dedicated goroutine which matches responses
+// and dispatches subscriptions, see WithDispatcherOpt.
+//
+// Spinning trades CPU for tail latency: a spinning goroutine keeps a whole
+// core busy for up to SpinDuration after every message. Keep GOMAXPROCS at
+// least one above the number of spinning goroutines (the dispatcher plus any
+// caller blocked in Call) so that the quickfix reader and the rest of the
+// program are never starved. LockOSThread pins the dispatcher to its own OS
+// thread, which pays off when that thread is also pinned to an isolated core
+// (taskset, isolcpus).
+type DispatcherConfig struct {
+	QueueSize    int           // Inbound messages buffered ahead of the dispatcher, 1024 if zero.
+	SpinDuration time.Duration // How long to busy-poll for work before parking, 0 never spins.
+	LockOSThread bool          // Run the dispatcher on a dedicated OS thread.
+}
+
+// dispatcher processes inbound application messages off the quickfix session
+// goroutine.
+type dispatcher struct {
+	queue   chan *quickfix.Message
+	stop    chan struct{}
+	stopped chan struct{}
+	spin    time.Duration
+	lock    bool
+	process func(msg *quickfix.Message) quickfix.MessageRejectError
+
+	enqueued  atomic.Uint64
+	processed atomic.Uint64
+}
+
+func newDispatcher(
+	conf DispatcherConfig, process func(msg *quickfix.Message) quickfix.MessageRejectError,
+) *dispatcher {
+	size := conf.QueueSize
+	if size <= 0 {
+		size = defaultDispatchQueueSize
+	}
+	return &dispatcher{
+		queue:   make(chan *quickfix.Message, size),
+		stop:    make(chan struct{}),
+		stopped: make(chan struct{}),
+		spin:    conf.SpinDuration,
+		lock:    conf.LockOSThread,
+		process: process,
+	}
+}
+
+func (d *dispatcher) start() {
+	go d.run()
+}
+
+// enqueue hands msg over to the dispatcher. It blocks while the queue is full.
+func (d *dispatcher) enqueue(msg *quickfix.Message) {
+	d.enqueued.Add(1)
+	d.queue <- msg
+}
+
+// caughtUp waits until every message enqueued before the call has been
+// processed.
+func (d *dispatcher) caughtUp(ctx context.Context) error {
+	target := d.enqueued.Load()
+	for d.processed.Load() < target {
+		select {
+		case <-time.After(drainPollInterval):
+		case <-d.stopped:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+// close processes the queued messages and stops the dispatcher. No message
+// must be enqueued afterwards.
+func (d *dispatcher) close() {
+	close(d.stop)
+	<-d.stopped
+}
+
+func (d *dispatcher) run() {
+	defer close(d.stopped)
+	if d.lock {
+		runtime.LockOSThread()
+		defer runtime.UnlockOSThread()
+	}
+
+	for {
+		msg, ok := d.poll()
+		if !ok {
+			return
+		}
+		// The session has moved on, errors can only be logged by process.
+		_ = d.process(msg)
+		d.processed.Add(1)
+	}
+}
+
+// poll returns the next message, busy-polling the queue for up to d.spin
+// before parking. It returns false once the dispatcher is stopped and the
+// queue drained.
+func (d *dispatcher) poll() (*quickfix.Message, bool) {
+	if d.spin > 0 {
+		deadline := time.Now().Add(d.spin)
+		for {
+			select {
+			case msg := <-d.queue:
+				return msg, true
+			default:
+			}
+			if time.Now().After(deadline) {
+				break
+			}
+		}
+	}
+
+	select {
+	case msg := <-d.queue:
+		return msg, true
+	case <-d.stop:
+	}
+
+	select {
+	case msg := <-d.queue:
+		return msg, true
+	default:
+		return nil, false
+	}
+}
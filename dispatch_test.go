@@ -0,0 +1,56 @@
+package fix
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/quickfixgo/quickfix"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDispatcher(t *testing.T) {
+	for _, spin := range []time.Duration{0, time.Millisecond} {
+		var processed []*quickfix.Message
+		d := newDispatcher(
+			DispatcherConfig{QueueSize: 4, SpinDuration: spin},
+			func(msg *quickfix.Message) quickfix.MessageRejectError {
+				processed = append(processed, msg)
+				return nil
+			},
+		)
+		d.start()
+
+		msgs := []*quickfix.Message{quickfix.NewMessage(), quickfix.NewMessage(), quickfix.NewMessage()}
+		for _, msg := range msgs {
+			d.enqueue(msg)
+		}
+		d.close()
+
+		assert.Equal(t, msgs, processed)
+	}
+}
+
+func TestDispatcherCaughtUp(t *testing.T) {
+	release := make(chan struct{})
+	var processed atomic.Int32
+	d := newDispatcher(DispatcherConfig{}, func(msg *quickfix.Message) quickfix.MessageRejectError {
+		<-release
+		processed.Add(1)
+		return nil
+	})
+	d.start()
+	defer d.close()
+
+	d.enqueue(quickfix.NewMessage())
+	d.enqueue(quickfix.NewMessage())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, d.caughtUp(ctx), context.DeadlineExceeded)
+
+	close(release)
+	assert.NoError(t, d.caughtUp(context.Background()))
+	assert.EqualValues(t, 2, processed.Load())
+}
@@ -0,0 +1,160 @@
+package fix
+
+import (
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/quickfix/config"
+)
+
+// Endpoint is the address of a FIX gateway.
+type Endpoint struct {
+	Host string
+	Port int
+}
+
+func (e Endpoint) String() string {
+	return e.Host + ":" + strconv.Itoa(e.Port)
+}
+
+// EndpointHealth is the connection history of an endpoint.
+type EndpointHealth struct {
+	Endpoint
+	// ConsecutiveFailures counts the failed connections, logons and dropped
+	// sessions since the last logon to the endpoint.
+	ConsecutiveFailures int
+	LastError           error
+	LastFailureAt       time.Time
+	LastLogonAt         time.Time
+}
+
+// WithEndpointsOpt connects to endpoints instead of the SocketConnectHost and
+// SocketConnectPort of the settings, starting with the first one. Whenever
+// connecting or logging on to an endpoint fails, or its session drops, the
+// client moves to the next endpoint with the fewest consecutive failures.
+// Start tries each endpoint once. Failing over after a dropped session relies
+// on the client reconnecting, see WithReconnectPolicyOpt.
+func WithEndpointsOpt(endpoints ...Endpoint) NewClientOption {
+	return func(o *Options) {
+		o.endpoints = endpoints
+	}
+}
+
+// endpointSet tracks the health of the configured endpoints and which one is
+// in use.
+type endpointSet struct {
+	mu      sync.Mutex
+	health  []EndpointHealth
+	current int
+}
+
+func newEndpointSet(endpoints []Endpoint) *endpointSet {
+	s := &endpointSet{health: make([]EndpointHealth, len(endpoints))}
+	for i, e := range endpoints {
+		s.health[i].Endpoint = e
+	}
+	return s
+}
+
+func (s *endpointSet) len() int {
+	if s == nil {
+		return 0
+	}
+	return len(s.health)
+}
+
+func (s *endpointSet) endpoint() Endpoint {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.health[s.current].Endpoint
+}
+
+// failed records err against the current endpoint and moves on to the next
+// one with the fewest consecutive failures, which is returned.
+func (s *endpointSet) failed(err error, at time.Time) Endpoint {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	h := &s.health[s.current]
+	h.ConsecutiveFailures++
+	h.LastError = err
+	h.LastFailureAt = at
+
+	next := -1
+	for i := 1; i <= len(s.health); i++ {
+		j := (s.current + i) % len(s.health)
+		if j == s.current && len(s.health) > 1 {
+			continue
+		}
+		if next < 0 || s.health[j].ConsecutiveFailures < s.health[next].ConsecutiveFailures {
+			next = j
+		}
+	}
+	s.current = next
+	return s.health[next].Endpoint
+}
+
+// loggedOn records a logon to the current endpoint.
+func (s *endpointSet) loggedOn(at time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	h := &s.health[s.current]
+	h.ConsecutiveFailures = 0
+	h.LastLogonAt = at
+}
+
+func (s *endpointSet) snapshot() []EndpointHealth {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]EndpointHealth(nil), s.health...)
+}
+
+// Endpoints returns the health of the endpoints set with WithEndpointsOpt, in
+// their configured order, nil without endpoints.
+func (c *Client) Endpoints() []EndpointHealth {
+	if c.endpoints.len() == 0 {
+		return nil
+	}
+	return c.endpoints.snapshot()
+}
+
+// CurrentEndpoint returns the endpoint the client connects to, false without
+// endpoints set with WithEndpointsOpt.
+func (c *Client) CurrentEndpoint() (Endpoint, bool) {
+	if c.endpoints.len() == 0 {
+		return Endpoint{}, false
+	}
+	return c.endpoints.endpoint(), true
+}
+
+// endpointFailed moves the client to the next endpoint, if any, after err.
+func (c *Client) endpointFailed(err error) {
+	if c.endpoints.len() == 0 {
+		return
+	}
+	failed := c.endpoints.endpoint()
+	next := c.endpoints.failed(err, time.Now())
+	c.l.Warnw("Endpoint failed, failing over", "endpoint", failed, "next", next, "error", err)
+}
+
+// initiatorSettings returns the settings the initiator is created with, the
+// client settings connecting to the current endpoint if any.
+func (c *Client) initiatorSettings() (*quickfix.Settings, error) {
+	if c.endpoints.len() == 0 {
+		return c.settings, nil
+	}
+
+	e := c.endpoints.endpoint()
+	settings := quickfix.NewSettings()
+	for _, session := range c.settings.SessionSettings() {
+		session.Set(config.SocketConnectHost, e.Host)
+		session.Set(config.SocketConnectPort, strconv.Itoa(e.Port))
+		if _, err := settings.AddSession(session); err != nil {
+			return nil, err
+		}
+	}
+	return settings, nil
+}
@@ -0,0 +1,74 @@
+package fix
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/quickfix/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEndpointSetFailover(t *testing.T) {
+	a, b, c := Endpoint{"a", 1}, Endpoint{"b", 2}, Endpoint{"c", 3}
+	s := newEndpointSet([]Endpoint{a, b, c})
+	errDown := errors.New("down")
+	now := time.Now()
+
+	assert.Equal(t, a, s.endpoint())
+	assert.Equal(t, b, s.failed(errDown, now))
+	assert.Equal(t, c, s.failed(errDown, now))
+	s.loggedOn(now)
+
+	// a and b failed once, the first of them in order comes next.
+	assert.Equal(t, a, s.failed(errDown, now))
+	assert.Equal(t, b, s.failed(errDown, now))
+	// a failed twice, c only once.
+	assert.Equal(t, c, s.failed(errDown, now))
+
+	health := s.snapshot()
+	require.Len(t, health, 3)
+	assert.Equal(t, 2, health[0].ConsecutiveFailures)
+	assert.Equal(t, 2, health[1].ConsecutiveFailures)
+	assert.Equal(t, 1, health[2].ConsecutiveFailures)
+	assert.Equal(t, errDown, health[2].LastError)
+	assert.Equal(t, now, health[2].LastLogonAt)
+
+	single := newEndpointSet([]Endpoint{a})
+	assert.Equal(t, a, single.failed(errDown, now))
+}
+
+func TestInitiatorSettingsEndpoint(t *testing.T) {
+	settings, err := quickfix.ParseSettings(strings.NewReader(`
+[DEFAULT]
+BeginString=FIX.4.4
+SenderCompID=EXAMPLE
+TargetCompID=SPOT
+SocketConnectPort=9000
+
+[SESSION]
+SocketConnectHost=fix-oe.binance.com
+`))
+	require.NoError(t, err)
+
+	c := &Client{settings: settings, endpoints: newEndpointSet([]Endpoint{{"backup.binance.com", 9001}})}
+	got, err := c.initiatorSettings()
+	require.NoError(t, err)
+	require.Len(t, got.SessionSettings(), 1)
+	for _, session := range got.SessionSettings() {
+		host, err := session.Setting(config.SocketConnectHost)
+		require.NoError(t, err)
+		assert.Equal(t, "backup.binance.com", host)
+		port, err := session.Setting(config.SocketConnectPort)
+		require.NoError(t, err)
+		assert.Equal(t, "9001", port)
+	}
+
+	c.endpoints = nil
+	got, err = c.initiatorSettings()
+	require.NoError(t, err)
+	assert.Same(t, settings, got)
+}
@@ -0,0 +1,55 @@
+package fix
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/tag"
+)
+
+// redactedTags hold credentials which must never end up in errors or logs.
+var redactedTags = map[quickfix.Tag]bool{
+	tag.RawData:  true,
+	tag.Username: true,
+	tag.Password: true,
+}
+
+// MessageError wraps an error caused by a FIX message together with the
+// message itself, credentials redacted, in a readable "tag=value|" form.
+// Use errors.As to retrieve it.
+type MessageError struct {
+	Err     error
+	Message string
+}
+
+func (e *MessageError) Error() string {
+	return e.Err.Error() + " (message: " + e.Message + ")"
+}
+
+func (e *MessageError) Unwrap() error {
+	return e.Err
+}
+
+func newMessageError(err error, msg *quickfix.Message) error {
+	if err == nil {
+		return nil
+	}
+	return &MessageError{Err: err, Message: redactMessage(msg)}
+}
+
+// redactMessage renders msg with "|" as field separator and redacted
+// credentials.
+func redactMessage(msg *quickfix.Message) string {
+	fields := strings.Split(strings.TrimSuffix(msg.String(), "\x01"), "\x01")
+	for i, f := range fields {
+		t, _, ok := strings.Cut(f, "=")
+		if !ok {
+			continue
+		}
+		if n, err := strconv.Atoi(t); err == nil && redactedTags[quickfix.Tag(n)] {
+			fields[i] = t + "=***"
+		}
+	}
+	return strings.Join(fields, "|")
+}
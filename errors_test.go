@@ -0,0 +1,33 @@
+package fix
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/quickfixgo/enum"
+	"github.com/quickfixgo/field"
+	"github.com/quickfixgo/quickfix"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMessageErrorRedactsCredentials(t *testing.T) {
+	msg := quickfix.NewMessage()
+	msg.Header.Set(field.NewMsgType(enum.MsgType_LOGON))
+	msg.Body.Set(field.NewRawData("signature"))
+	msg.Body.Set(field.NewUsername("api-key"))
+	msg.Body.Set(field.NewHeartBtInt(30))
+
+	cause := errors.New("boom")
+	err := newMessageError(cause, msg)
+
+	var msgErr *MessageError
+	require.True(t, errors.As(err, &msgErr))
+	assert.ErrorIs(t, err, cause)
+	assert.Contains(t, msgErr.Message, "35=A|")
+	assert.Contains(t, msgErr.Message, "|96=***|")
+	assert.Contains(t, msgErr.Message, "|553=***|")
+	assert.Contains(t, msgErr.Message, "|108=30|")
+	assert.NotContains(t, msgErr.Message, "signature")
+	assert.NotContains(t, msgErr.Message, "api-key")
+}
@@ -0,0 +1,87 @@
+package fix
+
+import (
+	"errors"
+	"fmt"
+)
+
+// EscalationPolicy decides what happens when the client hits an internal
+// error which should never happen: failing to decode a message sent by the
+// server, or a panic while handling a logout. Responses matching no pending
+// call are not escalated, they are expected for calls given up on and for
+// orders placed by other sessions of the account.
+type EscalationPolicy int
+
+const (
+	// EscalationReturnError logs the error and fails the affected call only.
+	EscalationReturnError EscalationPolicy = 1
+	// EscalationEmitEvent also delivers the error to the handlers registered
+	// with SubscribeToInternalErrors.
+	EscalationEmitEvent EscalationPolicy = 2
+	// EscalationPanic panics, for applications which rather crash than run
+	// with a client in an unknown state.
+	EscalationPanic EscalationPolicy = 3
+)
+
+// InternalError is an error escalated by the client.
+type InternalError struct {
+	Op  string // What the client was doing.
+	Err error
+}
+
+func (e *InternalError) Error() string {
+	return fmt.Sprintf("internal error: %s: %v", e.Op, e.Err)
+}
+
+func (e *InternalError) Unwrap() error {
+	return e.Err
+}
+
+// InternalErrorHandler is called with errors escalated under
+// EscalationEmitEvent.
+type InternalErrorHandler func(err *InternalError)
+
+// WithEscalationPolicyOpt sets how internal errors are escalated, the default
+// is EscalationReturnError.
+func WithEscalationPolicyOpt(p EscalationPolicy) NewClientOption {
+	return func(o *Options) {
+		o.escalationPolicy = p
+	}
+}
+
+// escalate logs err and applies the escalation policy. It returns the error
+// to hand to the affected call, if any.
+func (c *Client) escalate(op string, err error) error {
+	ierr := &InternalError{Op: op, Err: err}
+	c.l.Errorw("Internal error", "op", op, "error", err)
+
+	switch c.options.escalationPolicy {
+	case EscalationEmitEvent:
+		c.emitter.Emit(InternalErrorTopic, ierr)
+	case EscalationPanic:
+		panic(ierr)
+	}
+	return ierr
+}
+
+// escalateDecode escalates err, the failure to decode a message sent by the
+// server, unless err is the rejection the message carries.
+func (c *Client) escalateDecode(op string, err error) error {
+	var (
+		orderRej  *OrderRejectedError
+		cancelRej *CancelRejectedError
+		amendRej  *OrderAmendRejectedError
+		massRej   *MassCancelRejectedError
+		listRej   *OrderListRejectedError
+	)
+	switch {
+	case err == nil,
+		errors.As(err, &orderRej),
+		errors.As(err, &cancelRej),
+		errors.As(err, &amendRej),
+		errors.As(err, &massRej),
+		errors.As(err, &listRej):
+		return err
+	}
+	return c.escalate(op, err)
+}
@@ -0,0 +1,68 @@
+package fix
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/quickfixgo/enum"
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/tag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newUndecodableReport returns an execution report for clOrdID which fails to
+// decode.
+func newUndecodableReport(clOrdID string, status enum.OrdStatus) *quickfix.Message {
+	msg := newTestReport(clOrdID, status)
+	msg.Body.SetString(tag.CumQty, "not a number")
+	return msg
+}
+
+func TestEscalateInboundDecodeFailure(t *testing.T) {
+	errs := make(chan *InternalError, 2)
+	c := NewWithCaller(nil, WithEscalationPolicyOpt(EscalationEmitEvent))
+	c.SubscribeToInternalErrors(func(err *InternalError) { errs <- err })
+
+	c.handleSubscriptions(string(enum.MsgType_EXECUTION_REPORT), newUndecodableReport("a", enum.OrdStatus_NEW))
+	select {
+	case err := <-errs:
+		assert.Equal(t, "decode execution report", err.Op)
+		var msgErr *MessageError
+		assert.ErrorAs(t, err, &msgErr)
+	case <-time.After(time.Second):
+		t.Fatal("decode failure was not escalated")
+	}
+
+	// A rejected order is not an internal error.
+	rejected := newTestReport("b", enum.OrdStatus_REJECTED)
+	rejected.Body.SetString(tag.ExecType, string(enum.ExecType_REJECTED))
+	c.handleSubscriptions(string(enum.MsgType_EXECUTION_REPORT), rejected)
+	select {
+	case err := <-errs:
+		t.Fatalf("rejection was escalated: %v", err)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestEscalateResponseDecodeFailure(t *testing.T) {
+	c := NewWithCaller(CallerFunc(func(_ context.Context, id string, _ *quickfix.Message) (*quickfix.Message, error) {
+		return newUndecodableReport(id, enum.OrdStatus_NEW), nil
+	}))
+
+	_, err := c.NewOrderSingleService().
+		Symbol("BTCUSDT").Side(enum.Side_BUY).Type(enum.OrdType_MARKET).Quantity(1).
+		Do(context.Background())
+	var ierr *InternalError
+	require.ErrorAs(t, err, &ierr)
+	assert.Equal(t, "decode execution report", ierr.Op)
+}
+
+func TestEscalatePanic(t *testing.T) {
+	c := NewWithCaller(nil, WithEscalationPolicyOpt(EscalationPanic))
+
+	assert.Panics(t, func() {
+		c.handleSubscriptions(string(enum.MsgType_EXECUTION_REPORT), newUndecodableReport("a", enum.OrdStatus_NEW))
+	})
+}
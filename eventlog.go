@@ -0,0 +1,146 @@
+package fix
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"sync"
+	"time"
+)
+
+// orderEventVersion is the schema version of OrderEvent written to logs.
+const orderEventVersion = 1
+
+// OrderEvent is a decoded order update as recorded in the event log.
+type OrderEvent struct {
+	Seq     uint64    // Local sequence, strictly increasing across restarts.
+	Version int       // Schema version of the event.
+	Time    time.Time // When the event was recorded locally.
+	Order   Order
+}
+
+// EventLog is an append-only log of order events. Implementations must
+// replay events in the order they were appended.
+type EventLog interface {
+	Append(e OrderEvent) error
+	// Replay calls fn for every event with Seq >= fromSeq.
+	Replay(fromSeq uint64, fn func(OrderEvent) error) error
+}
+
+// MemoryEventLog keeps events in memory. It is mostly useful for tests.
+type MemoryEventLog struct {
+	mu     sync.Mutex
+	events []OrderEvent
+}
+
+func NewMemoryEventLog() *MemoryEventLog {
+	return &MemoryEventLog{}
+}
+
+func (l *MemoryEventLog) Append(e OrderEvent) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.events = append(l.events, e)
+	return nil
+}
+
+func (l *MemoryEventLog) Replay(fromSeq uint64, fn func(OrderEvent) error) error {
+	l.mu.Lock()
+	events := make([]OrderEvent, len(l.events))
+	copy(events, l.events)
+	l.mu.Unlock()
+
+	for _, e := range events {
+		if e.Seq < fromSeq {
+			continue
+		}
+		if err := fn(e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// FileEventLog stores events as JSON lines in a single file.
+type FileEventLog struct {
+	mu   sync.Mutex
+	path string
+	file *os.File
+	sync bool
+}
+
+// NewFileEventLog opens, or creates, the event log at path. With sync every
+// append is flushed to disk before returning.
+func NewFileEventLog(path string, sync bool) (*FileEventLog, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	return &FileEventLog{path: path, file: file, sync: sync}, nil
+}
+
+func (l *FileEventLog) Append(e OrderEvent) error {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if _, err := l.file.Write(append(data, '\n')); err != nil {
+		return err
+	}
+	if l.sync {
+		return l.file.Sync()
+	}
+	return nil
+}
+
+func (l *FileEventLog) Replay(fromSeq uint64, fn func(OrderEvent) error) error {
+	file, err := os.Open(l.path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var e OrderEvent
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			return err
+		}
+		if e.Seq < fromSeq {
+			continue
+		}
+		if err := fn(e); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+// Close closes the underlying file.
+func (l *FileEventLog) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.file.Close()
+}
+
+// ReplayEventLog delivers the events of the configured event log starting at
+// fromSeq to execution report subscribers, with Order.Replayed set.
+func (c *Client) ReplayEventLog(fromSeq uint64) error {
+	if c.options.eventLog == nil {
+		return ErrNoEventLog
+	}
+
+	return c.options.eventLog.Replay(fromSeq, func(e OrderEvent) error {
+		order := e.Order
+		order.Replayed = true
+		c.emitter.EmitSync(ExecutionReportTopic, &order)
+		return nil
+	})
+}
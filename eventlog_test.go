@@ -0,0 +1,37 @@
+package fix
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventLogReplayOnStartup(t *testing.T) {
+	log := NewMemoryEventLog()
+	for i, id := range []string{"a", "b", "c"} {
+		require.NoError(t, log.Append(OrderEvent{
+			Seq:   uint64(i + 1),
+			Order: Order{ClientOrderID: id, Status: OrderStatusNew},
+		}))
+	}
+
+	var replayed []Order
+	g := newTestGateway(t)
+	c := g.startClient(t,
+		WithEventLogOpt(log),
+		WithEventLogReplayOpt(2),
+		WithExecutionReportHandlerOpt(func(o *Order) { replayed = append(replayed, *o) }),
+	)
+
+	// The events from the requested sequence reached the subscriber before
+	// the client connected.
+	require.Len(t, replayed, 2)
+	for i, id := range []string{"b", "c"} {
+		assert.Equal(t, id, replayed[i].ClientOrderID)
+		assert.True(t, replayed[i].Replayed)
+	}
+	assert.True(t, c.IsConnected())
+
+	assert.ErrorIs(t, (&Client{}).ReplayEventLog(1), ErrNoEventLog)
+}
@@ -0,0 +1,50 @@
+package fix
+
+import (
+	"github.com/KyberNetwork/binance_fix_api/binancetag"
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/tag"
+)
+
+// executionReportTags are the body tags of an ExecutionReport<8> decoded
+// into an Order.
+var executionReportTags = tagSet(
+	tag.OrdStatus, tag.Symbol, tag.OrderID, tag.ClOrdID, tag.OrigClOrdID, tag.ListID, tag.Price, tag.OrderQty,
+	tag.CashOrderQty, tag.CumQty, binancetag.CumQuoteQty, tag.ExecType, tag.ExecID,
+	tag.LastPx, tag.LastQty, tag.TradeID, tag.AggressorIndicator, tag.TimeInForce,
+	tag.OrdType, tag.Side, tag.MaxFloor, tag.TriggerPrice, binancetag.TriggerTrailingDeltaBps,
+	binancetag.TrailingTime, binancetag.WorkingFloor, binancetag.SOR, tag.ExecInst,
+	tag.TransactTime, binancetag.OrderCreationTime, binancetag.WorkingTime,
+	binancetag.SelfTradePreventionMode, binancetag.PreventedQty, binancetag.LastPreventedQty,
+	binancetag.PreventedMatchID, binancetag.PreventedExecutionPrice, binancetag.PreventedExecutionQty,
+	binancetag.TradeGroupID, binancetag.CounterSymbol, binancetag.CounterOrderID,
+	tag.OrdRejReason, binancetag.ErrorCode, tag.Text,
+)
+
+func tagSet(tags ...quickfix.Tag) map[quickfix.Tag]bool {
+	set := make(map[quickfix.Tag]bool, len(tags))
+	for _, t := range tags {
+		set[t] = true
+	}
+	return set
+}
+
+// extraFields returns the body fields of msg whose tag is not in decoded, nil
+// if there is none. Fields of repeating groups are only kept once.
+func extraFields(msg *quickfix.Message, decoded map[quickfix.Tag]bool) map[quickfix.Tag]string {
+	var extra map[quickfix.Tag]string
+	for _, t := range msg.Body.Tags() {
+		if decoded[t] {
+			continue
+		}
+		v, err := msg.Body.GetString(t)
+		if err != nil {
+			continue
+		}
+		if extra == nil {
+			extra = make(map[quickfix.Tag]string)
+		}
+		extra[t] = v
+	}
+	return extra
+}
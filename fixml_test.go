@@ -0,0 +1,34 @@
+package fix
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportFIXML(t *testing.T) {
+	day := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	log := NewMemoryEventLog()
+	for i, o := range []Order{
+		{Symbol: "BTCUSDT", OrderID: 1, ClientOrderID: "a", Status: OrderStatusNew, Side: SideTypeBuy, Type: OrderTypeLimit, TimeInForce: TimeInForceGTC, Price: 42000.5, OrderQty: 2, TransactTime: day.Add(-time.Hour)},
+		{Symbol: "BTCUSDT", OrderID: 1, ClientOrderID: "a", Status: OrderStatusPartiallyFilled, Side: SideTypeBuy, Type: OrderTypeLimit, TimeInForce: TimeInForceGTC, Price: 42000.5, OrderQty: 2, CumQty: 0.5, TransactTime: day.Add(time.Hour)},
+	} {
+		require.NoError(t, log.Append(OrderEvent{Seq: uint64(i + 1), Order: o}))
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, ExportFIXML(&buf, log, FIXMLExportFilter{From: day, To: day.AddDate(0, 0, 1), Account: "main"}))
+
+	assert.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>
+<FIXML v="5.0 SP2">
+  <Batch>
+    <ExecRpt OrdID="1" ID="a" ExecTyp="F" Stat="1" Acct="main" Side="1" Typ="2" Px="42000.5" TmInForce="1" CumQty="0.5" LeavesQty="1.5" TxnTm="2024-01-02T01:00:00.000Z">
+      <Instrmt Sym="BTCUSDT"></Instrmt>
+      <OrdQty Qty="2"></OrdQty>
+    </ExecRpt>
+  </Batch>
+</FIXML>`, buf.String())
+}
@@ -0,0 +1,58 @@
+package fix
+
+import (
+	"context"
+	"sync"
+)
+
+// Future is the pending result of a request sent with DoAsync.
+type Future[T any] struct {
+	done   chan struct{}
+	result T
+	err    error
+}
+
+// Done is closed once the result is available.
+func (f *Future[T]) Done() <-chan struct{} {
+	return f.done
+}
+
+// Result blocks until the request completes and returns what Do would have.
+func (f *Future[T]) Result() (T, error) {
+	<-f.done
+	return f.result, f.err
+}
+
+type sentHookCtxKey struct{}
+
+// doAsync runs do in the background and returns once its request has been
+// handed to the session, or do failed before sending, so that requests made
+// in a row are sent in that order.
+func doAsync[T any](ctx context.Context, do func(ctx context.Context) (T, error)) *Future[T] {
+	f := &Future[T]{done: make(chan struct{})}
+
+	sent := make(chan struct{})
+	var once sync.Once
+	ctx = context.WithValue(ctx, sentHookCtxKey{}, func() {
+		once.Do(func() { close(sent) })
+	})
+
+	go func() {
+		defer close(f.done)
+		f.result, f.err = do(ctx)
+	}()
+
+	select {
+	case <-sent:
+	case <-f.done:
+	}
+	return f
+}
+
+// notifySent tells doAsync, if the request of ctx was made with it, that the
+// request has been sent.
+func notifySent(ctx context.Context) {
+	if hook, ok := ctx.Value(sentHookCtxKey{}).(func()); ok {
+		hook()
+	}
+}
@@ -0,0 +1,35 @@
+package fix
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDoAsync(t *testing.T) {
+	respond := make(chan struct{})
+	f := doAsync(context.Background(), func(ctx context.Context) (int, error) {
+		notifySent(ctx)
+		<-respond
+		return 42, nil
+	})
+
+	// doAsync returned once the request was sent, before the response.
+	select {
+	case <-f.Done():
+		t.Fatal("future done before the response")
+	default:
+	}
+	close(respond)
+	res, err := f.Result()
+	assert.NoError(t, err)
+	assert.Equal(t, 42, res)
+
+	failed := doAsync(context.Background(), func(ctx context.Context) (int, error) {
+		return 0, ErrInvalidOrder
+	})
+	_, err = failed.Result()
+	assert.True(t, errors.Is(err, ErrInvalidOrder))
+}
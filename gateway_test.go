@@ -0,0 +1,178 @@
+package fix
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/quickfixgo/enum"
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/tag"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// testGateway is a FIX acceptor on localhost standing in for the Binance
+// gateway, logging on every client and handing it the application messages.
+// Clients connect as EXAMPLE, or as STANDBY for a second session.
+type testGateway struct {
+	port     int
+	acceptor *quickfix.Acceptor
+
+	mu      sync.Mutex
+	onApp   func(msg *quickfix.Message, sessionID quickfix.SessionID)
+	onAdmin func(msg *quickfix.Message, sessionID quickfix.SessionID)
+}
+
+func newTestGateway(t *testing.T) *testGateway {
+	t.Helper()
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	port := l.Addr().(*net.TCPAddr).Port
+	require.NoError(t, l.Close())
+
+	settings, err := quickfix.ParseSettings(strings.NewReader(fmt.Sprintf(`
+[DEFAULT]
+BeginString=FIX.4.4
+SenderCompID=SPOT
+SocketAcceptHost=127.0.0.1
+SocketAcceptPort=%d
+
+[SESSION]
+TargetCompID=EXAMPLE
+
+[SESSION]
+TargetCompID=STANDBY
+`, port)))
+	require.NoError(t, err)
+
+	g := &testGateway{port: port}
+	g.acceptor, err = quickfix.NewAcceptor(g, quickfix.NewMemoryStoreFactory(), settings, quickfix.NewNullLogFactory())
+	require.NoError(t, err)
+	require.NoError(t, g.acceptor.Start())
+	t.Cleanup(g.acceptor.Stop)
+	return g
+}
+
+// settings returns the settings of a client connecting to the gateway.
+func (g *testGateway) settings(t *testing.T) *quickfix.Settings {
+	return g.settingsAs(t, "EXAMPLE")
+}
+
+// settingsAs returns the settings of a client connecting to the gateway as
+// senderCompID.
+func (g *testGateway) settingsAs(t *testing.T, senderCompID string) *quickfix.Settings {
+	t.Helper()
+
+	settings, err := quickfix.ParseSettings(strings.NewReader(fmt.Sprintf(`
+[DEFAULT]
+BeginString=FIX.4.4
+SenderCompID=%s
+TargetCompID=SPOT
+SocketConnectHost=127.0.0.1
+SocketConnectPort=%d
+HeartBtInt=30
+ReconnectInterval=1
+ResetOnLogon=Y
+
+[SESSION]
+`, senderCompID, g.port)))
+	require.NoError(t, err)
+	return settings
+}
+
+// newClient creates a client of the gateway, stopped once the test is done.
+func (g *testGateway) newClient(t *testing.T, opts ...NewClientOption) *Client {
+	t.Helper()
+	return g.newClientAs(t, "EXAMPLE", opts...)
+}
+
+// newClientAs creates a client of the gateway connecting as senderCompID.
+func (g *testGateway) newClientAs(t *testing.T, senderCompID string, opts ...NewClientOption) *Client {
+	t.Helper()
+
+	c, err := New(zap.NewNop().Sugar(), Config{
+		APIKey:             "key",
+		PrivateKeyFilePath: "./sample/ed25519.pem",
+		Settings:           g.settingsAs(t, senderCompID),
+	}, opts...)
+	require.NoError(t, err)
+	t.Cleanup(c.Stop)
+	return c
+}
+
+// startClient creates a client of the gateway and logs it on.
+func (g *testGateway) startClient(t *testing.T, opts ...NewClientOption) *Client {
+	t.Helper()
+
+	c := g.newClient(t, opts...)
+	require.NoError(t, c.Start(context.Background()))
+	return c
+}
+
+// handle calls fn with every application message received.
+func (g *testGateway) handle(fn func(msg *quickfix.Message, sessionID quickfix.SessionID)) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.onApp = fn
+}
+
+// handleAdmin calls fn with every admin message received.
+func (g *testGateway) handleAdmin(fn func(msg *quickfix.Message, sessionID quickfix.SessionID)) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.onAdmin = fn
+}
+
+// reply sends msg to the client of sessionID, as seen by the gateway.
+func (g *testGateway) reply(t *testing.T, sessionID quickfix.SessionID, msg *quickfix.Message) {
+	t.Helper()
+	require.NoError(t, quickfix.SendToTarget(msg, sessionID))
+}
+
+// ackOrders answers every NewOrderSingle<D> with an ExecutionReport<8> of
+// status, and sends every message received to received if not nil.
+func (g *testGateway) ackOrders(t *testing.T, status enum.OrdStatus, received chan<- *quickfix.Message) {
+	g.handle(func(msg *quickfix.Message, sessionID quickfix.SessionID) {
+		if received != nil {
+			received <- msg
+		}
+		if !msg.IsMsgTypeOf(string(enum.MsgType_ORDER_SINGLE)) {
+			return
+		}
+		clOrdID, _ := msg.Body.GetString(tag.ClOrdID)
+		report := newTestReport(clOrdID, status)
+		report.Body.SetString(tag.ExecType, string(enum.ExecType_NEW))
+		g.reply(t, sessionID, report)
+	})
+}
+
+func (g *testGateway) OnCreate(quickfix.SessionID)                       {}
+func (g *testGateway) OnLogon(quickfix.SessionID)                        {}
+func (g *testGateway) OnLogout(quickfix.SessionID)                       {}
+func (g *testGateway) ToAdmin(*quickfix.Message, quickfix.SessionID)     {}
+func (g *testGateway) ToApp(*quickfix.Message, quickfix.SessionID) error { return nil }
+
+func (g *testGateway) FromAdmin(msg *quickfix.Message, sessionID quickfix.SessionID) quickfix.MessageRejectError {
+	g.mu.Lock()
+	onAdmin := g.onAdmin
+	g.mu.Unlock()
+	if onAdmin != nil {
+		onAdmin(msg, sessionID)
+	}
+	return nil
+}
+
+func (g *testGateway) FromApp(msg *quickfix.Message, sessionID quickfix.SessionID) quickfix.MessageRejectError {
+	g.mu.Lock()
+	onApp := g.onApp
+	g.mu.Unlock()
+	if onApp != nil {
+		onApp(msg, sessionID)
+	}
+	return nil
+}
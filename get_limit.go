@@ -2,6 +2,7 @@ package fix
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/quickfixgo/field"
@@ -49,11 +50,18 @@ type LimitResponse struct {
 }
 
 type LimitService struct {
-	c *Client
+	c      *Client
+	fields customFields
 }
 
 func (c *Client) NewGetLimitService() *LimitService {
-	return &LimitService{c}
+	return &LimitService{c: c}
+}
+
+// SetField sets any body tag, e.g. one newly added by Binance.
+func (s *LimitService) SetField(t quickfix.Tag, value string) *LimitService {
+	s.fields.set(t, value)
+	return s
 }
 
 func (s *LimitService) Do(ctx context.Context) (LimitResponse, error) {
@@ -66,13 +74,29 @@ func (s *LimitService) Do(ctx context.Context) (LimitResponse, error) {
 	msg.Header.Set(field.NewMsgType(msgType_LIMIT_REQUEST))
 
 	msg.Body.SetString(tagGetLimitReqID, id.String())
+	s.fields.apply(msg)
 
 	resp, err := s.c.Call(ctx, id.String(), msg)
 	if err != nil {
+		s.c.logger(ctx).Errorw("Failed to get limits", "request", msg, "error", err)
 		return LimitResponse{}, err
 	}
 
-	// parse response
+	limit, err := decodeLimitResponse(resp)
+	if err != nil {
+		return LimitResponse{}, s.c.escalateDecode("decode limit response", newMessageError(err, resp))
+	}
+	s.c.budget.update(limit, time.Now())
+
+	return limit, nil
+}
+
+// DoAsync sends the request like Do, without waiting for the response.
+func (s *LimitService) DoAsync(ctx context.Context) *Future[LimitResponse] {
+	return doAsync(ctx, s.Do)
+}
+
+func decodeLimitResponse(resp *quickfix.Message) (LimitResponse, error) {
 	reqID, err := resp.Body.GetString(tagGetLimitReqID)
 	if err != nil {
 		return LimitResponse{}, err
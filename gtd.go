@@ -0,0 +1,61 @@
+package fix
+
+import (
+	"sync"
+	"time"
+)
+
+// chaseCancelTimeout bounds the cancel sent when an order expires or exceeds
+// its ack latency budget.
+const chaseCancelTimeout = 10 * time.Second
+
+// orderExpiries holds the timers canceling orders placed with a time to live.
+type orderExpiries struct {
+	mu     sync.Mutex
+	timers map[string]*time.Timer // Keyed by ClOrdID.
+}
+
+func (e *orderExpiries) add(clOrdID string, ttl time.Duration, expire func()) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.timers == nil {
+		e.timers = make(map[string]*time.Timer)
+	}
+	if timer, ok := e.timers[clOrdID]; ok {
+		timer.Stop()
+	}
+	var timer *time.Timer
+	timer = time.AfterFunc(ttl, func() {
+		e.mu.Lock()
+		// The order may have been given a new timer while this one fired.
+		if e.timers[clOrdID] == timer {
+			delete(e.timers, clOrdID)
+		}
+		e.mu.Unlock()
+		expire()
+	})
+	e.timers[clOrdID] = timer
+}
+
+func (e *orderExpiries) stop(clOrdID string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if timer, ok := e.timers[clOrdID]; ok {
+		timer.Stop()
+		delete(e.timers, clOrdID)
+	}
+}
+
+// expireAfter cancels the order once ttl elapsed, unless it reached a terminal
+// state by then. Expiries are kept in memory only and do not survive a
+// restart of the process.
+func (c *Client) expireAfter(symbol, clOrdID string, ttl time.Duration) {
+	c.expiries.add(clOrdID, ttl, func() {
+		if o, ok := c.tracker.Order(clOrdID); ok && o.Status.IsTerminal() {
+			return
+		}
+		c.chaseCancel(symbol, clOrdID, "time to live elapsed")
+	})
+}
@@ -0,0 +1,82 @@
+package fix
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/quickfixgo/enum"
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/tag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimeToLive(t *testing.T) {
+	g := newTestGateway(t)
+	canceled := make(chan string, 2)
+	// The first order rests, the second one fills right away.
+	placed := 0
+	g.handle(func(msg *quickfix.Message, sessionID quickfix.SessionID) {
+		clOrdID, _ := msg.Body.GetString(tag.ClOrdID)
+		switch {
+		case msg.IsMsgTypeOf(string(enum.MsgType_ORDER_SINGLE)):
+			status := enum.OrdStatus_NEW
+			if placed++; placed > 1 {
+				status = enum.OrdStatus_FILLED
+			}
+			g.reply(t, sessionID, newTestReport(clOrdID, status))
+		case msg.IsMsgTypeOf(string(enum.MsgType_ORDER_CANCEL_REQUEST)):
+			orig, _ := msg.Body.GetString(tag.OrigClOrdID)
+			canceled <- orig
+			report := newTestReport(clOrdID, enum.OrdStatus_CANCELED)
+			report.Body.SetString(tag.OrigClOrdID, orig)
+			report.Body.SetString(tag.ExecType, string(enum.ExecType_CANCELED))
+			g.reply(t, sessionID, report)
+		}
+	})
+	c := g.startClient(t)
+	place := func() Order {
+		order, err := c.NewOrderSingleService().
+			Symbol("BTCUSDT").Side(enum.Side_BUY).Type(enum.OrdType_LIMIT).Price(100).Quantity(1).
+			TimeInForce(enum.TimeInForce_GOOD_TILL_CANCEL).TimeToLive(50 * time.Millisecond).
+			Do(context.Background())
+		require.NoError(t, err)
+		return order
+	}
+
+	open := place()
+	select {
+	case orig := <-canceled:
+		assert.Equal(t, open.ClientOrderID, orig)
+	case <-time.After(time.Second):
+		t.Fatal("expired order was not canceled")
+	}
+
+	// An order done before its time to live is left alone.
+	place()
+	select {
+	case orig := <-canceled:
+		t.Fatalf("filled order %s was canceled", orig)
+	case <-time.After(200 * time.Millisecond):
+	}
+}
+
+func TestOrderExpiriesReplacedWhileFiring(t *testing.T) {
+	var e orderExpiries
+	expired := make(chan struct{})
+	e.add("a", time.Millisecond, func() { close(expired) })
+
+	// The timer fires while the order is given a new one.
+	e.mu.Lock()
+	time.Sleep(10 * time.Millisecond)
+	replacement := time.AfterFunc(time.Hour, func() {})
+	defer replacement.Stop()
+	e.timers["a"] = replacement
+	e.mu.Unlock()
+	<-expired
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	assert.Same(t, replacement, e.timers["a"])
+}
@@ -1,9 +1,13 @@
 package fix
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/quickfixgo/enum"
 	"github.com/quickfixgo/field"
 	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/tag"
 	"go.uber.org/zap"
 )
 
@@ -14,24 +18,56 @@ func (c *Client) OnCreate(quickfix.SessionID) {}
 
 // OnLogon notification of a session successfully logging on.
 func (c *Client) OnLogon(quickfix.SessionID) {
+	c.mu.Lock()
+	c.loggedOut = make(chan struct{})
+	c.mu.Unlock()
+
 	c.isConnected.Store(true)
+	c.startWatchdog()
+	c.logonSucceeded()
+	if c.endpoints.len() > 0 {
+		c.endpoints.loggedOn(time.Now())
+	}
+	c.setState(ConnStateLoggedOn)
 	c.l.Info("Logon successfully!")
+	c.resumeAfterMaintenance()
+	c.recordLogonForAlert()
 }
 
 // OnLogout notification of a session logging off or disconnecting.
 func (c *Client) OnLogout(quickfix.SessionID) {
 	defer func() {
 		if err := recover(); err != nil {
-			c.l.Errorw("Recover from panic", "error", err)
+			_ = c.escalate("handle logout", fmt.Errorf("panic: %v", err))
 		}
 	}()
 
 	c.isConnected.Store(false)
+	c.stopWatchdog()
+	c.endReplay()
+	c.logonEnded()
+	c.setState(ConnStateDisconnected)
 	c.l.Info("Logged out!")
-	for _, call := range c.pending {
+
+	c.mu.Lock()
+	select {
+	case <-c.loggedOut:
+	default:
+		close(c.loggedOut)
+	}
+
+	pending := c.pending
+	c.pending = make(map[string]*call)
+	c.mu.Unlock()
+
+	for _, call := range pending {
 		call.done <- ErrClosed
 		close(call.done)
 	}
+
+	if c.shouldReconnect() {
+		go c.reconnect(ErrClosed)
+	}
 }
 
 // ToAdmin notification of admin message being sent to target.
@@ -43,12 +79,25 @@ func (c *Client) ToAdmin(msg *quickfix.Message, _ quickfix.SessionID) {
 	}
 
 	c.l.Infow("ToAdmin message type", "data", msgType)
+	c.budget.recordSent(enum.MsgType(msgType), time.Now())
+	c.stampSendingTime(msg)
+	c.detectSessionAnomaly(enum.MsgType(msgType), msg)
+	if enum.MsgType(msgType) == enum.MsgType_SEQUENCE_RESET {
+		c.resetSenderSeqNum(msg)
+	}
 	if enum.MsgType(msgType) == enum.MsgType_LOGON {
-		rawData := GetLogonRawData(c.privateKey, c.senderCompID, c.targetCompID, SendingTimeNow())
+		c.logonAttempt()
+		// Sign the SendingTime quickfix put in the header so both always match.
+		sendingTime, err := msg.Header.GetString(tag.SendingTime)
+		if err != nil {
+			sendingTime = formatUTCTimestamp(c.now(), c.timestampPrecision)
+			msg.Header.SetString(tag.SendingTime, sendingTime)
+		}
+		rawData := GetLogonRawData(c.privateKey, c.senderCompID, c.targetCompID, sendingTime)
 		msg.Body.Set(field.NewRawDataLength(len(rawData)))
 		msg.Body.Set(field.NewRawData(rawData))
 		msg.Body.Set(field.NewUsername(c.apiKey))
-		msg.Body.Set(field.NewResetSeqNumFlag(true))
+		msg.Body.Set(field.NewResetSeqNumFlag(!c.options.keepSeqNums))
 		msg.Body.SetInt(tagMessageHandling, int(c.options.messageHandling))
 		msg.Body.SetInt(tagResponseMode, int(c.options.responseMode))
 	}
@@ -57,17 +106,46 @@ func (c *Client) ToAdmin(msg *quickfix.Message, _ quickfix.SessionID) {
 // ToApp notification of app message being sent to target.
 func (c *Client) ToApp(msg *quickfix.Message, _ quickfix.SessionID) error {
 	c.l.Infow("Sending message to server", "msg", msg)
+	if msgType, err := msg.MsgType(); err == nil {
+		c.budget.recordSent(enum.MsgType(msgType), time.Now())
+	}
+	c.stampSendingTime(msg)
 	return nil
 }
 
 // FromAdmin notification of admin message being received from target.
 func (c *Client) FromAdmin(msg *quickfix.Message, _ quickfix.SessionID) quickfix.MessageRejectError {
 	c.l.Infow("FromAdmin message", "msg", msg)
+	c.recordActivity()
+	c.recordProcessedSeqNum(msg)
+	c.recordClockSkew(msg)
+	switch {
+	case msg.IsMsgTypeOf(msgTypeSessionReject):
+		c.handleSessionReject(msg)
+	case msg.IsMsgTypeOf(string(enum.MsgType_HEARTBEAT)):
+		c.handleHeartbeat(msg)
+	case msg.IsMsgTypeOf(string(enum.MsgType_LOGOUT)):
+		c.recordLogout(msg)
+	}
 	return nil
 }
 
 // FromApp notification of app message being received from target.
-func (c *Client) FromApp(msg *quickfix.Message, s quickfix.SessionID) quickfix.MessageRejectError {
+func (c *Client) FromApp(msg *quickfix.Message, _ quickfix.SessionID) quickfix.MessageRejectError {
+	c.recordActivity()
+	c.recordProcessedSeqNum(msg)
+	c.recordClockSkew(msg)
+
+	if d := c.dispatcher.Load(); d != nil {
+		d.enqueue(msg)
+		return nil
+	}
+	return c.processApp(msg)
+}
+
+// processApp matches an inbound application message with its pending call
+// and dispatches it to the subscribers.
+func (c *Client) processApp(msg *quickfix.Message) quickfix.MessageRejectError {
 	// Process message according to message type.
 	msgType, err := msg.MsgType()
 	if err != nil {
@@ -75,7 +153,11 @@ func (c *Client) FromApp(msg *quickfix.Message, s quickfix.SessionID) quickfix.M
 		return err
 	}
 
-	c.handleSubscriptions(msgType, msg)
+	possDup := isPossDup(msg)
+	c.trackReplay(possDup)
+	if !possDup || c.options.replayPolicy != ReplayPolicyDrop {
+		c.handleSubscriptions(msgType, msg)
+	}
 
 	reqIDTag, err2 := getReqIDTagFromMsgType(enum.MsgType(msgType))
 	if err2 != nil {
@@ -92,21 +174,26 @@ func (c *Client) FromApp(msg *quickfix.Message, s quickfix.SessionID) quickfix.M
 	c.mu.Lock()
 	call := c.pending[id]
 	delete(c.pending, id)
+	if call != nil {
+		c.recordMatch(call)
+	} else {
+		c.recordUnmatched(msg)
+	}
 	c.mu.Unlock()
 
 	if call != nil {
-		c.l.Infow(
+		c.recordLatencyForAlert(id, time.Since(call.sentAt))
+		c.resolveCheckpoint(id)
+		call.l.Infow(
 			"Matching response message",
 			"id_tag", reqIDTag,
 			"id", id,
 			"request", call.request,
 			"response", msg,
 		)
-		response, err2 := copyMessage(msg)
-		if err2 != nil {
-			c.l.Fatalw("Failed to copy response message", "error", err2)
-		}
-		call.response = response
+		// quickfix parses every inbound message into a new Message and does
+		// not touch it once FromApp returns, so the call can own it.
+		call.response = msg
 		call.done <- nil
 		close(call.done)
 	}
@@ -0,0 +1,81 @@
+package fix
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/chuckpreslar/emission"
+	"github.com/quickfixgo/enum"
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/tag"
+	"go.uber.org/zap"
+)
+
+// copyMessage round-trips msg through its string form, the way responses were
+// copied before being handed off.
+func copyMessage(msg *quickfix.Message) (*quickfix.Message, error) {
+	out := quickfix.NewMessage()
+	err := quickfix.ParseMessage(out, bytes.NewBufferString(msg.String()))
+	if err != nil {
+		return nil, err
+	}
+	out.ReceiveTime = msg.ReceiveTime
+	return out, nil
+}
+
+func newBenchResponse(b *testing.B) *quickfix.Message {
+	b.Helper()
+	msg := newTestReport("a", enum.OrdStatus_NEW)
+	msg.Header.SetString(tag.BeginString, quickfix.BeginStringFIX44)
+	msg.Header.SetInt(tag.MsgSeqNum, 1)
+	msg.Body.SetString(tag.ExecType, string(enum.ExecType_NEW))
+	msg.Body.SetString(tag.Price, "42000.5")
+	msg.Body.SetString(tag.OrderQty, "0.01")
+
+	// Parse it like quickfix does with inbound messages.
+	parsed, err := copyMessage(msg)
+	if err != nil {
+		b.Fatal(err)
+	}
+	return parsed
+}
+
+// BenchmarkProcessAppResponse measures matching a response with its pending
+// call, the response handed off without being copied.
+func BenchmarkProcessAppResponse(b *testing.B) {
+	c := &Client{
+		l:       zap.NewNop().Sugar(),
+		pending: make(map[string]*call),
+		emitter: emission.NewEmitter(),
+		tracker: NewOrderTracker(),
+	}
+	msg := newBenchResponse(b)
+	l := zap.NewNop().Sugar()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		cc := &call{l: l, done: make(chan error, 1)}
+		c.mu.Lock()
+		c.pending["a"] = cc
+		c.mu.Unlock()
+		if err := c.processApp(msg); err != nil {
+			b.Fatal(err)
+		}
+		<-cc.done
+	}
+}
+
+// BenchmarkCopyMessage measures the string round-trip responses used to go
+// through before being handed off.
+func BenchmarkCopyMessage(b *testing.B) {
+	msg := newBenchResponse(b)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := copyMessage(msg); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package fix
+
+import (
+	"sync"
+
+	"github.com/KyberNetwork/binance_fix_api/binancetag"
+	"github.com/quickfixgo/enum"
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/tag"
+)
+
+/*
+ListStatus<N> is sent in response to NewOrderList<E> and whenever the status
+of a list changes, e.g. once one of its orders is filled.
+
+Tag     Name                Type            Required    Description
+55      Symbol              STRING          Y
+66      ListID              STRING          N           Assigned by the server.
+25014   ClListID            STRING          N
+25015   OrigClListID        STRING          N
+1385    ContingencyType     INT             N           1: ONE_CANCELS_THE_OTHER, 2: ONE_TRIGGERS_THE_OTHER
+429     ListStatusType      INT             Y           2: RESPONSE, 4: EXEC_STARTED, 5: ALL_DONE, 6: UPDATED
+431     ListOrderStatus     INT             Y           3: EXECUTING, 6: ALL_DONE, 7: REJECT
+1386    ListRejectReason    INT             N           99: OTHER
+25016   ErrorCode           INT             N
+58      Text                STRING          N
+60      TransactTime        UTCTIMESTAMP    N
+73      NoOrders            NUM_IN_GROUP    N
+» 55    Symbol              STRING          Y
+» 37    OrderID             INT             Y
+» 11    ClOrdID             STRING          Y
+*/
+
+type ListStatusHandler func(l *OrderList)
+
+// SubscribeToListStatus registers listener for every ListStatus<N> received,
+// including those of lists placed by another session.
+func (c *Client) SubscribeToListStatus(listener ListStatusHandler) {
+	c.emitter.On(ListStatusTopic, listener)
+}
+
+// listLegRejects correlates the rejected execution reports of list orders
+// with their list by ListID<66>, until the list is done.
+type listLegRejects struct {
+	mu      sync.Mutex
+	rejects map[string]map[string]string
+}
+
+func (r *listLegRejects) add(listID, clOrdID, reason string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.rejects == nil {
+		r.rejects = make(map[string]map[string]string)
+	}
+	legs := r.rejects[listID]
+	if legs == nil {
+		legs = make(map[string]string)
+		r.rejects[listID] = legs
+	}
+	legs[clOrdID] = reason
+}
+
+// legRejects returns the leg rejects of listID, forgetting them with done.
+func (r *listLegRejects) legRejects(listID string, done bool) map[string]string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	legs := r.rejects[listID]
+	if done {
+		delete(r.rejects, listID)
+	}
+	if legs == nil {
+		return nil
+	}
+	out := make(map[string]string, len(legs))
+	for id, reason := range legs {
+		out[id] = reason
+	}
+	return out
+}
+
+// handleListStatus dispatches a ListStatus<N> to the subscribers. The leg
+// rejects of a done list are kept for the call which placed it, if any.
+func (c *Client) handleListStatus(msg *quickfix.Message) {
+	list, err := decodeListStatus(msg)
+	if err != nil {
+		_ = c.escalate("decode list status", newMessageError(err, msg))
+		return
+	}
+	if list.ListOrderStatus == enum.ListOrderStatus_REJECT {
+		c.recordRejectForAlert()
+	}
+
+	done := list.IsTerminal() && !c.isPending(list.ClListID)
+	list.LegRejects = c.lists.legRejects(list.ListID, done)
+	c.emitter.Emit(ListStatusTopic, &list)
+}
+
+// recordLegReject keeps why an order of a list was rejected, if msg is the
+// rejected execution report of one.
+func (c *Client) recordLegReject(msg *quickfix.Message) {
+	if !isRejectedReport(msg) || !msg.Body.Has(tag.ListID) {
+		return
+	}
+	listID, err := binancetag.GetString(msg, tag.ListID)
+	if err != nil {
+		return
+	}
+	clOrdID, err := getClientOrderID(msg)
+	if err != nil {
+		return
+	}
+	reason, err := getText(msg)
+	if err != nil {
+		return
+	}
+	c.lists.add(listID, clOrdID, reason)
+}
+
+func (c *Client) isPending(id string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	_, ok := c.pending[id]
+	return ok
+}
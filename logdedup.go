@@ -0,0 +1,118 @@
+package fix
+
+import (
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// WithLogDedupOpt collapses repeated warnings and errors of the client
+// logger. The first entry with a given level, message and fields is logged as
+// usual, identical ones logged within interval are counted instead, and a
+// single summary entry carrying the fields and the count is logged once the
+// interval elapsed.
+func WithLogDedupOpt(interval time.Duration) NewClientOption {
+	return func(o *Options) {
+		o.logDedupInterval = interval
+	}
+}
+
+func dedupLogger(l *zap.SugaredLogger, interval time.Duration) *zap.SugaredLogger {
+	state := &dedupState{interval: interval, entries: make(map[dedupKey]*dedupEntry)}
+	return l.WithOptions(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+		return &dedupCore{Core: core, state: state}
+	}))
+}
+
+type dedupKey struct {
+	level   zapcore.Level
+	message string
+	fields  string // Encoded context and fields of the entry.
+}
+
+// dedupEntry counts the entries suppressed since the first one was logged.
+type dedupEntry struct {
+	suppressed int
+	last       zapcore.Entry
+	fields     []zapcore.Field // Of the first entry, identical to the others.
+	core       zapcore.Core    // Core of the first entry, with its context.
+}
+
+type dedupState struct {
+	interval time.Duration
+	mu       sync.Mutex
+	entries  map[dedupKey]*dedupEntry
+}
+
+// dedupCore is a zapcore.Core suppressing repeated warnings and errors.
+type dedupCore struct {
+	zapcore.Core
+	state   *dedupState
+	context []zapcore.Field
+}
+
+func (c *dedupCore) With(fields []zapcore.Field) zapcore.Core {
+	return &dedupCore{
+		Core:    c.Core.With(fields),
+		state:   c.state,
+		context: append(c.context[:len(c.context):len(c.context)], fields...),
+	}
+}
+
+// encodeFields returns the context and fields of an entry as a string.
+func (c *dedupCore) encodeFields(fields []zapcore.Field) string {
+	enc := zapcore.NewJSONEncoder(zapcore.EncoderConfig{})
+	buf, err := enc.EncodeEntry(zapcore.Entry{}, append(c.context[:len(c.context):len(c.context)], fields...))
+	if err != nil {
+		return ""
+	}
+	defer buf.Free()
+	return buf.String()
+}
+
+func (c *dedupCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c *dedupCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	if ent.Level < zapcore.WarnLevel {
+		return c.Core.Write(ent, fields)
+	}
+
+	key := dedupKey{level: ent.Level, message: ent.Message, fields: c.encodeFields(fields)}
+	s := c.state
+	s.mu.Lock()
+	if e, ok := s.entries[key]; ok {
+		e.suppressed++
+		e.last = ent
+		s.mu.Unlock()
+		return nil
+	}
+	s.entries[key] = &dedupEntry{fields: fields, core: c.Core}
+	s.mu.Unlock()
+
+	time.AfterFunc(s.interval, func() { s.flush(key) })
+	return c.Core.Write(ent, fields)
+}
+
+// flush logs the summary of the entries suppressed for key, if any, and
+// lets the next identical entry through.
+func (s *dedupState) flush(key dedupKey) {
+	s.mu.Lock()
+	e := s.entries[key]
+	delete(s.entries, key)
+	s.mu.Unlock()
+
+	if e == nil || e.suppressed == 0 {
+		return
+	}
+	_ = e.core.Write(e.last, append(e.fields[:len(e.fields):len(e.fields)],
+		zap.Int("suppressed", e.suppressed),
+		zap.Duration("interval", s.interval),
+	))
+}
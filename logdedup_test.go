@@ -0,0 +1,40 @@
+package fix
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestDedupLogger(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	l := dedupLogger(zap.New(core).Sugar(), 20*time.Millisecond)
+
+	for i := 0; i < 5; i++ {
+		l.Errorw("Failed to decode", "id", "a")
+		l.Infow("Received message", "i", i)
+	}
+	l.Errorw("Failed to decode", "id", "b")
+	// Identical to the first one, with the field in the context.
+	l.With("id", "a").Errorw("Failed to decode")
+	l.Warnw("Other warning")
+
+	assert.Equal(t, 2, logs.FilterMessage("Failed to decode").Len())
+	assert.Equal(t, 5, logs.FilterMessage("Received message").Len())
+	assert.Equal(t, 1, logs.FilterMessage("Other warning").Len())
+
+	assert.Eventually(t, func() bool {
+		return logs.FilterMessage("Failed to decode").FilterField(zap.Int("suppressed", 5)).Len() == 1
+	}, time.Second, 5*time.Millisecond)
+	summary := logs.FilterMessage("Failed to decode").FilterField(zap.Int("suppressed", 5)).All()[0]
+	assert.Equal(t, "a", summary.ContextMap()["id"])
+	assert.Equal(t, 1, logs.FilterMessage("Other warning").Len())
+
+	// Logged again once the interval elapsed.
+	l.Errorw("Failed to decode", "id", "a")
+	assert.Equal(t, 4, logs.FilterMessage("Failed to decode").Len())
+}
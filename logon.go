@@ -0,0 +1,100 @@
+package fix
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/tag"
+)
+
+// ErrLogonFailed is returned by Start when the session is logged out or
+// disconnected before the logon completes, e.g. because the server rejected
+// the credentials.
+var ErrLogonFailed = errors.New("logon failed")
+
+// logonSignal is closed once a logon attempt completes, err telling why it
+// failed if it did.
+type logonSignal struct {
+	done chan struct{}
+	err  error
+}
+
+func newLogonSignal() *logonSignal {
+	return &logonSignal{done: make(chan struct{})}
+}
+
+func (s *logonSignal) resolved() bool {
+	select {
+	case <-s.done:
+		return true
+	default:
+		return false
+	}
+}
+
+func (s *logonSignal) resolve(err error) {
+	if !s.resolved() {
+		s.err = err
+		close(s.done)
+	}
+}
+
+// logonSignalLocked returns the signal of the current logon attempt. c.mu
+// must be held.
+func (c *Client) logonSignalLocked() *logonSignal {
+	if c.logon == nil {
+		c.logon = newLogonSignal()
+	}
+	return c.logon
+}
+
+// logonAttempt renews the signal of a failed attempt as a new one starts.
+func (c *Client) logonAttempt() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if s := c.logonSignalLocked(); s.resolved() && s.err != nil {
+		c.logon = newLogonSignal()
+	}
+}
+
+// logonSucceeded resolves the current logon attempt.
+func (c *Client) logonSucceeded() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.logonSignalLocked().resolve(nil)
+}
+
+// logonEnded fails the current logon attempt if it was still in progress, or
+// prepares the next one if it had succeeded.
+func (c *Client) logonEnded() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	s := c.logonSignalLocked()
+	if s.resolved() {
+		c.logon = newLogonSignal()
+		c.logoutText = ""
+		return
+	}
+	err := ErrLogonFailed
+	if c.logoutText != "" {
+		err = fmt.Errorf("%w: %s", ErrLogonFailed, c.logoutText)
+		c.logoutText = ""
+	}
+	s.resolve(err)
+}
+
+// recordLogout keeps the reason of a Logout<5> received from the server,
+// which tells why a logon was rejected.
+func (c *Client) recordLogout(msg *quickfix.Message) {
+	text, err := msg.Body.GetString(tag.Text)
+	if err != nil {
+		return
+	}
+	c.mu.Lock()
+	c.logoutText = text
+	c.mu.Unlock()
+}
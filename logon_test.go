@@ -0,0 +1,33 @@
+package fix
+
+import (
+	"testing"
+
+	"github.com/quickfixgo/enum"
+	"github.com/quickfixgo/tag"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLogonSignal(t *testing.T) {
+	c := &Client{}
+	first := c.logonSignalLocked()
+
+	logout := newTestMessage(enum.MsgType_LOGOUT)
+	logout.Body.SetString(tag.Text, "Invalid API key")
+	c.recordLogout(logout)
+	c.logonEnded()
+	<-first.done
+	assert.ErrorIs(t, first.err, ErrLogonFailed)
+	assert.ErrorContains(t, first.err, "Invalid API key")
+
+	c.logonAttempt()
+	second := c.logonSignalLocked()
+	assert.NotSame(t, first, second)
+	c.logonSucceeded()
+	<-second.done
+	assert.NoError(t, second.err)
+
+	// The session dropping after the logon waits for the next one.
+	c.logonEnded()
+	assert.False(t, c.logonSignalLocked().resolved())
+}
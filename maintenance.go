@@ -0,0 +1,254 @@
+package fix
+
+import (
+	"time"
+
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/tag"
+)
+
+// News is a News<B> message. Binance sends them every few seconds ahead of a
+// maintenance, after which the session is logged out.
+type News struct {
+	Headline   string
+	Text       string
+	ReceivedAt time.Time
+}
+
+type NewsHandler func(n *News)
+
+func decodeNews(msg *quickfix.Message) (News, error) {
+	var (
+		n   News
+		err error
+	)
+	if n.Headline, err = msg.Body.GetString(tag.Headline); err != nil {
+		return News{}, err
+	}
+	if n.Text, err = getText(msg); err != nil {
+		return News{}, err
+	}
+	n.ReceivedAt = msg.ReceiveTime
+	return n, nil
+}
+
+// MaintenanceConfig configures the maintenance mode, see
+// WithMaintenanceModeOpt.
+type MaintenanceConfig struct {
+	// IsNotice tells whether a News<B> announces a maintenance. Every news
+	// does if nil.
+	IsNotice func(n *News) bool
+	// OnPause is called in its own goroutine when the maintenance mode is
+	// entered, e.g. to cancel resting quotes.
+	OnPause func(n *News)
+	// OnResume is called in its own goroutine once the session logged on
+	// again after a maintenance.
+	OnResume func()
+}
+
+// WithMaintenanceModeOpt pauses trading when the exchange announces a
+// maintenance: new orders fail with ErrMaintenance until the session has
+// logged on again.
+func WithMaintenanceModeOpt(conf MaintenanceConfig) NewClientOption {
+	return func(o *Options) {
+		o.maintenance = &conf
+	}
+}
+
+// InMaintenance reports whether new orders are paused by a maintenance notice.
+func (c *Client) InMaintenance() bool {
+	return c.maintenance.Load()
+}
+
+func (c *Client) handleNews(msg *quickfix.Message) {
+	n, err := decodeNews(msg)
+	if err != nil {
+		_ = c.escalate("decode news", newMessageError(err, msg))
+		return
+	}
+	c.emitter.Emit(NewsTopic, &n)
+
+	conf := c.options.maintenance
+	if conf == nil || (conf.IsNotice != nil && !conf.IsNotice(&n)) {
+		return
+	}
+	if c.maintenance.CompareAndSwap(false, true) {
+		c.l.Warnw("Exchange maintenance announced, pausing new orders", "headline", n.Headline)
+		if conf.OnPause != nil {
+			go conf.OnPause(&n)
+		}
+	}
+}
+
+// resumeAfterMaintenance leaves the maintenance mode on logon, unless a
+// maintenance window is still going on.
+func (c *Client) resumeAfterMaintenance() {
+	if _, ok := c.scheduledDowntimeEnd(time.Now()); ok {
+		return
+	}
+	if !c.maintenance.CompareAndSwap(true, false) {
+		return
+	}
+	c.l.Info("Logged on again after maintenance, resuming new orders")
+	if conf := c.options.maintenance; conf != nil && conf.OnResume != nil {
+		go conf.OnResume()
+	}
+}
+
+// week is the period of weekly maintenance windows.
+const week = 7 * 24 * time.Hour
+
+// MaintenanceWindow is an expected downtime of the exchange, during which the
+// session may be logged out.
+type MaintenanceWindow struct {
+	Start    time.Time
+	Duration time.Duration
+	Weekly   bool // Repeats every week from Start.
+}
+
+// next returns the first occurrence of the window not over at now.
+func (w MaintenanceWindow) next(now time.Time) (start, end time.Time, ok bool) {
+	start = w.Start
+	if w.Weekly && now.After(start) {
+		start = start.Add(now.Sub(start) / week * week)
+		if !start.Add(w.Duration).After(now) {
+			start = start.Add(week)
+		}
+	}
+	end = start.Add(w.Duration)
+	return start, end, end.After(now)
+}
+
+// ScheduledDowntimePhase is the progress of a maintenance window.
+type ScheduledDowntimePhase string
+
+const (
+	// ScheduledDowntimeUpcoming is reported when new orders are paused ahead
+	// of the window.
+	ScheduledDowntimeUpcoming ScheduledDowntimePhase = "upcoming"
+	ScheduledDowntimeStarted  ScheduledDowntimePhase = "started"
+	// ScheduledDowntimeEnded is reported when the window is over, the session
+	// reconnects then if it was dropped.
+	ScheduledDowntimeEnded ScheduledDowntimePhase = "ended"
+)
+
+// ScheduledDowntime is the event of a maintenance window reaching a phase.
+type ScheduledDowntime struct {
+	Phase ScheduledDowntimePhase
+	Start time.Time
+	End   time.Time
+}
+
+type ScheduledDowntimeHandler func(d *ScheduledDowntime)
+
+// SubscribeToScheduledDowntime registers listener for the phases of the
+// windows set with WithMaintenanceWindowsOpt.
+func (c *Client) SubscribeToScheduledDowntime(listener ScheduledDowntimeHandler) {
+	c.emitter.On(ScheduledDowntimeTopic, listener)
+}
+
+// WithMaintenanceWindowsOpt pauses new orders pauseBefore the start of every
+// window, as WithMaintenanceModeOpt does, until the window is over. A session
+// dropped during a window is expected to be: it is not failed over, and it is
+// reconnected once the window ends.
+func WithMaintenanceWindowsOpt(pauseBefore time.Duration, windows ...MaintenanceWindow) NewClientOption {
+	return func(o *Options) {
+		o.maintenancePauseBefore = pauseBefore
+		o.maintenanceWindows = windows
+	}
+}
+
+// nextMaintenanceWindow returns the first occurrence of the maintenance
+// windows not over at now.
+func (c *Client) nextMaintenanceWindow(now time.Time) (start, end time.Time, ok bool) {
+	for _, w := range c.options.maintenanceWindows {
+		s, e, found := w.next(now)
+		if found && (!ok || s.Before(start)) {
+			start, end, ok = s, e, true
+		}
+	}
+	return start, end, ok
+}
+
+// scheduledDowntimeEnd returns the end of the maintenance window new orders
+// are paused for at now, if any.
+func (c *Client) scheduledDowntimeEnd(now time.Time) (time.Time, bool) {
+	start, end, ok := c.nextMaintenanceWindow(now)
+	if !ok || now.Before(start.Add(-c.options.maintenancePauseBefore)) {
+		return time.Time{}, false
+	}
+	return end, true
+}
+
+// startMaintenanceSchedule follows the maintenance windows until Stop.
+func (c *Client) startMaintenanceSchedule() {
+	if len(c.options.maintenanceWindows) == 0 {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.downtimeStop != nil {
+		return
+	}
+	c.downtimeStop = make(chan struct{})
+	go c.runMaintenanceSchedule(c.downtimeStop)
+}
+
+func (c *Client) stopMaintenanceSchedule() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.downtimeStop != nil {
+		close(c.downtimeStop)
+		c.downtimeStop = nil
+	}
+}
+
+func (c *Client) runMaintenanceSchedule(stop <-chan struct{}) {
+	for {
+		start, end, ok := c.nextMaintenanceWindow(time.Now())
+		if !ok {
+			return
+		}
+		phases := []struct {
+			at    time.Time
+			phase ScheduledDowntimePhase
+		}{
+			{start.Add(-c.options.maintenancePauseBefore), ScheduledDowntimeUpcoming},
+			{start, ScheduledDowntimeStarted},
+			{end, ScheduledDowntimeEnded},
+		}
+		for _, p := range phases {
+			if !sleepUntil(p.at, stop) {
+				return
+			}
+			c.enterDowntimePhase(ScheduledDowntime{Phase: p.phase, Start: start, End: end})
+		}
+	}
+}
+
+func (c *Client) enterDowntimePhase(d ScheduledDowntime) {
+	c.l.Infow("Scheduled maintenance", "phase", d.Phase, "start", d.Start, "end", d.End)
+	switch d.Phase {
+	case ScheduledDowntimeUpcoming:
+		c.maintenance.Store(true)
+	case ScheduledDowntimeEnded:
+		// A dropped session resumes on logon.
+		if c.IsConnected() {
+			c.resumeAfterMaintenance()
+		}
+	}
+	c.emitter.Emit(ScheduledDowntimeTopic, &d)
+}
+
+// sleepUntil waits until at, returning false if stop is closed first.
+func sleepUntil(at time.Time, stop <-chan struct{}) bool {
+	t := time.NewTimer(time.Until(at))
+	defer t.Stop()
+
+	select {
+	case <-t.C:
+		return true
+	case <-stop:
+		return false
+	}
+}
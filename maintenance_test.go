@@ -0,0 +1,107 @@
+package fix
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/chuckpreslar/emission"
+	"github.com/quickfixgo/enum"
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/tag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestMaintenanceWindowNext(t *testing.T) {
+	start := time.Date(2026, 10, 14, 2, 0, 0, 0, time.UTC) // A Wednesday.
+	w := MaintenanceWindow{Start: start, Duration: time.Hour, Weekly: true}
+
+	tests := []struct {
+		now   time.Time
+		start time.Time
+	}{
+		{start.Add(-time.Hour), start},
+		{start.Add(30 * time.Minute), start},
+		{start.Add(time.Hour), start.Add(week)},
+		{start.Add(3*week + 10*time.Minute), start.Add(3 * week)},
+		{start.Add(3*week + 2*time.Hour), start.Add(4 * week)},
+	}
+	for _, tt := range tests {
+		s, e, ok := w.next(tt.now)
+		require.True(t, ok)
+		assert.Equal(t, tt.start, s, tt.now)
+		assert.Equal(t, tt.start.Add(time.Hour), e, tt.now)
+	}
+
+	w.Weekly = false
+	_, _, ok := w.next(start.Add(time.Hour))
+	assert.False(t, ok)
+}
+
+func TestScheduledDowntime(t *testing.T) {
+	start := time.Now().Add(10 * time.Minute)
+	c := &Client{l: zap.NewNop().Sugar(), emitter: emission.NewEmitter()}
+	WithMaintenanceWindowsOpt(15*time.Minute, MaintenanceWindow{Start: start, Duration: time.Hour})(&c.options)
+
+	end, ok := c.scheduledDowntimeEnd(time.Now())
+	require.True(t, ok)
+	assert.Equal(t, start.Add(time.Hour), end)
+	_, ok = c.scheduledDowntimeEnd(start.Add(-20 * time.Minute))
+	assert.False(t, ok)
+
+	events := make(chan ScheduledDowntime, 1)
+	c.SubscribeToScheduledDowntime(func(d *ScheduledDowntime) { events <- *d })
+	c.enterDowntimePhase(ScheduledDowntime{Phase: ScheduledDowntimeUpcoming, Start: start, End: end})
+	assert.Equal(t, ScheduledDowntimeUpcoming, (<-events).Phase)
+	assert.True(t, c.InMaintenance())
+
+	// Logging on again during the window keeps new orders paused.
+	c.resumeAfterMaintenance()
+	assert.True(t, c.InMaintenance())
+}
+
+func TestMaintenanceMode(t *testing.T) {
+	g := newTestGateway(t)
+	g.ackOrders(t, enum.OrdStatus_NEW, nil)
+	paused := make(chan *News, 1)
+	resumed := make(chan struct{}, 1)
+	c := g.startClient(t, WithMaintenanceModeOpt(MaintenanceConfig{
+		IsNotice: func(n *News) bool { return strings.Contains(n.Headline, "maintenance") },
+		OnPause:  func(n *News) { paused <- n },
+		OnResume: func() { resumed <- struct{}{} },
+	}))
+	sessionID := quickfix.SessionID{BeginString: quickfix.BeginStringFIX44, SenderCompID: "SPOT", TargetCompID: "EXAMPLE"}
+	news := func(headline string) {
+		msg := newTestMessage(enum.MsgType_NEWS)
+		msg.Body.SetString(tag.Headline, headline)
+		g.reply(t, sessionID, msg)
+	}
+	place := func() error {
+		_, err := c.NewOrderSingleService().
+			Symbol("BTCUSDT").Side(enum.Side_BUY).Type(enum.OrdType_MARKET).Quantity(1).
+			Do(context.Background())
+		return err
+	}
+
+	// Other news do not pause trading.
+	news("New listing")
+	news("Server maintenance in 10 minutes")
+	n := <-paused
+	assert.Equal(t, "Server maintenance in 10 minutes", n.Headline)
+	assert.True(t, c.InMaintenance())
+	assert.ErrorIs(t, place(), ErrMaintenance)
+
+	// Trading resumes once the session logged on again.
+	c.Stop()
+	require.NoError(t, c.Start(context.Background()))
+	select {
+	case <-resumed:
+	case <-time.After(time.Second):
+		t.Fatal("trading did not resume")
+	}
+	assert.False(t, c.InMaintenance())
+	assert.NoError(t, place())
+}
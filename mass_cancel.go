@@ -0,0 +1,199 @@
+package fix
+
+import (
+	"context"
+	"time"
+
+	"github.com/KyberNetwork/binance_fix_api/binancetag"
+	"github.com/google/uuid"
+	"github.com/quickfixgo/enum"
+	"github.com/quickfixgo/field"
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/tag"
+)
+
+// massCancelGracePeriod is how long a mass cancel waits, once its report
+// arrived, for the execution reports of the canceled orders.
+const massCancelGracePeriod = time.Second
+
+/*
+Tag     Name                    Type    Required    Description
+11      ClOrdID                 STRING  Y           ClOrdID of this mass cancel request.
+55      Symbol                  STRING  Y           Symbol whose orders are canceled.
+530     MassCancelRequestType   CHAR    Y           1: CANCEL_SYMBOL_ORDERS
+
+OrderMassCancelReport<r>
+531     MassCancelResponse      CHAR    Y           0: CANCEL_REQUEST_REJECTED, 1: CANCEL_SYMBOL_ORDERS
+532     MassCancelRejectReason  INT     N           99: OTHER
+533     TotalAffectedOrders     INT     N           How many orders were canceled.
+*/
+
+// MassCancelResult is the outcome of a mass cancel.
+type MassCancelResult struct {
+	Symbol              string
+	TotalAffectedOrders int
+	// StillOpen holds the orders of the symbol which are not confirmed done
+	// once the report arrived and the execution reports of the canceled
+	// orders had a grace period to follow, e.g. because the cancel raced with
+	// a new order.
+	StillOpen []Order
+}
+
+// MassCancelRejectedError is returned when the server rejects a mass cancel.
+type MassCancelRejectedError struct {
+	ClOrdID                string
+	MassCancelRejectReason int // MassCancelRejectReason<532>, -1 if absent.
+	ErrorCode              int // Binance ErrorCode<25016>, 0 if absent.
+	Text                   string
+}
+
+func (e *MassCancelRejectedError) Error() string {
+	return "mass cancel rejected: " + e.Text
+}
+
+// MassCancelService cancels every open order of a symbol with an
+// OrderMassCancelRequest<q>.
+type MassCancelService struct {
+	c      *Client
+	symbol string
+	fields customFields
+}
+
+func (c *Client) NewMassCancelService() *MassCancelService {
+	return &MassCancelService{c: c}
+}
+
+// Symbol set symbol
+func (s *MassCancelService) Symbol(symbol string) *MassCancelService {
+	s.symbol = symbol
+	return s
+}
+
+// SetField sets any body tag, e.g. one newly added by Binance.
+func (s *MassCancelService) SetField(t quickfix.Tag, value string) *MassCancelService {
+	s.fields.set(t, value)
+	return s
+}
+
+// Do sends the mass cancel and waits for its OrderMassCancelReport<r>, then
+// for the execution reports of the orders the tracker knew as open, see
+// MassCancelResult.StillOpen. A reject is returned as a
+// *MassCancelRejectedError.
+func (s *MassCancelService) Do(ctx context.Context) (MassCancelResult, error) {
+	res, _, err := s.do(ctx)
+	return res, err
+}
+
+// do is Do, also returning the ClOrdID<11> of the tracked orders confirmed
+// canceled. The reports of the canceled orders carry the ClOrdID of the mass
+// cancel and the one of the canceled order as OrigClOrdID<41>, see
+// Order.trackedID.
+func (s *MassCancelService) do(ctx context.Context) (MassCancelResult, []string, error) {
+	if s.symbol == "" {
+		return MassCancelResult{}, nil, invalidOrder("Symbol is required")
+	}
+
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return MassCancelResult{}, nil, err
+	}
+
+	msg := quickfix.NewMessage()
+	msg.Header.Set(field.NewMsgType(enum.MsgType_ORDER_MASS_CANCEL_REQUEST))
+	msg.Body.Set(field.NewClOrdID(id.String()))
+	msg.Body.Set(field.NewSymbol(s.symbol))
+	msg.Body.Set(field.NewMassCancelRequestType(enum.MassCancelRequestType_CANCEL_ORDERS_FOR_A_SECURITY))
+	s.fields.apply(msg)
+
+	// Watch before sending so no cancel can be missed.
+	var watches []*orderWatch
+	for _, o := range s.c.tracker.OpenOrders() {
+		if o.Symbol == s.symbol {
+			watches = append(watches, s.c.watchers.add(o.ClientOrderID))
+		}
+	}
+	defer func() {
+		for _, w := range watches {
+			s.c.watchers.remove(w)
+		}
+	}()
+
+	l := s.c.logger(ctx)
+	resp, err := s.c.Call(ctx, id.String(), msg)
+	if err != nil {
+		l.Errorw("Failed to mass cancel orders", "request", msg, "err", err)
+		return MassCancelResult{}, nil, err
+	}
+
+	res, err := decodeMassCancelReport(resp)
+	if err != nil {
+		return MassCancelResult{}, nil, s.c.escalateDecode("decode mass cancel report", err)
+	}
+
+	var canceled []string
+	grace, cancel := context.WithTimeout(ctx, massCancelGracePeriod)
+	defer cancel()
+	for _, w := range watches {
+		o, done := s.c.awaitDone(grace, w)
+		switch {
+		case !done:
+			res.StillOpen = append(res.StillOpen, o)
+		case o.Status == OrderStatusCanceled:
+			canceled = append(canceled, w.clOrdID)
+		}
+	}
+
+	// Orders placed while the mass cancel was in flight.
+	for _, o := range s.c.tracker.OpenOrders() {
+		if o.Symbol == s.symbol && !watched(watches, o.ClientOrderID) {
+			res.StillOpen = append(res.StillOpen, o)
+		}
+	}
+	return res, canceled, nil
+}
+
+// DoAsync sends the request like Do, without waiting for the response.
+func (s *MassCancelService) DoAsync(ctx context.Context) *Future[MassCancelResult] {
+	return doAsync(ctx, s.Do)
+}
+
+func decodeMassCancelReport(msg *quickfix.Message) (MassCancelResult, error) {
+	var response field.MassCancelResponseField
+	if err := msg.Body.Get(&response); err != nil {
+		return MassCancelResult{}, newMessageError(err, msg)
+	}
+
+	if response.Value() == enum.MassCancelResponse_CANCEL_REQUEST_REJECTED {
+		e := MassCancelRejectedError{MassCancelRejectReason: -1}
+		var err error
+		if e.ClOrdID, err = getClientOrderID(msg); err != nil {
+			return MassCancelResult{}, newMessageError(err, msg)
+		}
+		if msg.Body.Has(tag.MassCancelRejectReason) {
+			if e.MassCancelRejectReason, err = msg.Body.GetInt(tag.MassCancelRejectReason); err != nil {
+				return MassCancelResult{}, newMessageError(err, msg)
+			}
+		}
+		if e.ErrorCode, err = binancetag.GetErrorCode(msg); err != nil {
+			return MassCancelResult{}, newMessageError(err, msg)
+		}
+		if e.Text, err = getText(msg); err != nil {
+			return MassCancelResult{}, newMessageError(err, msg)
+		}
+		return MassCancelResult{}, newMessageError(&e, msg)
+	}
+
+	var res MassCancelResult
+	var symbol field.SymbolField
+	if err := msg.Body.Get(&symbol); err == nil {
+		res.Symbol = symbol.Value()
+	}
+	if msg.Body.Has(tag.TotalAffectedOrders) {
+		total, err := msg.Body.GetInt(tag.TotalAffectedOrders)
+		if err != nil {
+			return MassCancelResult{}, newMessageError(err, msg)
+		}
+		res.TotalAffectedOrders = total
+	}
+	return res, nil
+}
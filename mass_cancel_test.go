@@ -0,0 +1,33 @@
+package fix
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/quickfixgo/enum"
+	"github.com/quickfixgo/tag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeMassCancelReport(t *testing.T) {
+	report := newTestMessage(enum.MsgType_ORDER_MASS_CANCEL_REPORT)
+	report.Body.SetString(tag.ClOrdID, "a")
+	report.Body.SetString(tag.Symbol, "BTCUSDT")
+	report.Body.SetString(tag.MassCancelResponse, "1")
+	report.Body.SetInt(tag.TotalAffectedOrders, 3)
+
+	res, err := decodeMassCancelReport(report)
+	require.NoError(t, err)
+	assert.Equal(t, MassCancelResult{Symbol: "BTCUSDT", TotalAffectedOrders: 3}, res)
+
+	rejected := newTestMessage(enum.MsgType_ORDER_MASS_CANCEL_REPORT)
+	rejected.Body.SetString(tag.ClOrdID, "b")
+	rejected.Body.SetString(tag.MassCancelResponse, "0")
+	rejected.Body.SetString(tag.Text, "Unknown symbol.")
+
+	_, err = decodeMassCancelReport(rejected)
+	var rejErr *MassCancelRejectedError
+	require.True(t, errors.As(err, &rejErr))
+	assert.Equal(t, MassCancelRejectedError{ClOrdID: "b", MassCancelRejectReason: -1, Text: "Unknown symbol."}, *rejErr)
+}
@@ -0,0 +1,192 @@
+package fix
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/quickfix/config"
+	"go.uber.org/zap"
+)
+
+// ErrUnknownSession is returned when routing to a session which is not part
+// of a SessionGroup.
+var ErrUnknownSession = errors.New("unknown session")
+
+// sessionGlobalSettings are the settings the client reads from the global
+// settings, which every session of a SessionGroup gets its own value of.
+var sessionGlobalSettings = []string{
+	config.BeginString,
+	config.SenderCompID,
+	config.TargetCompID,
+	config.TimeStampPrecision,
+	config.SocketConnectHost,
+	config.SocketConnectPort,
+	config.SocketUseSSL,
+	config.SocketServerName,
+	config.SocketInsecureSkipVerify,
+	config.SocketCAFile,
+	config.FileStorePath,
+	config.FileStoreSync,
+}
+
+// SessionGroup runs a client for every session of a settings file, each with
+// its own connection, logon and sequence numbers, and routes calls to them.
+type SessionGroup struct {
+	clients map[quickfix.SessionID]*Client
+	ids     []quickfix.SessionID
+
+	mu        sync.RWMutex
+	defaultID quickfix.SessionID
+}
+
+// NewSessionGroup creates a client for every session of conf.Settings and
+// connects them. The first session, in the order of their ID, is the default
+// one. Sessions differing only by their SessionQualifier are rejected: the
+// client of a session is set up from its comp IDs only, both would log on as
+// the same session.
+func NewSessionGroup(ctx context.Context, l *zap.SugaredLogger, conf Config, opts ...NewClientOption) (*SessionGroup, error) {
+	if conf.Settings == nil {
+		return nil, errors.New("empty quickfix settings")
+	}
+	if err := checkSessionQualifiers(conf.Settings); err != nil {
+		return nil, err
+	}
+
+	g := &SessionGroup{clients: make(map[quickfix.SessionID]*Client)}
+	for id, settings := range conf.Settings.SessionSettings() {
+		sessionConf := conf
+		var err error
+		if sessionConf.Settings, err = splitSessionSettings(settings); err != nil {
+			return nil, fmt.Errorf("session %v: %w", id, err)
+		}
+		client, err := New(l.With("session", id.String()), sessionConf, opts...)
+		if err != nil {
+			return nil, fmt.Errorf("session %v: %w", id, err)
+		}
+		g.clients[id] = client
+		g.ids = append(g.ids, id)
+	}
+	if len(g.ids) == 0 {
+		return nil, errors.New("no session configured")
+	}
+	sort.Slice(g.ids, func(i, j int) bool { return g.ids[i].String() < g.ids[j].String() })
+	g.defaultID = g.ids[0]
+
+	for _, id := range g.ids {
+		if err := g.clients[id].Connect(ctx); err != nil {
+			g.Close()
+			return nil, fmt.Errorf("session %v: %w", id, err)
+		}
+	}
+	return g, nil
+}
+
+// checkSessionQualifiers fails if two sessions of settings only differ by
+// their SessionQualifier.
+func checkSessionQualifiers(settings *quickfix.Settings) error {
+	seen := make(map[quickfix.SessionID]quickfix.SessionID)
+	for id := range settings.SessionSettings() {
+		key := id
+		key.Qualifier = ""
+		if other, ok := seen[key]; ok {
+			return fmt.Errorf("sessions %v and %v only differ by their SessionQualifier", other, id)
+		}
+		seen[key] = id
+	}
+	return nil
+}
+
+// splitSessionSettings returns settings holding the single session whose
+// merged settings are given.
+func splitSessionSettings(session *quickfix.SessionSettings) (*quickfix.Settings, error) {
+	settings := quickfix.NewSettings()
+	global := settings.GlobalSettings()
+	for _, name := range sessionGlobalSettings {
+		if !session.HasSetting(name) {
+			continue
+		}
+		value, err := session.Setting(name)
+		if err != nil {
+			return nil, err
+		}
+		global.Set(name, value)
+	}
+	if _, err := settings.AddSession(session); err != nil {
+		return nil, err
+	}
+	return settings, nil
+}
+
+// Sessions returns the ID of every session, sorted.
+func (g *SessionGroup) Sessions() []quickfix.SessionID {
+	return append([]quickfix.SessionID(nil), g.ids...)
+}
+
+// Session returns the client of the session id, the default one if id is
+// zero.
+func (g *SessionGroup) Session(id quickfix.SessionID) (*Client, error) {
+	if id == (quickfix.SessionID{}) {
+		g.mu.RLock()
+		id = g.defaultID
+		g.mu.RUnlock()
+	}
+	client, ok := g.clients[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %v", ErrUnknownSession, id)
+	}
+	return client, nil
+}
+
+// Default returns the client of the default session.
+func (g *SessionGroup) Default() *Client {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.clients[g.defaultID]
+}
+
+// SetDefault makes id the default session.
+func (g *SessionGroup) SetDefault(id quickfix.SessionID) error {
+	if _, ok := g.clients[id]; !ok {
+		return fmt.Errorf("%w: %v", ErrUnknownSession, id)
+	}
+	g.mu.Lock()
+	g.defaultID = id
+	g.mu.Unlock()
+	return nil
+}
+
+// Connected reports whether every session is logged on, by session.
+func (g *SessionGroup) Connected() map[quickfix.SessionID]bool {
+	connected := make(map[quickfix.SessionID]bool, len(g.clients))
+	for id, client := range g.clients {
+		connected[id] = client.IsConnected()
+	}
+	return connected
+}
+
+// Call sends msg on the session sessionID, the default one if it is zero,
+// and waits for the response, see Client.Call.
+func (g *SessionGroup) Call(
+	ctx context.Context, sessionID quickfix.SessionID, id string, msg *quickfix.Message,
+) (*quickfix.Message, error) {
+	client, err := g.Session(sessionID)
+	if err != nil {
+		return nil, err
+	}
+	return client.Call(ctx, id, msg)
+}
+
+// Close closes every session, see Client.Close.
+func (g *SessionGroup) Close() error {
+	var errs []error
+	for _, id := range g.ids {
+		if err := g.clients[id].Close(); err != nil {
+			errs = append(errs, fmt.Errorf("session %v: %w", id, err))
+		}
+	}
+	return errors.Join(errs...)
+}
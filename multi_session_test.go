@@ -0,0 +1,135 @@
+package fix
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/quickfix/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+const multiSessionConf = `
+[DEFAULT]
+BeginString=FIX.4.4
+SocketConnectHost=fix-oe.binance.com
+SocketConnectPort=9000
+HeartBtInt=30
+TargetCompID=SPOT
+
+[SESSION]
+SenderCompID=FIRST
+
+[SESSION]
+SenderCompID=SECOND
+SocketConnectPort=9001
+`
+
+func TestSplitSessionSettings(t *testing.T) {
+	settings, err := quickfix.ParseSettings(strings.NewReader(multiSessionConf))
+	require.NoError(t, err)
+	require.Len(t, settings.SessionSettings(), 2)
+
+	for id, session := range settings.SessionSettings() {
+		split, err := splitSessionSettings(session)
+		require.NoError(t, err)
+		require.Len(t, split.SessionSettings(), 1)
+		assert.Contains(t, split.SessionSettings(), id)
+
+		sender, err := split.GlobalSettings().Setting(config.SenderCompID)
+		require.NoError(t, err)
+		assert.Equal(t, id.SenderCompID, sender)
+		port, err := split.GlobalSettings().Setting(config.SocketConnectPort)
+		require.NoError(t, err)
+		want := map[string]string{"FIRST": "9000", "SECOND": "9001"}[id.SenderCompID]
+		assert.Equal(t, want, port)
+
+		_, err = New(zap.NewNop().Sugar(), Config{
+			APIKey:             "key",
+			PrivateKeyFilePath: "./sample/ed25519.pem",
+			Settings:           split,
+		})
+		require.NoError(t, err)
+	}
+}
+
+func TestSessionGroupRouting(t *testing.T) {
+	first := quickfix.SessionID{BeginString: quickfix.BeginStringFIX44, SenderCompID: "FIRST", TargetCompID: "SPOT"}
+	second := quickfix.SessionID{BeginString: quickfix.BeginStringFIX44, SenderCompID: "SECOND", TargetCompID: "SPOT"}
+	g := &SessionGroup{
+		clients:   map[quickfix.SessionID]*Client{first: {}, second: {}},
+		ids:       []quickfix.SessionID{first, second},
+		defaultID: first,
+	}
+
+	c, err := g.Session(quickfix.SessionID{})
+	require.NoError(t, err)
+	assert.Same(t, g.clients[first], c)
+
+	require.NoError(t, g.SetDefault(second))
+	assert.Same(t, g.clients[second], g.Default())
+
+	unknown := quickfix.SessionID{BeginString: quickfix.BeginStringFIX44, SenderCompID: "OTHER", TargetCompID: "SPOT"}
+	_, err = g.Session(unknown)
+	assert.ErrorIs(t, err, ErrUnknownSession)
+	assert.ErrorIs(t, g.SetDefault(unknown), ErrUnknownSession)
+	assert.Equal(t, []quickfix.SessionID{first, second}, g.Sessions())
+}
+
+func TestSessionGroupSetDefaultConcurrently(t *testing.T) {
+	first := quickfix.SessionID{BeginString: quickfix.BeginStringFIX44, SenderCompID: "FIRST", TargetCompID: "SPOT"}
+	second := quickfix.SessionID{BeginString: quickfix.BeginStringFIX44, SenderCompID: "SECOND", TargetCompID: "SPOT"}
+	g := &SessionGroup{
+		clients:   map[quickfix.SessionID]*Client{first: {}, second: {}},
+		ids:       []quickfix.SessionID{first, second},
+		defaultID: first,
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			assert.NoError(t, g.SetDefault([]quickfix.SessionID{first, second}[i%2]))
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			_, err := g.Session(quickfix.SessionID{})
+			assert.NoError(t, err)
+			assert.NotNil(t, g.Default())
+		}
+	}()
+	wg.Wait()
+}
+
+func TestSessionGroupRejectsQualifiedDuplicates(t *testing.T) {
+	settings, err := quickfix.ParseSettings(strings.NewReader(`
+[DEFAULT]
+BeginString=FIX.4.4
+SocketConnectHost=fix-oe.binance.com
+SocketConnectPort=9000
+SenderCompID=FIRST
+TargetCompID=SPOT
+
+[SESSION]
+SessionQualifier=A
+
+[SESSION]
+SessionQualifier=B
+`))
+	require.NoError(t, err)
+	require.Len(t, settings.SessionSettings(), 2)
+
+	_, err = NewSessionGroup(context.Background(), zap.NewNop().Sugar(), Config{
+		APIKey:             "key",
+		PrivateKeyFilePath: "./sample/ed25519.pem",
+		Settings:           settings,
+	})
+	assert.ErrorContains(t, err, "only differ by their SessionQualifier")
+}
@@ -0,0 +1,112 @@
+package fix
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// LegResult is the outcome of a leg placed by PlaceLegs.
+type LegResult struct {
+	Order     Order
+	Err       error // Why the leg could not be placed.
+	Canceled  bool  // The leg was canceled because another one failed.
+	CancelErr error // Why canceling the leg failed.
+}
+
+// MultiLegError is returned by PlaceLegs when some legs failed.
+type MultiLegError struct {
+	Legs []LegResult
+}
+
+func (e *MultiLegError) Error() string {
+	var failed []string
+	for i, leg := range e.Legs {
+		if leg.Err != nil {
+			failed = append(failed, "leg "+strconv.Itoa(i)+": "+leg.Err.Error())
+		}
+	}
+	return "multi-leg order failed: " + strings.Join(failed, "; ")
+}
+
+// PlaceLegs sends every leg concurrently. If any leg fails, the legs which
+// were placed and are still open are canceled, as well as the legs whose
+// outcome is unknown because they timed out or exceeded their ack latency
+// budget, and a *MultiLegError holding the result of every leg is returned.
+// Legs filled before the failure are not unwound.
+func (c *Client) PlaceLegs(ctx context.Context, legs ...*NewOrderSingleService) ([]LegResult, error) {
+	results := make([]LegResult, len(legs))
+
+	// Fix the ClOrdID<11> of every leg so that legs with an unknown outcome
+	// can be canceled.
+	ids := make([]string, len(legs))
+	for i, leg := range legs {
+		id, err := leg.clientOrderID()
+		if err != nil {
+			return nil, err
+		}
+		ids[i] = id
+		prev := leg.clOrdID
+		leg.clOrdID = id
+		defer func() { leg.clOrdID = prev }()
+	}
+
+	var wg sync.WaitGroup
+	for i, leg := range legs {
+		wg.Add(1)
+		go func(i int, leg *NewOrderSingleService) {
+			defer wg.Done()
+			results[i].Order, results[i].Err = leg.Do(ctx)
+		}(i, leg)
+	}
+	wg.Wait()
+
+	failed := false
+	for _, r := range results {
+		if r.Err != nil {
+			failed = true
+			break
+		}
+	}
+	if !failed {
+		return results, nil
+	}
+
+	for i, leg := range legs {
+		r := &results[i]
+		if r.Err != nil && !outcomeUnknown(r.Err) || r.Err == nil && r.Order.Status.IsTerminal() {
+			continue
+		}
+		wg.Add(1)
+		go func(id string) {
+			defer wg.Done()
+			// ctx may be what made the leg fail.
+			ctx, cancel := context.WithTimeout(context.Background(), chaseCancelTimeout)
+			defer cancel()
+			// A leg with an unknown outcome may not have reached the
+			// matching engine yet, and be unknown to the first cancel.
+			policy := CancelRetryPolicy{Backoff: 100 * time.Millisecond, RetryIf: RetryUnknownOrder}
+			r.CancelErr = RetryCancel(ctx, policy, func(ctx context.Context) error {
+				_, err := c.cancelOrder(ctx, leg.symbol, id)
+				return err
+			})
+			r.Canceled = r.CancelErr == nil
+		}(ids[i])
+	}
+	wg.Wait()
+
+	return results, &MultiLegError{Legs: results}
+}
+
+// outcomeUnknown reports whether err leaves it unknown if an order reached
+// the server, unless a cancel is already chasing it.
+func outcomeUnknown(err error) bool {
+	var budgetErr *LatencyBudgetExceededError
+	if errors.As(err, &budgetErr) {
+		return !budgetErr.CancelSent
+	}
+	return errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled)
+}
@@ -0,0 +1,81 @@
+package fix
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/quickfixgo/enum"
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/tag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestLeg(c *Client, clOrdID, symbol string) *NewOrderSingleService {
+	return c.NewOrderSingleService().ClOrdID(clOrdID).Symbol(symbol).
+		Side(enum.Side_BUY).Type(enum.OrdType_MARKET).Quantity(1)
+}
+
+func TestPlaceLegsCancelsUnknownOutcome(t *testing.T) {
+	// The lost leg is unknown to the first cancel.
+	var orders lateOrders
+	c := NewWithCaller(CallerFunc(func(ctx context.Context, id string, msg *quickfix.Message) (*quickfix.Message, error) {
+		msgType, _ := msg.MsgType()
+		if enum.MsgType(msgType) == enum.MsgType_ORDER_CANCEL_REQUEST {
+			return orders.cancel(id, msg), nil
+		}
+
+		symbol, _ := msg.Body.GetString(tag.Symbol)
+		if symbol == "ETHUSDT" {
+			// Lost on the way back.
+			<-ctx.Done()
+			return nil, ctx.Err()
+		}
+		return newTestReport(id, enum.OrdStatus_NEW), nil
+	}))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	results, err := c.PlaceLegs(ctx, newTestLeg(c, "a", "BTCUSDT"), newTestLeg(c, "b", "ETHUSDT"))
+	var legErr *MultiLegError
+	require.ErrorAs(t, err, &legErr)
+	require.Len(t, results, 2)
+
+	assert.NoError(t, results[0].Err)
+	assert.True(t, results[0].Canceled)
+	assert.ErrorIs(t, results[1].Err, context.DeadlineExceeded)
+	assert.True(t, results[1].Canceled)
+	assert.NoError(t, results[1].CancelErr)
+	assert.Equal(t, 2, orders.attemptsOf("b"))
+}
+
+func TestPlaceLegsGeneratesClOrdIDs(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		placed   []string
+		canceled []string
+	)
+	c := NewWithCaller(CallerFunc(func(ctx context.Context, id string, msg *quickfix.Message) (*quickfix.Message, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		msgType, _ := msg.MsgType()
+		if enum.MsgType(msgType) == enum.MsgType_ORDER_CANCEL_REQUEST {
+			orig, _ := msg.Body.GetString(tag.OrigClOrdID)
+			canceled = append(canceled, orig)
+			return nil, ErrClosed
+		}
+		placed = append(placed, id)
+		return nil, context.DeadlineExceeded
+	}))
+
+	leg := newTestLeg(c, "", "BTCUSDT")
+	results, err := c.PlaceLegs(context.Background(), leg)
+	require.Error(t, err)
+	assert.False(t, results[0].Canceled)
+	assert.ErrorIs(t, results[0].CancelErr, ErrClosed)
+	require.Len(t, placed, 1)
+	assert.Equal(t, placed, canceled)
+	assert.Empty(t, leg.clOrdID)
+}
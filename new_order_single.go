@@ -2,13 +2,16 @@ package fix
 
 import (
 	"context"
+	"fmt"
+	"time"
 
+	"github.com/KyberNetwork/binance_fix_api/binancetag"
 	"github.com/google/uuid"
 	"github.com/quickfixgo/enum"
 	"github.com/quickfixgo/field"
 	"github.com/quickfixgo/quickfix"
 	"github.com/quickfixgo/tag"
-	"go.uber.org/zap"
+	"github.com/shopspring/decimal"
 )
 
 /*
@@ -35,15 +38,34 @@ Tag     Name                    Type    Required    Description
 25032   SOR                     BOOLEAN N           Whether to activate SOR for this order.
 */
 
-// NewOrderSingleService uses uuid to generate unique ClOrdID.
+// NewOrderSingleService uses uuid to generate unique ClOrdID unless one is
+// set with ClOrdID. Amounts set with the *Decimal builders are sent as is,
+// those set with float64 are sent in their shortest representation.
 type NewOrderSingleService struct {
 	c           *Client
+	clOrdID     string
 	symbol      string
 	side        enum.Side
 	orderType   enum.OrdType
 	timeInForce *enum.TimeInForce
-	quantity    *float64
-	price       *float64
+	execInst    enum.ExecInst
+	quantity    *decimal.Decimal
+	cashQty     *decimal.Decimal
+	price       *decimal.Decimal
+	maxFloor    *decimal.Decimal
+
+	triggerPrice     *decimal.Decimal
+	triggerDirection TriggerDirection
+	trailingDelta    *int
+
+	strategyID  *int
+	strategy    *int
+	stpMode     SelfTradePreventionMode
+	sor         bool
+	ttl         time.Duration
+	ackBudget   time.Duration
+	chaseCancel bool
+	fields      customFields
 }
 
 func (c *Client) NewOrderSingleService() *NewOrderSingleService {
@@ -52,6 +74,13 @@ func (c *Client) NewOrderSingleService() *NewOrderSingleService {
 	}
 }
 
+// ClOrdID set the ClOrdID of the order, a random one is generated otherwise.
+// Do fails with ErrDuplicateID if a call with the same ID is pending.
+func (s *NewOrderSingleService) ClOrdID(clOrdID string) *NewOrderSingleService {
+	s.clOrdID = clOrdID
+	return s
+}
+
 // Symbol set symbol
 func (s *NewOrderSingleService) Symbol(symbol string) *NewOrderSingleService {
 	s.symbol = symbol
@@ -76,52 +105,374 @@ func (s *NewOrderSingleService) TimeInForce(timeInForce enum.TimeInForce) *NewOr
 	return s
 }
 
+// ExecInst set execInst. With PARTICIPANT_DONT_INITIATE the order is
+// post-only: it is rejected instead of taking liquidity, and Do then fails
+// with an *OrderRejectedError matching ErrPostOnlyWouldTake.
+func (s *NewOrderSingleService) ExecInst(execInst enum.ExecInst) *NewOrderSingleService {
+	s.execInst = execInst
+	return s
+}
+
 // Quantity set quantity
 func (s *NewOrderSingleService) Quantity(quantity float64) *NewOrderSingleService {
+	return s.QuantityDecimal(decimal.NewFromFloat(quantity))
+}
+
+// QuantityDecimal set quantity
+func (s *NewOrderSingleService) QuantityDecimal(quantity decimal.Decimal) *NewOrderSingleService {
 	s.quantity = &quantity
 	return s
 }
 
+// CashOrderQty set the quantity of a market order in units of the quote
+// asset, instead of Quantity.
+func (s *NewOrderSingleService) CashOrderQty(quoteQty float64) *NewOrderSingleService {
+	return s.CashOrderQtyDecimal(decimal.NewFromFloat(quoteQty))
+}
+
+// CashOrderQtyDecimal is CashOrderQty with an exact quantity.
+func (s *NewOrderSingleService) CashOrderQtyDecimal(quoteQty decimal.Decimal) *NewOrderSingleService {
+	s.cashQty = &quoteQty
+	return s
+}
+
 // Price set price
 func (s *NewOrderSingleService) Price(price float64) *NewOrderSingleService {
+	return s.PriceDecimal(decimal.NewFromFloat(price))
+}
+
+// PriceDecimal set price
+func (s *NewOrderSingleService) PriceDecimal(price decimal.Decimal) *NewOrderSingleService {
 	s.price = &price
 	return s
 }
 
-func (s *NewOrderSingleService) Do(ctx context.Context) (Order, error) {
-	id, err := uuid.NewRandom()
-	if err != nil {
-		return Order{}, err
-	}
+// TriggerPrice makes a STOP or STOP_LIMIT order activate once the last trade
+// price reaches price, moving up or down as set by direction. A buy stop-loss
+// triggers up, a sell stop-loss down; take-profit orders trigger the other
+// way.
+func (s *NewOrderSingleService) TriggerPrice(price float64, direction TriggerDirection) *NewOrderSingleService {
+	return s.TriggerPriceDecimal(decimal.NewFromFloat(price), direction)
+}
 
-	msg := quickfix.NewMessage()
-	msg.Header.Set(field.NewMsgType(enum.MsgType_ORDER_SINGLE))
+// TriggerPriceDecimal is TriggerPrice with an exact price.
+func (s *NewOrderSingleService) TriggerPriceDecimal(price decimal.Decimal, direction TriggerDirection) *NewOrderSingleService {
+	s.triggerPrice = &price
+	s.triggerDirection = direction
+	return s
+}
+
+// TriggerTrailingDeltaBps makes a STOP or STOP_LIMIT order a trailing stop,
+// following the price by deltaBps basis points in direction. It starts
+// trailing right away, or once the price set with TriggerPrice is reached.
+func (s *NewOrderSingleService) TriggerTrailingDeltaBps(deltaBps int, direction TriggerDirection) *NewOrderSingleService {
+	s.trailingDelta = &deltaBps
+	s.triggerDirection = direction
+	return s
+}
 
-	msg.Body.Set(field.NewClOrdID(id.String()))
+// MaxFloor makes the order an iceberg order showing only maxFloor on the
+// book. Only good-till-cancel limit orders can be icebergs.
+func (s *NewOrderSingleService) MaxFloor(maxFloor float64) *NewOrderSingleService {
+	return s.MaxFloorDecimal(decimal.NewFromFloat(maxFloor))
+}
+
+// MaxFloorDecimal is MaxFloor with an exact quantity.
+func (s *NewOrderSingleService) MaxFloorDecimal(maxFloor decimal.Decimal) *NewOrderSingleService {
+	s.maxFloor = &maxFloor
+	return s
+}
+
+// minStrategyID is the lowest StrategyID<7940> accepted by the server.
+const minStrategyID = 1000000
+
+// StrategyID set the StrategyID<7940> the order is attributed to, at least
+// 1000000.
+func (s *NewOrderSingleService) StrategyID(strategyID int) *NewOrderSingleService {
+	s.strategyID = &strategyID
+	return s
+}
+
+// TargetStrategy set the TargetStrategy<847> of the order
+func (s *NewOrderSingleService) TargetStrategy(strategy int) *NewOrderSingleService {
+	s.strategy = &strategy
+	return s
+}
+
+// SelfTradePreventionMode set selfTradePreventionMode, overriding the one set
+// with WithSelfTradePreventionModeOpt.
+func (s *NewOrderSingleService) SelfTradePreventionMode(mode SelfTradePreventionMode) *NewOrderSingleService {
+	s.stpMode = mode
+	return s
+}
+
+// SOR routes the order through Binance smart order routing, which may fill
+// it on the books of interchangeable symbols.
+func (s *NewOrderSingleService) SOR(sor bool) *NewOrderSingleService {
+	s.sor = sor
+	return s
+}
+
+// TimeToLive cancels the order once ttl elapsed if it is still open,
+// emulating a good-till-date order. The deadline is kept in memory only.
+func (s *NewOrderSingleService) TimeToLive(ttl time.Duration) *NewOrderSingleService {
+	s.ttl = ttl
+	return s
+}
+
+// AckLatencyBudget fails Do with a *LatencyBudgetExceededError if the order
+// is not acknowledged within budget. With chaseCancel, a cancel is then sent
+// for the order in the background so that it cannot rest unnoticed.
+func (s *NewOrderSingleService) AckLatencyBudget(budget time.Duration, chaseCancel bool) *NewOrderSingleService {
+	s.ackBudget = budget
+	s.chaseCancel = chaseCancel
+	return s
+}
+
+// SetField sets any body tag, e.g. one newly added by Binance. It overrides
+// the value set by other builder methods for the same tag.
+func (s *NewOrderSingleService) SetField(t quickfix.Tag, value string) *NewOrderSingleService {
+	s.fields.set(t, value)
+	return s
+}
+
+// setOrderFields sets the body fields describing the order, which are shared
+// by NewOrderSingle<D> and OrderCancelRequestAndNewOrderSingle<XCN>.
+func (s *NewOrderSingleService) setOrderFields(msg *quickfix.Message) {
 	msg.Body.Set(field.NewSymbol(s.symbol))
 	msg.Body.Set(field.NewSide(s.side))
 	msg.Body.Set(field.NewOrdType(s.orderType))
 	if s.quantity != nil {
-		msg.Body.SetString(tag.OrderQty, floatToString(*s.quantity))
+		msg.Body.SetString(tag.OrderQty, s.quantity.String())
+	}
+	if s.cashQty != nil {
+		msg.Body.SetString(tag.CashOrderQty, s.cashQty.String())
 	}
 	if s.price != nil {
-		msg.Body.SetString(tag.Price, floatToString(*s.price))
+		msg.Body.SetString(tag.Price, s.price.String())
 	}
 	if s.timeInForce != nil {
 		msg.Body.Set(field.NewTimeInForce(*s.timeInForce))
 	}
+	if s.execInst != "" {
+		msg.Body.Set(field.NewExecInst(s.execInst))
+	}
+	if s.strategyID != nil {
+		msg.Body.SetInt(binancetag.StrategyID, *s.strategyID)
+	}
+	if s.strategy != nil {
+		msg.Body.SetInt(tag.TargetStrategy, *s.strategy)
+	}
+	stpMode := s.stpMode
+	if stpMode == "" {
+		stpMode = s.c.options.selfTradePreventionMode
+	}
+	if stpMode != "" {
+		msg.Body.SetString(binancetag.SelfTradePreventionMode, string(stpMode))
+	}
+	if s.triggerPrice != nil || s.trailingDelta != nil {
+		setPriceTrigger(&msg.Body.FieldMap, s.triggerPrice, s.triggerDirection)
+	}
+	if s.trailingDelta != nil {
+		msg.Body.SetInt(binancetag.TriggerTrailingDeltaBps, *s.trailingDelta)
+	}
+	if s.sor {
+		msg.Body.SetBool(binancetag.SOR, true)
+	}
+	if s.maxFloor != nil {
+		msg.Body.SetString(tag.MaxFloor, s.maxFloor.String())
+	}
+	s.fields.apply(msg)
+}
 
-	resp, err := s.c.Call(ctx, id.String(), msg)
+// clientOrderID returns the ClOrdID set by the caller, or a random one.
+func (s *NewOrderSingleService) clientOrderID() (string, error) {
+	if s.clOrdID != "" {
+		return s.clOrdID, nil
+	}
+	id, err := uuid.NewRandom()
 	if err != nil {
-		zap.S().Errorw("Failed to create new order", "request", msg, "err", err)
+		return "", err
+	}
+	return id.String(), nil
+}
+
+/*
+Fields required by each OrdType<40>, checked before sending:
+
+OrdType     OrderQty    Price   TimeInForce     Trigger
+MARKET      Y or 152    -       -               -
+LIMIT       Y           Y       Y (- if maker)  -
+STOP        Y           -       -               Y
+STOP_LIMIT  Y           Y       Y               Y
+
+Trigger is a TriggerPrice<1102> and/or a TriggerTrailingDeltaBps<25009>,
+with a TriggerPriceDirection<1109>.
+*/
+
+// validate checks the order before anything is sent, so that an order the
+// server would reject does not use the order rate limit.
+func (s *NewOrderSingleService) validate() error {
+	if s.symbol == "" {
+		return invalidOrder("Symbol is required")
+	}
+	if s.side == "" {
+		return invalidOrder("Side is required")
+	}
+
+	limit := s.orderType == enum.OrdType_LIMIT || s.orderType == enum.OrdType_STOP_LIMIT
+	stop := s.orderType == enum.OrdType_STOP || s.orderType == enum.OrdType_STOP_LIMIT
+	maker := s.execInst == enum.ExecInst_PARTICIPANT_DONT_INITIATE
+	triggered := s.triggerPrice != nil || s.trailingDelta != nil
+	name := mappedOrderType[s.orderType]
+	switch {
+	case name == "":
+		return invalidOrder("unsupported OrdType %q", s.orderType)
+	case s.quantity == nil && s.cashQty == nil:
+		return invalidOrder("%s order needs a Quantity", name)
+	case s.quantity != nil && !s.quantity.IsPositive():
+		return invalidOrder("Quantity must be positive")
+	case s.cashQty != nil && !s.cashQty.IsPositive():
+		return invalidOrder("CashOrderQty must be positive")
+	case limit && s.price == nil:
+		return invalidOrder("%s order needs a Price", name)
+	case !limit && s.price != nil:
+		return invalidOrder("Price needs a LIMIT or STOP_LIMIT order")
+	case s.price != nil && !s.price.IsPositive():
+		return invalidOrder("Price must be positive")
+	case limit && !maker && s.timeInForce == nil:
+		return invalidOrder("%s order needs a TimeInForce", name)
+	case (!limit || maker) && s.timeInForce != nil:
+		return invalidOrder("TimeInForce needs a LIMIT or STOP_LIMIT order which is not post-only")
+	case maker && s.orderType != enum.OrdType_LIMIT:
+		return invalidOrder("post-only needs a LIMIT order")
+	case stop && !triggered:
+		return invalidOrder("%s order needs a TriggerPrice or a TriggerTrailingDeltaBps", name)
+	case !stop && triggered:
+		return invalidOrder("TriggerPrice and TriggerTrailingDeltaBps need a STOP or STOP_LIMIT order")
+	case triggered && s.triggerDirection != TriggerDirectionUp && s.triggerDirection != TriggerDirectionDown:
+		return invalidOrder("trigger needs an up or down direction")
+	case s.trailingDelta != nil && *s.trailingDelta <= 0:
+		return invalidOrder("TriggerTrailingDeltaBps must be positive")
+	}
+
+	if s.cashQty != nil {
+		if s.orderType != enum.OrdType_MARKET {
+			return invalidOrder("CashOrderQty needs a MARKET order")
+		}
+		if s.quantity != nil {
+			return invalidOrder("CashOrderQty and Quantity cannot be combined")
+		}
+	}
+	if s.maxFloor != nil {
+		if s.orderType != enum.OrdType_LIMIT ||
+			s.timeInForce == nil || *s.timeInForce != enum.TimeInForce_GOOD_TILL_CANCEL {
+			return invalidOrder("MaxFloor needs a LIMIT order with GOOD_TILL_CANCEL time in force")
+		}
+		if s.quantity != nil && s.maxFloor.GreaterThanOrEqual(*s.quantity) {
+			return invalidOrder("MaxFloor must be less than the order quantity")
+		}
+	}
+	if s.strategyID != nil && *s.strategyID < minStrategyID {
+		return invalidOrder("StrategyID cannot be less than %d", minStrategyID)
+	}
+	return nil
+}
+
+// invalidOrder returns an error wrapping ErrInvalidOrder.
+func invalidOrder(format string, args ...any) error {
+	return fmt.Errorf("%w: "+format, append([]any{ErrInvalidOrder}, args...)...)
+}
+
+func (s *NewOrderSingleService) Do(ctx context.Context) (Order, error) {
+	if err := s.validate(); err != nil {
 		return Order{}, err
 	}
 
-	order, err := decodeExecutionReport(resp)
+	id, err := s.clientOrderID()
 	if err != nil {
-		zap.S().Errorw("Failed to decode ExecutionReport message", "request", msg, "response", resp, "error", err)
 		return Order{}, err
 	}
 
+	msg := quickfix.NewMessage()
+	msg.Header.Set(field.NewMsgType(enum.MsgType_ORDER_SINGLE))
+
+	msg.Body.Set(field.NewClOrdID(id))
+	s.setOrderFields(msg)
+
+	l := s.c.logger(ctx)
+	callCtx, cancel, budgetExceeded := withAckBudget(ctx, s.ackBudget)
+	defer cancel()
+	resp, err := s.c.Call(callCtx, id, msg)
+	if err != nil {
+		if e, ok := budgetExceeded(err); ok {
+			e.ClOrdID = id
+			if s.chaseCancel {
+				s.c.chaseCancel(s.symbol, e.ClOrdID, "ack latency budget exceeded")
+				e.CancelSent = true
+			}
+			err = e
+		}
+		l.Errorw("Failed to create new order", "request", msg, "err", err)
+		return Order{}, err
+	}
+
+	order, err := decodeExecutionReport(resp)
+	if err != nil {
+		l.Errorw("Failed to decode ExecutionReport message", "request", msg, "response", resp, "error", err)
+		return order, s.c.escalateDecode("decode execution report", newMessageError(err, resp))
+	}
+	s.c.attachRaw(&order, resp)
+	order.Metadata = MetadataFromContext(ctx)
+	if s.ttl > 0 && !order.Status.IsTerminal() {
+		s.c.expireAfter(s.symbol, order.ClientOrderID, s.ttl)
+	}
+
 	return order, nil
 }
+
+// DoAndAwaitFinal places the order like Do, then waits until it reaches a
+// terminal state or ctx is done. It returns the latest state of the order
+// and every execution report received for it, fills included, in order.
+func (s *NewOrderSingleService) DoAndAwaitFinal(ctx context.Context) (Order, []Order, error) {
+	id, err := s.clientOrderID()
+	if err != nil {
+		return Order{}, nil, err
+	}
+	prev := s.clOrdID
+	s.clOrdID = id
+	defer func() { s.clOrdID = prev }()
+
+	// Watch before sending so no execution report can be missed, nor dropped
+	// from the history.
+	w := s.c.watchers.addWatch(id, 0)
+	defer s.c.watchers.remove(w)
+
+	order, err := s.Do(ctx)
+	if err != nil {
+		return order, nil, err
+	}
+
+	var history []Order
+	for !order.Status.IsTerminal() {
+		o, err := w.next(ctx)
+		if err != nil {
+			return order, history, err
+		}
+		history = append(history, o)
+		order = o
+	}
+	// The ack may have been terminal already, with its report still queued.
+	for {
+		o, ok := w.poll()
+		if !ok {
+			return order, history, nil
+		}
+		history = append(history, o)
+	}
+}
+
+// DoAsync sends the request like Do, without waiting for the response.
+func (s *NewOrderSingleService) DoAsync(ctx context.Context) *Future[Order] {
+	return doAsync(ctx, s.Do)
+}
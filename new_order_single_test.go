@@ -0,0 +1,193 @@
+package fix
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/KyberNetwork/binance_fix_api/binancetag"
+	"github.com/quickfixgo/enum"
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/tag"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateMaxFloor(t *testing.T) {
+	c := &Client{}
+	iceberg := func() *NewOrderSingleService {
+		return c.NewOrderSingleService().Symbol("BTCUSDT").Side(enum.Side_BUY).Quantity(1).MaxFloor(0.1)
+	}
+
+	assert.NoError(t, iceberg().Type(enum.OrdType_LIMIT).Price(1).TimeInForce(enum.TimeInForce_GOOD_TILL_CANCEL).validate())
+	assert.Error(t, iceberg().Type(enum.OrdType_LIMIT).Price(1).TimeInForce(enum.TimeInForce_IMMEDIATE_OR_CANCEL).validate())
+	assert.Error(t, iceberg().Type(enum.OrdType_MARKET).validate())
+	assert.Error(t, iceberg().Type(enum.OrdType_LIMIT).Price(1).TimeInForce(enum.TimeInForce_GOOD_TILL_CANCEL).Quantity(0.1).validate())
+}
+
+func TestValidateCashOrderQty(t *testing.T) {
+	c := &Client{}
+	quote := func() *NewOrderSingleService {
+		return c.NewOrderSingleService().Symbol("BTCUSDT").Side(enum.Side_BUY).CashOrderQty(100)
+	}
+
+	assert.NoError(t, quote().Type(enum.OrdType_MARKET).validate())
+	assert.Error(t, quote().Type(enum.OrdType_LIMIT).Price(1).validate())
+	assert.Error(t, quote().Type(enum.OrdType_MARKET).Quantity(1).validate())
+	assert.ErrorIs(t, quote().Type(enum.OrdType_MARKET).CashOrderQty(0).validate(), ErrInvalidOrder)
+	assert.ErrorIs(t, quote().Type(enum.OrdType_MARKET).CashOrderQty(-100).validate(), ErrInvalidOrder)
+}
+
+func TestValidateStrategyID(t *testing.T) {
+	c := &Client{}
+	order := func() *NewOrderSingleService {
+		return c.NewOrderSingleService().Symbol("BTCUSDT").Side(enum.Side_BUY).Type(enum.OrdType_MARKET).Quantity(1)
+	}
+
+	assert.NoError(t, order().StrategyID(minStrategyID).TargetStrategy(1).validate())
+	assert.Error(t, order().StrategyID(minStrategyID-1).validate())
+}
+
+func TestSelfTradePreventionMode(t *testing.T) {
+	c := &Client{}
+	WithSelfTradePreventionModeOpt(SelfTradePreventionModeExpireMaker)(&c.options)
+
+	stpMode := func(s *NewOrderSingleService) string {
+		msg := quickfix.NewMessage()
+		s.setOrderFields(msg)
+		mode, err := msg.Body.GetString(binancetag.SelfTradePreventionMode)
+		require.NoError(t, err)
+		return mode
+	}
+
+	assert.Equal(t, "3", stpMode(c.NewOrderSingleService()))
+	assert.Equal(t, "4", stpMode(c.NewOrderSingleService().SelfTradePreventionMode(SelfTradePreventionModeExpireBoth)))
+}
+
+func TestValidateTrigger(t *testing.T) {
+	c := &Client{}
+	sell := func(orderType enum.OrdType) *NewOrderSingleService {
+		return c.NewOrderSingleService().Symbol("BTCUSDT").Side(enum.Side_SELL).Type(orderType).Quantity(1)
+	}
+
+	assert.NoError(t, sell(enum.OrdType_STOP).TriggerPrice(90, TriggerDirectionDown).validate())
+	assert.NoError(t, sell(enum.OrdType_STOP_LIMIT).TriggerPrice(90, TriggerDirectionDown).
+		Price(89).TimeInForce(enum.TimeInForce_GOOD_TILL_CANCEL).validate())
+	assert.Error(t, sell(enum.OrdType_STOP).validate())
+	assert.Error(t, sell(enum.OrdType_STOP).TriggerPrice(90, "").validate())
+	assert.Error(t, sell(enum.OrdType_STOP_LIMIT).TriggerPrice(90, TriggerDirectionDown).validate())
+	assert.Error(t, sell(enum.OrdType_MARKET).TriggerPrice(90, TriggerDirectionDown).validate())
+}
+
+func TestTrailingStop(t *testing.T) {
+	c := &Client{}
+	trailing := c.NewOrderSingleService().Symbol("BTCUSDT").Side(enum.Side_SELL).Type(enum.OrdType_STOP).Quantity(1).
+		TriggerTrailingDeltaBps(100, TriggerDirectionDown)
+	require.NoError(t, trailing.validate())
+
+	msg := quickfix.NewMessage()
+	trailing.setOrderFields(msg)
+	assert.False(t, msg.Body.Has(tag.TriggerPrice))
+	delta, err := msg.Body.GetInt(binancetag.TriggerTrailingDeltaBps)
+	require.NoError(t, err)
+	assert.Equal(t, 100, delta)
+
+	assert.Error(t, c.NewOrderSingleService().Type(enum.OrdType_LIMIT).TriggerTrailingDeltaBps(100, TriggerDirectionDown).validate())
+	assert.Error(t, c.NewOrderSingleService().Type(enum.OrdType_STOP).TriggerTrailingDeltaBps(0, TriggerDirectionDown).validate())
+}
+
+func TestDecimalAmounts(t *testing.T) {
+	c := &Client{}
+	order := c.NewOrderSingleService().Symbol("SHIBUSDT").Side(enum.Side_BUY).Type(enum.OrdType_LIMIT).
+		TimeInForce(enum.TimeInForce_GOOD_TILL_CANCEL).
+		QuantityDecimal(decimal.RequireFromString("123456789012345.12345678")).
+		PriceDecimal(decimal.RequireFromString("0.00001234"))
+	require.NoError(t, order.validate())
+
+	msg := quickfix.NewMessage()
+	order.setOrderFields(msg)
+	qty, err := msg.Body.GetString(tag.OrderQty)
+	require.NoError(t, err)
+	assert.Equal(t, "123456789012345.12345678", qty)
+	price, err := msg.Body.GetString(tag.Price)
+	require.NoError(t, err)
+	assert.Equal(t, "0.00001234", price)
+
+	assert.Error(t, order.PriceDecimal(decimal.Zero).validate())
+}
+
+func TestValidateOrderMatrix(t *testing.T) {
+	c := &Client{}
+	buy := func(orderType enum.OrdType) *NewOrderSingleService {
+		return c.NewOrderSingleService().Symbol("BTCUSDT").Side(enum.Side_BUY).Type(orderType).Quantity(1)
+	}
+	gtc := enum.TimeInForce_GOOD_TILL_CANCEL
+
+	tests := []struct {
+		name  string
+		order *NewOrderSingleService
+		valid bool
+	}{
+		{"market", buy(enum.OrdType_MARKET), true},
+		{"market with price", buy(enum.OrdType_MARKET).Price(1), false},
+		{"market with time in force", buy(enum.OrdType_MARKET).TimeInForce(gtc), false},
+		{"limit", buy(enum.OrdType_LIMIT).Price(1).TimeInForce(gtc), true},
+		{"limit without price", buy(enum.OrdType_LIMIT).TimeInForce(gtc), false},
+		{"limit without time in force", buy(enum.OrdType_LIMIT).Price(1), false},
+		{"limit maker", buy(enum.OrdType_LIMIT).Price(1).ExecInst(enum.ExecInst_PARTICIPANT_DONT_INITIATE), true},
+		{"limit maker with time in force", buy(enum.OrdType_LIMIT).Price(1).TimeInForce(gtc).
+			ExecInst(enum.ExecInst_PARTICIPANT_DONT_INITIATE), false},
+		{"no quantity", c.NewOrderSingleService().Symbol("BTCUSDT").Side(enum.Side_BUY).Type(enum.OrdType_MARKET), false},
+		{"no symbol", c.NewOrderSingleService().Side(enum.Side_BUY).Type(enum.OrdType_MARKET).Quantity(1), false},
+		{"no side", c.NewOrderSingleService().Symbol("BTCUSDT").Type(enum.OrdType_MARKET).Quantity(1), false},
+		{"no type", c.NewOrderSingleService().Symbol("BTCUSDT").Side(enum.Side_BUY).Quantity(1), false},
+	}
+	for _, tt := range tests {
+		err := tt.order.validate()
+		if tt.valid {
+			assert.NoError(t, err, tt.name)
+		} else {
+			assert.ErrorIs(t, err, ErrInvalidOrder, tt.name)
+		}
+	}
+}
+
+func TestDoAndAwaitFinal(t *testing.T) {
+	const fills = 2 * orderWatchBuffer
+	var c *Client
+	c = NewWithCaller(CallerFunc(func(_ context.Context, id string, _ *quickfix.Message) (*quickfix.Message, error) {
+		// Every fill is reported before the ack is processed.
+		for i := 1; i <= fills; i++ {
+			c.deliverOrder(Order{ClientOrderID: id, Status: OrderStatusPartiallyFilled, CumQty: float64(i)})
+		}
+		c.deliverOrder(Order{ClientOrderID: id, Status: OrderStatusFilled, CumQty: fills + 1})
+		return newTestReport(id, enum.OrdStatus_NEW), nil
+	}))
+
+	order, history, err := c.NewOrderSingleService().Symbol("BTCUSDT").Side(enum.Side_BUY).
+		Type(enum.OrdType_MARKET).Quantity(fills + 1).DoAndAwaitFinal(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, OrderStatusFilled, order.Status)
+	// The fills, then the ack.
+	require.Len(t, history, fills+2)
+	for i := 0; i < fills; i++ {
+		assert.Equal(t, float64(i+1), history[i].CumQty)
+	}
+	assert.Equal(t, OrderStatusFilled, history[fills].Status)
+}
+
+func TestDoAndAwaitFinalTimeout(t *testing.T) {
+	c := NewWithCaller(CallerFunc(func(_ context.Context, id string, _ *quickfix.Message) (*quickfix.Message, error) {
+		return newTestReport(id, enum.OrdStatus_NEW), nil
+	}))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	order, history, err := c.NewOrderSingleService().ClOrdID("a").Symbol("BTCUSDT").Side(enum.Side_BUY).
+		Type(enum.OrdType_MARKET).Quantity(1).DoAndAwaitFinal(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, OrderStatusNew, order.Status)
+	require.Len(t, history, 1)
+	assert.Equal(t, "a", history[0].ClientOrderID)
+}
@@ -0,0 +1,99 @@
+package fix
+
+import (
+	"bytes"
+	"errors"
+
+	"github.com/KyberNetwork/binance_fix_api/binancetag"
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/tag"
+	"github.com/shopspring/decimal"
+)
+
+// ExactAmounts holds the amounts of an Order exactly as sent by the server,
+// which the float64 fields of Order may only approximate.
+type ExactAmounts struct {
+	Price           decimal.Decimal
+	OrderQty        decimal.Decimal
+	CashOrderQty    decimal.Decimal
+	CumQty          decimal.Decimal
+	CumQuoteQty     decimal.Decimal
+	LastPx          decimal.Decimal
+	LastQty         decimal.Decimal
+	IcebergQuantity decimal.Decimal
+	TriggerPrice    decimal.Decimal
+}
+
+func decodeExactAmounts(msg *quickfix.Message) (*ExactAmounts, error) {
+	var a ExactAmounts
+	for t, v := range map[quickfix.Tag]*decimal.Decimal{
+		tag.Price:              &a.Price,
+		tag.OrderQty:           &a.OrderQty,
+		tag.CashOrderQty:       &a.CashOrderQty,
+		tag.CumQty:             &a.CumQty,
+		binancetag.CumQuoteQty: &a.CumQuoteQty,
+		tag.LastPx:             &a.LastPx,
+		tag.LastQty:            &a.LastQty,
+		tag.MaxFloor:           &a.IcebergQuantity,
+		tag.TriggerPrice:       &a.TriggerPrice,
+	} {
+		d, err := binancetag.GetDecimal(msg, t)
+		if err != nil {
+			return nil, err
+		}
+		*v = d
+	}
+	return &a, nil
+}
+
+// RawMessage parses Raw back into a message, e.g. to read a tag Order does
+// not map yet.
+func (o Order) RawMessage() (*quickfix.Message, error) {
+	if o.Raw == "" {
+		return nil, errors.New("order was not decoded from a message")
+	}
+	msg := quickfix.NewMessage()
+	if err := quickfix.ParseMessage(msg, bytes.NewBufferString(o.Raw)); err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
+
+// attachRaw sets order.Raw to msg if WithRawMessageOpt is set.
+func (c *Client) attachRaw(order *Order, msg *quickfix.Message) {
+	if c.options.rawMessages {
+		order.Raw = msg.String()
+	}
+}
+
+// RemainingQty returns the quantity left to fill, never negative.
+func (o Order) RemainingQty() float64 {
+	remaining := decimal.NewFromFloat(o.OrderQty).Sub(decimal.NewFromFloat(o.CumQty))
+	if remaining.IsNegative() {
+		return 0
+	}
+	return remaining.InexactFloat64()
+}
+
+// FilledRatio returns the filled fraction of the order quantity, from 0 to 1.
+func (o Order) FilledRatio() float64 {
+	if o.OrderQty == 0 {
+		return 0
+	}
+	return decimal.NewFromFloat(o.CumQty).Div(decimal.NewFromFloat(o.OrderQty)).InexactFloat64()
+}
+
+// LastQuoteQty returns the quote quantity of the fill reported, 0 if the
+// report is not a fill.
+func (o Order) LastQuoteQty() float64 {
+	return decimal.NewFromFloat(o.LastPx).Mul(decimal.NewFromFloat(o.LastQty)).InexactFloat64()
+}
+
+// AvgFillPrice returns the average price of the fills so far, 0 if nothing
+// was filled.
+func (o Order) AvgFillPrice() float64 {
+	if o.CumQty == 0 {
+		return 0
+	}
+	return decimal.NewFromFloat(o.CumQuoteQty).Div(decimal.NewFromFloat(o.CumQty)).InexactFloat64()
+}
@@ -0,0 +1,96 @@
+package fix
+
+import (
+	"context"
+	"time"
+
+	"github.com/chuckpreslar/emission"
+	"github.com/quickfixgo/quickfix"
+	"go.uber.org/zap"
+)
+
+//go:generate moq -out mocks/order_entry_client.go -pkg mocks . OrderEntryClient
+
+// OrderEntryClient is the public surface of Client: lifecycle, services and
+// subscriptions. Depend on it instead of *Client to unit-test order flow with
+// the mock in package mocks, returning the services of a client created by
+// NewWithCaller so that their Do reaches a fake exchange.
+type OrderEntryClient interface {
+	Start(ctx context.Context) error
+	Stop()
+	Shutdown(ctx context.Context) error
+	Logout(ctx context.Context, text string) error
+	IsConnected() bool
+	StateChanges() <-chan ConnState
+	Ping(ctx context.Context) (time.Duration, error)
+
+	Call(ctx context.Context, id string, msg *quickfix.Message) (*quickfix.Message, error)
+	NewOrderSingleService() *NewOrderSingleService
+	NewOrderCancelService() *OrderCancelService
+	NewCancelReplaceService() *CancelReplaceService
+	NewMassCancelService() *MassCancelService
+	NewAmendOrderService() *AmendOrderService
+	NewOrderListService() *NewOrderListService
+	NewGetLimitService() *LimitService
+	CancelAllOpenOrders(ctx context.Context, symbol string) (CancelAllResult, error)
+
+	SubscribeToExecutionReport(listener ExecutionReportHandler)
+	SubscribeToNews(listener NewsHandler)
+	SubscribeToListStatus(listener ListStatusHandler)
+	WaitForOrder(ctx context.Context, clOrdID string) (Order, error)
+	OrderWatcher(ctx context.Context, clOrdID string) <-chan Order
+
+	Tracker() *OrderTracker
+	Stats() Stats
+	RemainingOrderBudget() (int, bool)
+	RemainingMessageBudget() (int, bool)
+}
+
+var _ OrderEntryClient = (*Client)(nil)
+
+// Caller sends a request and returns its response, see Client.Call.
+type Caller interface {
+	Call(ctx context.Context, id string, msg *quickfix.Message) (*quickfix.Message, error)
+}
+
+// CallerFunc adapts a function to Caller.
+type CallerFunc func(ctx context.Context, id string, msg *quickfix.Message) (*quickfix.Message, error)
+
+func (f CallerFunc) Call(ctx context.Context, id string, msg *quickfix.Message) (*quickfix.Message, error) {
+	return f(ctx, id, msg)
+}
+
+// NewWithCaller creates a client without a FIX session which hands every
+// request to caller, e.g. a fake exchange, and processes the responses like
+// those of a session: the services, the tracker and the subscriptions work as
+// usual. Return its services from the mock of package mocks to unit-test
+// order flow.
+func NewWithCaller(caller Caller, opts ...NewClientOption) *Client {
+	options := defaultOpts()
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	return &Client{
+		l:            zap.NewNop().Sugar(),
+		pending:      make(map[string]*call),
+		emitter:      emission.NewEmitter(),
+		loggedOut:    make(chan struct{}),
+		testRequests: make(map[string]chan struct{}),
+		tracker:      NewOrderTracker(),
+		caller:       caller,
+		options:      options,
+	}
+}
+
+// callCaller hands msg to the caller of a client created by NewWithCaller.
+func (c *Client) callCaller(ctx context.Context, id string, msg *quickfix.Message) (*quickfix.Message, error) {
+	resp, err := c.caller.Call(ctx, id, msg)
+	if err != nil {
+		return nil, err
+	}
+	if msgType, err := resp.MsgType(); err == nil {
+		c.handleSubscriptions(msgType, resp)
+	}
+	return resp, nil
+}
@@ -0,0 +1,76 @@
+package fix_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/quickfixgo/enum"
+	"github.com/quickfixgo/field"
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/tag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	fix "github.com/KyberNetwork/binance_fix_api"
+	"github.com/KyberNetwork/binance_fix_api/mocks"
+)
+
+// fakeExchange acknowledges every NewOrderSingle with a NEW execution report.
+func fakeExchange(t *testing.T) fix.CallerFunc {
+	return func(_ context.Context, id string, msg *quickfix.Message) (*quickfix.Message, error) {
+		msgType, err := msg.MsgType()
+		require.NoError(t, err)
+		require.Equal(t, string(enum.MsgType_ORDER_SINGLE), msgType)
+
+		symbol, err := msg.Body.GetString(tag.Symbol)
+		require.NoError(t, err)
+
+		resp := quickfix.NewMessage()
+		resp.Header.SetString(tag.BeginString, quickfix.BeginStringFIX44)
+		resp.Header.Set(field.NewMsgType(enum.MsgType_EXECUTION_REPORT))
+		resp.Body.SetString(tag.ClOrdID, id)
+		resp.Body.SetString(tag.OrderID, "1")
+		resp.Body.SetString(tag.Symbol, symbol)
+		resp.Body.SetString(tag.Side, string(enum.Side_BUY))
+		resp.Body.SetString(tag.OrdType, string(enum.OrdType_LIMIT))
+		resp.Body.SetString(tag.ExecType, string(enum.ExecType_NEW))
+		resp.Body.SetString(tag.OrdStatus, string(enum.OrdStatus_NEW))
+		return resp, nil
+	}
+}
+
+// placeOrder is order flow depending on OrderEntryClient only.
+func placeOrder(ctx context.Context, c fix.OrderEntryClient) (fix.Order, error) {
+	return c.NewOrderSingleService().
+		ClOrdID("a").
+		Symbol("BTCUSDT").
+		Side(enum.Side_BUY).
+		Type(enum.OrdType_LIMIT).
+		TimeInForce(enum.TimeInForce_GOOD_TILL_CANCEL).
+		Quantity(1).
+		Price(100).
+		Do(ctx)
+}
+
+func TestOrderFlowOnMock(t *testing.T) {
+	c := fix.NewWithCaller(fakeExchange(t))
+	var reports []fix.Order
+	c.SubscribeToExecutionReport(func(o *fix.Order) { reports = append(reports, *o) })
+
+	mock := &mocks.OrderEntryClientMock{
+		NewOrderSingleServiceFunc: c.NewOrderSingleService,
+		TrackerFunc:               c.Tracker,
+	}
+
+	order, err := placeOrder(context.Background(), mock)
+	require.NoError(t, err)
+	assert.Equal(t, "a", order.ClientOrderID)
+	assert.Equal(t, fix.OrderStatusNew, order.Status)
+	assert.Len(t, mock.NewOrderSingleServiceCalls(), 1)
+
+	tracked, ok := mock.Tracker().Order("a")
+	require.True(t, ok)
+	assert.Equal(t, fix.OrderStatusNew, tracked.Status)
+	require.Len(t, reports, 1)
+	assert.Equal(t, "a", reports[0].ClientOrderID)
+}
@@ -0,0 +1,593 @@
+package fix
+
+import (
+	"context"
+	"time"
+
+	"github.com/KyberNetwork/binance_fix_api/binancetag"
+	"github.com/google/uuid"
+	"github.com/quickfixgo/enum"
+	"github.com/quickfixgo/field"
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/tag"
+	"github.com/shopspring/decimal"
+)
+
+/*
+Tag     Name                            Type            Required    Description
+25014   ClListID                        STRING          Y           ClListID to be assigned to the order list.
+1385    ContingencyType                 INT             N           1: ONE_CANCELS_THE_OTHER, 2: ONE_TRIGGERS_THE_OTHER
+73      NoOrders                        NUM_IN_GROUP    N           The length of the array for Orders.
+» 11    ClOrdID                         STRING          Y
+» 55    Symbol                          STRING          Y
+» 54    Side                            CHAR            Y
+» 40    OrdType                         CHAR            Y
+» 18    ExecInst                        CHAR            N           6: PARTICIPATE_DONT_INITIATE
+» 38    OrderQty                        QTY             N
+» 44    Price                           PRICE           N
+» 59    TimeInForce                     CHAR            N
+» 1100  TriggerType                     CHAR            N           4: PRICE_MOVEMENT
+» 1101  TriggerAction                   CHAR            N           1: ACTIVATE
+» 1102  TriggerPrice                    PRICE           N
+» 1107  TriggerPriceType                CHAR            N           2: LAST_TRADE
+» 1109  TriggerPriceDirection           CHAR            N           U or D
+» 25010 NoListTriggeringInstructions    NUM_IN_GROUP    N
+»» 25011 ListTriggerType                CHAR            N           1: ACTIVATED, 2: PARTIALLY_FILLED, 3: FILLED
+»» 25012 ListTriggerTriggerIndex        INT             N           Index of the order triggering this one.
+»» 25013 ListTriggerAction              CHAR            N           1: RELEASE, 2: CANCEL
+*/
+
+// ListTriggerType<25011> values.
+const (
+	listTriggerTypeActivated       = "1"
+	listTriggerTypePartiallyFilled = "2"
+	listTriggerTypeFilled          = "3"
+)
+
+// ListTriggerAction<25013> values.
+const (
+	listTriggerActionRelease = "1"
+	listTriggerActionCancel  = "2"
+)
+
+// Trigger values of contingent orders.
+const (
+	triggerTypePriceMovement  = "4"
+	triggerActionActivate     = "1"
+	triggerPriceTypeLastTrade = "2"
+)
+
+// setPriceTrigger sets the tags activating a contingent order once the last
+// trade price reaches price, moving in direction. Price is nil for trailing
+// orders activated right away.
+func setPriceTrigger(fm *quickfix.FieldMap, price *decimal.Decimal, direction TriggerDirection) {
+	fm.SetString(tag.TriggerType, triggerTypePriceMovement)
+	fm.SetString(tag.TriggerAction, triggerActionActivate)
+	if price != nil {
+		fm.SetString(tag.TriggerPrice, price.String())
+	}
+	fm.SetString(tag.TriggerPriceType, triggerPriceTypeLastTrade)
+	fm.SetString(tag.TriggerPriceDirection, string(direction))
+}
+
+// TriggerDirection is the TriggerPriceDirection<1109> of a contingent order:
+// whether it activates when the price moves up to or down to its trigger
+// price.
+type TriggerDirection string
+
+const (
+	TriggerDirectionUp   TriggerDirection = "U"
+	TriggerDirectionDown TriggerDirection = "D"
+)
+
+// execInstParticipateDontInitiate<18> makes a limit order a LIMIT_MAKER.
+const execInstParticipateDontInitiate = "6"
+
+// listTrigger is an entry of the NoListTriggeringInstructions<25010> group.
+type listTrigger struct {
+	triggerType  string
+	triggerIndex int
+	action       string
+}
+
+// listOrder is an entry of the NoOrders<73> group of NewOrderList<E>.
+type listOrder struct {
+	clOrdID          string
+	side             enum.Side
+	orderType        enum.OrdType
+	execInst         string
+	quantity         decimal.Decimal
+	price            *decimal.Decimal
+	timeInForce      *enum.TimeInForce
+	triggerPrice     *decimal.Decimal
+	triggerDirection TriggerDirection
+	triggers         []listTrigger
+}
+
+func newListOrderGroup() *quickfix.RepeatingGroup {
+	return quickfix.NewRepeatingGroup(tag.NoOrders, quickfix.GroupTemplate{
+		quickfix.GroupElement(tag.ClOrdID),
+		quickfix.GroupElement(tag.Symbol),
+		quickfix.GroupElement(tag.Side),
+		quickfix.GroupElement(tag.OrdType),
+		quickfix.GroupElement(tag.ExecInst),
+		quickfix.GroupElement(tag.OrderQty),
+		quickfix.GroupElement(tag.Price),
+		quickfix.GroupElement(tag.TimeInForce),
+		quickfix.GroupElement(tag.TriggerType),
+		quickfix.GroupElement(tag.TriggerAction),
+		quickfix.GroupElement(tag.TriggerPrice),
+		quickfix.GroupElement(tag.TriggerPriceType),
+		quickfix.GroupElement(tag.TriggerPriceDirection),
+		newListTriggerGroup(),
+	})
+}
+
+func newListTriggerGroup() *quickfix.RepeatingGroup {
+	return quickfix.NewRepeatingGroup(binancetag.NoListTriggeringInstructions, quickfix.GroupTemplate{
+		quickfix.GroupElement(binancetag.ListTriggerType),
+		quickfix.GroupElement(binancetag.ListTriggerTriggerIndex),
+		quickfix.GroupElement(binancetag.ListTriggerAction),
+	})
+}
+
+// newListStatusOrderGroup is the NoOrders<73> group of ListStatus<N>.
+func newListStatusOrderGroup() *quickfix.RepeatingGroup {
+	return quickfix.NewRepeatingGroup(tag.NoOrders, quickfix.GroupTemplate{
+		quickfix.GroupElement(tag.Symbol),
+		quickfix.GroupElement(tag.OrderID),
+		quickfix.GroupElement(tag.ClOrdID),
+	})
+}
+
+// OrderList is an order list as reported by the server.
+type OrderList struct {
+	Symbol          string
+	ListID          string
+	ClListID        string
+	ContingencyType enum.ContingencyType
+	ListStatusType  enum.ListStatusType
+	ListOrderStatus enum.ListOrderStatus
+	TransactTime    time.Time
+
+	// Set when the list is rejected.
+	ListRejectReason enum.ListRejectReason
+	ErrorCode        int
+	Text             string
+	// LegRejects holds why legs of the list were rejected by ClOrdID<11>,
+	// from the execution reports carrying the ListID<66> of the list.
+	LegRejects map[string]string `json:",omitempty"`
+
+	// Orders holds the orders of the list in list order, with the first
+	// execution report received for them, if any.
+	Orders []Order
+}
+
+// IsTerminal reports whether the list is done and no more ListStatus<N> is
+// expected for it.
+func (l OrderList) IsTerminal() bool {
+	return l.ListOrderStatus == enum.ListOrderStatus_ALL_DONE || l.ListOrderStatus == enum.ListOrderStatus_REJECT
+}
+
+// Working returns the working order of a one-triggers-the-other list.
+func (l OrderList) Working() (Order, bool) {
+	if l.ContingencyType != enum.ContingencyType_ONE_TRIGGERS_THE_OTHER || len(l.Orders) == 0 {
+		return Order{}, false
+	}
+	return l.Orders[0], true
+}
+
+// Pending returns the orders of a one-triggers-the-other list which are
+// released once the working order is filled.
+func (l OrderList) Pending() []Order {
+	if l.ContingencyType != enum.ContingencyType_ONE_TRIGGERS_THE_OTHER || len(l.Orders) == 0 {
+		return nil
+	}
+	return l.Orders[1:]
+}
+
+// ListLeg is an order of a list.
+type ListLeg struct {
+	ClOrdID     string // Generated if empty.
+	Side        enum.Side
+	Type        enum.OrdType
+	Quantity    decimal.Decimal
+	Price       decimal.Decimal  // Limit price, zero if none.
+	TimeInForce enum.TimeInForce // Unset if empty.
+	// LimitMaker rejects the order instead of letting it take liquidity.
+	LimitMaker bool
+	// TriggerPrice activates a STOP or STOP_LIMIT order when the last trade
+	// price crosses it in TriggerDirection.
+	TriggerPrice     decimal.Decimal
+	TriggerDirection TriggerDirection
+}
+
+func (leg ListLeg) listOrder() (listOrder, error) {
+	o := listOrder{
+		clOrdID:          leg.ClOrdID,
+		side:             leg.Side,
+		orderType:        leg.Type,
+		quantity:         leg.Quantity,
+		triggerDirection: leg.TriggerDirection,
+	}
+	if o.clOrdID == "" {
+		id, err := uuid.NewRandom()
+		if err != nil {
+			return listOrder{}, err
+		}
+		o.clOrdID = id.String()
+	}
+	if leg.Price.IsPositive() {
+		o.price = &leg.Price
+	}
+	if leg.TimeInForce != "" {
+		o.timeInForce = &leg.TimeInForce
+	}
+	if leg.LimitMaker {
+		o.execInst = execInstParticipateDontInitiate
+	}
+	if leg.TriggerPrice.IsPositive() {
+		o.triggerPrice = &leg.TriggerPrice
+	}
+	return o, nil
+}
+
+// NewOrderListService places a list of contingent orders with a
+// NewOrderList<E>.
+type NewOrderListService struct {
+	c           *Client
+	symbol      string
+	clListID    string
+	contingency enum.ContingencyType
+	legs        []ListLeg
+	fields      customFields
+}
+
+// NewOrderListService places a one-cancels-the-other (OCO) list unless
+// another ContingencyType is set, or working and pending legs are added.
+func (c *Client) NewOrderListService() *NewOrderListService {
+	return &NewOrderListService{c: c, contingency: enum.ContingencyType_ONE_CANCELS_THE_OTHER}
+}
+
+// Symbol set symbol of every order of the list
+func (s *NewOrderListService) Symbol(symbol string) *NewOrderListService {
+	s.symbol = symbol
+	return s
+}
+
+// ClListID set the ClListID of the list, a random one is generated otherwise
+func (s *NewOrderListService) ClListID(clListID string) *NewOrderListService {
+	s.clListID = clListID
+	return s
+}
+
+// ContingencyType set contingencyType
+func (s *NewOrderListService) ContingencyType(contingency enum.ContingencyType) *NewOrderListService {
+	s.contingency = contingency
+	return s
+}
+
+// Leg appends an order to the list
+func (s *NewOrderListService) Leg(leg ListLeg) *NewOrderListService {
+	s.legs = append(s.legs, leg)
+	return s
+}
+
+// LimitMakerLeg appends a LIMIT_MAKER order, the limit leg of an OCO
+func (s *NewOrderListService) LimitMakerLeg(side enum.Side, quantity, price float64) *NewOrderListService {
+	return s.Leg(ListLeg{
+		Side:       side,
+		Type:       enum.OrdType_LIMIT,
+		Quantity:   decimal.NewFromFloat(quantity),
+		Price:      decimal.NewFromFloat(price),
+		LimitMaker: true,
+	})
+}
+
+// StopLeg appends a stop-loss order, the stop leg of an OCO. It is a stop
+// market order if limitPrice is 0, a good-till-cancel stop limit order
+// otherwise.
+func (s *NewOrderListService) StopLeg(side enum.Side, quantity, stopPrice, limitPrice float64) *NewOrderListService {
+	leg := ListLeg{
+		Side:             side,
+		Type:             enum.OrdType_STOP,
+		Quantity:         decimal.NewFromFloat(quantity),
+		TriggerPrice:     decimal.NewFromFloat(stopPrice),
+		TriggerDirection: TriggerDirectionDown,
+	}
+	if side == enum.Side_BUY {
+		leg.TriggerDirection = TriggerDirectionUp
+	}
+	if limitPrice > 0 {
+		leg.Type = enum.OrdType_STOP_LIMIT
+		leg.Price = decimal.NewFromFloat(limitPrice)
+		leg.TimeInForce = enum.TimeInForce_GOOD_TILL_CANCEL
+	}
+	return s.Leg(leg)
+}
+
+// WorkingLeg makes the list one-triggers-the-other (OTO) with leg as its
+// working order, placed right away.
+func (s *NewOrderListService) WorkingLeg(leg ListLeg) *NewOrderListService {
+	s.contingency = enum.ContingencyType_ONE_TRIGGERS_THE_OTHER
+	s.legs = append([]ListLeg{leg}, s.legs...)
+	return s
+}
+
+// PendingLeg makes the list one-triggers-the-other (OTO) and appends leg as
+// a pending order, placed once the working order is filled. With two pending
+// legs, the list is an OTOCO: the pending orders cancel each other.
+func (s *NewOrderListService) PendingLeg(leg ListLeg) *NewOrderListService {
+	s.contingency = enum.ContingencyType_ONE_TRIGGERS_THE_OTHER
+	s.legs = append(s.legs, leg)
+	return s
+}
+
+// SetField sets any body tag, e.g. one newly added by Binance.
+func (s *NewOrderListService) SetField(t quickfix.Tag, value string) *NewOrderListService {
+	s.fields.set(t, value)
+	return s
+}
+
+// Do places the list. A rejected list is returned as an
+// *OrderListRejectedError.
+func (s *NewOrderListService) Do(ctx context.Context) (OrderList, error) {
+	if s.symbol == "" {
+		return OrderList{}, invalidOrder("Symbol is required")
+	}
+	if len(s.legs) < 2 || len(s.legs) > 3 {
+		return OrderList{}, invalidOrder("order list needs 2 or 3 legs, got %d", len(s.legs))
+	}
+
+	orders := make([]listOrder, 0, len(s.legs))
+	for _, leg := range s.legs {
+		o, err := leg.listOrder()
+		if err != nil {
+			return OrderList{}, err
+		}
+		orders = append(orders, o)
+	}
+	setListTriggers(s.contingency, orders)
+
+	return s.c.sendOrderList(ctx, s.clListID, s.symbol, s.contingency, orders, s.fields)
+}
+
+// DoAsync sends the request like Do, without waiting for the response.
+func (s *NewOrderListService) DoAsync(ctx context.Context) *Future[OrderList] {
+	return doAsync(ctx, s.Do)
+}
+
+// setListTriggers links the orders of a list according to its contingency:
+//   - OCO: either order being filled, even partially, cancels the other.
+//   - OTO: the first order is working, the others are pending and released
+//     once it is fully filled. Two pending orders form an OCO (OTOCO).
+func setListTriggers(contingency enum.ContingencyType, orders []listOrder) {
+	switch contingency {
+	case enum.ContingencyType_ONE_CANCELS_THE_OTHER:
+		if len(orders) != 2 {
+			return
+		}
+		for i := range orders {
+			orders[i].triggers = []listTrigger{
+				{triggerType: listTriggerTypePartiallyFilled, triggerIndex: 1 - i, action: listTriggerActionCancel},
+			}
+		}
+	case enum.ContingencyType_ONE_TRIGGERS_THE_OTHER:
+		for i := 1; i < len(orders); i++ {
+			orders[i].triggers = []listTrigger{
+				{triggerType: listTriggerTypeFilled, triggerIndex: 0, action: listTriggerActionRelease},
+			}
+		}
+		if len(orders) == 3 {
+			for i := 1; i < 3; i++ {
+				orders[i].triggers = append(orders[i].triggers, listTrigger{
+					triggerType: listTriggerTypePartiallyFilled, triggerIndex: 3 - i, action: listTriggerActionCancel,
+				})
+			}
+		}
+	}
+}
+
+// sendOrderList places orders as one NewOrderList<E> and returns the list
+// once the server answered with a ListStatus<N> and, unless the list was
+// rejected, an execution report for every order.
+func (c *Client) sendOrderList(
+	ctx context.Context, clListID, symbol string, contingency enum.ContingencyType, orders []listOrder, fields customFields,
+) (OrderList, error) {
+	if clListID == "" {
+		id, err := uuid.NewRandom()
+		if err != nil {
+			return OrderList{}, err
+		}
+		clListID = id.String()
+	}
+
+	msg := quickfix.NewMessage()
+	msg.Header.Set(field.NewMsgType(enum.MsgType_ORDER_LIST))
+	msg.Body.SetString(binancetag.ClListID, clListID)
+	msg.Body.Set(field.NewContingencyType(contingency))
+
+	group := newListOrderGroup()
+	for _, o := range orders {
+		g := group.Add()
+		g.Set(field.NewClOrdID(o.clOrdID))
+		g.Set(field.NewSymbol(symbol))
+		g.Set(field.NewSide(o.side))
+		g.Set(field.NewOrdType(o.orderType))
+		if o.execInst != "" {
+			g.SetString(tag.ExecInst, o.execInst)
+		}
+		g.SetString(tag.OrderQty, o.quantity.String())
+		if o.price != nil {
+			g.SetString(tag.Price, o.price.String())
+		}
+		if o.timeInForce != nil {
+			g.Set(field.NewTimeInForce(*o.timeInForce))
+		}
+		if o.triggerPrice != nil {
+			setPriceTrigger(&g.FieldMap, o.triggerPrice, o.triggerDirection)
+		}
+		if len(o.triggers) > 0 {
+			triggers := newListTriggerGroup()
+			for _, t := range o.triggers {
+				tg := triggers.Add()
+				tg.SetString(binancetag.ListTriggerType, t.triggerType)
+				tg.SetInt(binancetag.ListTriggerTriggerIndex, t.triggerIndex)
+				tg.SetString(binancetag.ListTriggerAction, t.action)
+			}
+			g.SetGroup(triggers)
+		}
+	}
+	msg.Body.SetGroup(group)
+	fields.apply(msg)
+
+	// Watch every order before sending so no execution report can be missed.
+	watches := make([]*orderWatch, len(orders))
+	for i, o := range orders {
+		watches[i] = c.watchers.add(o.clOrdID)
+	}
+	defer func() {
+		for _, w := range watches {
+			c.watchers.remove(w)
+		}
+	}()
+
+	l := c.logger(ctx)
+	resp, err := c.Call(ctx, clListID, msg)
+	if err != nil {
+		l.Errorw("Failed to place order list", "request", msg, "err", err)
+		return OrderList{}, err
+	}
+
+	list, err := decodeListStatus(resp)
+	if err != nil {
+		return OrderList{}, c.escalateDecode("decode list status", newMessageError(err, resp))
+	}
+	if list.IsTerminal() {
+		list.LegRejects = c.lists.legRejects(list.ListID, true)
+	}
+	if list.ListOrderStatus == enum.ListOrderStatus_REJECT {
+		return list, newMessageError(&OrderListRejectedError{
+			ClListID:         clListID,
+			ListRejectReason: list.ListRejectReason,
+			ErrorCode:        list.ErrorCode,
+			Text:             list.Text,
+			LegRejects:       list.LegRejects,
+		}, resp)
+	}
+
+	if len(list.Orders) == 0 {
+		for _, o := range orders {
+			list.Orders = append(list.Orders, Order{Symbol: symbol, ClientOrderID: o.clOrdID})
+		}
+	}
+	if c.options.responseMode == ResponseModeEverything {
+		for i, w := range watches {
+			if i >= len(list.Orders) {
+				break
+			}
+			if o, ok := c.tracker.Order(w.clOrdID); ok {
+				list.Orders[i] = o
+				continue
+			}
+			o, err := w.next(ctx)
+			if err != nil {
+				return list, err
+			}
+			list.Orders[i] = o
+		}
+	}
+	md := MetadataFromContext(ctx)
+	for i := range list.Orders {
+		list.Orders[i].Metadata = md
+	}
+
+	return list, nil
+}
+
+func decodeListStatus(msg *quickfix.Message) (OrderList, error) {
+	var (
+		list OrderList
+		err  error
+	)
+
+	if list.Symbol, err = binancetag.GetString(msg, tag.Symbol); err != nil {
+		return OrderList{}, err
+	}
+	if list.ListID, err = binancetag.GetString(msg, tag.ListID); err != nil {
+		return OrderList{}, err
+	}
+	if list.ClListID, err = binancetag.GetString(msg, binancetag.ClListID); err != nil {
+		return OrderList{}, err
+	}
+	contingency, err := binancetag.GetString(msg, tag.ContingencyType)
+	if err != nil {
+		return OrderList{}, err
+	}
+	list.ContingencyType = enum.ContingencyType(contingency)
+	status, err := binancetag.GetString(msg, tag.ListOrderStatus)
+	if err != nil {
+		return OrderList{}, err
+	}
+	list.ListOrderStatus = enum.ListOrderStatus(status)
+	statusType, err := binancetag.GetString(msg, tag.ListStatusType)
+	if err != nil {
+		return OrderList{}, err
+	}
+	list.ListStatusType = enum.ListStatusType(statusType)
+	if list.TransactTime, err = binancetag.GetUTCTimestamp(msg, tag.TransactTime); err != nil {
+		return OrderList{}, err
+	}
+	rejectReason, err := binancetag.GetString(msg, tag.ListRejectReason)
+	if err != nil {
+		return OrderList{}, err
+	}
+	list.ListRejectReason = enum.ListRejectReason(rejectReason)
+	if list.ErrorCode, err = binancetag.GetErrorCode(msg); err != nil {
+		return OrderList{}, err
+	}
+	if list.Text, err = getText(msg); err != nil {
+		return OrderList{}, err
+	}
+
+	if !msg.Body.Has(tag.NoOrders) {
+		return list, nil
+	}
+	group := newListStatusOrderGroup()
+	if err := msg.Body.GetGroup(group); err != nil {
+		return OrderList{}, err
+	}
+	for i := range group.Len() {
+		g := group.Get(i)
+		var o Order
+		if o.Symbol, err = g.GetString(tag.Symbol); err != nil {
+			return OrderList{}, err
+		}
+		if o.ClientOrderID, err = g.GetString(tag.ClOrdID); err != nil {
+			return OrderList{}, err
+		}
+		if g.Has(tag.OrderID) {
+			orderID, err := g.GetInt(tag.OrderID)
+			if err != nil {
+				return OrderList{}, err
+			}
+			o.OrderID = int64(orderID)
+		}
+		list.Orders = append(list.Orders, o)
+	}
+	return list, nil
+}
+
+// OrderListRejectedError is returned when the server rejects a whole order
+// list.
+type OrderListRejectedError struct {
+	ClListID         string
+	ListRejectReason enum.ListRejectReason
+	ErrorCode        int
+	Text             string
+	LegRejects       map[string]string // Why legs were rejected, by ClOrdID<11>.
+}
+
+func (e *OrderListRejectedError) Error() string {
+	return "order list rejected: " + e.Text
+}
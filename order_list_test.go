@@ -0,0 +1,226 @@
+package fix
+
+import (
+	"context"
+	"testing"
+
+	"github.com/KyberNetwork/binance_fix_api/binancetag"
+	"github.com/quickfixgo/enum"
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/tag"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeListStatus(t *testing.T) {
+	msg := newTestMessage(enum.MsgType_LIST_STATUS)
+	msg.Body.SetString(tag.ListID, "7")
+	msg.Body.SetString(binancetag.ClListID, "list")
+	msg.Body.SetString(tag.ContingencyType, string(enum.ContingencyType_ONE_CANCELS_THE_OTHER))
+	msg.Body.SetString(tag.ListOrderStatus, string(enum.ListOrderStatus_EXECUTING))
+	group := newListStatusOrderGroup()
+	for i, id := range []string{"a", "b"} {
+		g := group.Add()
+		g.SetString(tag.Symbol, "BTCUSDT")
+		g.SetInt(tag.OrderID, 10+i)
+		g.SetString(tag.ClOrdID, id)
+	}
+	msg.Body.SetGroup(group)
+
+	list, err := decodeListStatus(msg)
+	require.NoError(t, err)
+	assert.Equal(t, OrderList{
+		ListID:          "7",
+		ClListID:        "list",
+		ContingencyType: enum.ContingencyType_ONE_CANCELS_THE_OTHER,
+		ListOrderStatus: enum.ListOrderStatus_EXECUTING,
+		Orders: []Order{
+			{Symbol: "BTCUSDT", OrderID: 10, ClientOrderID: "a"},
+			{Symbol: "BTCUSDT", OrderID: 11, ClientOrderID: "b"},
+		},
+	}, list)
+}
+
+func TestSetListTriggers(t *testing.T) {
+	otoco := make([]listOrder, 3)
+	setListTriggers(enum.ContingencyType_ONE_TRIGGERS_THE_OTHER, otoco)
+
+	assert.Empty(t, otoco[0].triggers)
+	assert.Equal(t, []listTrigger{
+		{triggerType: listTriggerTypeFilled, triggerIndex: 0, action: listTriggerActionRelease},
+		{triggerType: listTriggerTypePartiallyFilled, triggerIndex: 2, action: listTriggerActionCancel},
+	}, otoco[1].triggers)
+	assert.Equal(t, []listTrigger{
+		{triggerType: listTriggerTypeFilled, triggerIndex: 0, action: listTriggerActionRelease},
+		{triggerType: listTriggerTypePartiallyFilled, triggerIndex: 1, action: listTriggerActionCancel},
+	}, otoco[2].triggers)
+
+	oco := make([]listOrder, 2)
+	setListTriggers(enum.ContingencyType_ONE_CANCELS_THE_OTHER, oco)
+	assert.Equal(t, 1, oco[0].triggers[0].triggerIndex)
+	assert.Equal(t, 0, oco[1].triggers[0].triggerIndex)
+}
+
+func TestDecodeListStatusReject(t *testing.T) {
+	msg := newTestMessage(enum.MsgType_LIST_STATUS)
+	msg.Body.SetString(tag.Symbol, "BTCUSDT")
+	msg.Body.SetString(binancetag.ClListID, "list")
+	msg.Body.SetString(tag.ContingencyType, string(enum.ContingencyType_ONE_CANCELS_THE_OTHER))
+	msg.Body.SetString(tag.ListStatusType, string(enum.ListStatusType_RESPONSE))
+	msg.Body.SetString(tag.ListOrderStatus, string(enum.ListOrderStatus_REJECT))
+	msg.Body.SetString(tag.ListRejectReason, string(enum.ListRejectReason_OTHER))
+	msg.Body.SetInt(binancetag.ErrorCode, -1013)
+	msg.Body.SetString(tag.Text, "Filter failure: PRICE_FILTER")
+
+	list, err := decodeListStatus(msg)
+	require.NoError(t, err)
+	assert.True(t, list.IsTerminal())
+	assert.Equal(t, OrderList{
+		Symbol:           "BTCUSDT",
+		ClListID:         "list",
+		ContingencyType:  enum.ContingencyType_ONE_CANCELS_THE_OTHER,
+		ListStatusType:   enum.ListStatusType_RESPONSE,
+		ListOrderStatus:  enum.ListOrderStatus_REJECT,
+		ListRejectReason: enum.ListRejectReason_OTHER,
+		ErrorCode:        -1013,
+		Text:             "Filter failure: PRICE_FILTER",
+	}, list)
+}
+
+func TestListLegRejects(t *testing.T) {
+	var r listLegRejects
+	assert.Nil(t, r.legRejects("7", false))
+
+	r.add("7", "a", "Order would immediately trigger.")
+	r.add("8", "b", "other list")
+	assert.Equal(t, map[string]string{"a": "Order would immediately trigger."}, r.legRejects("7", false))
+	assert.Equal(t, map[string]string{"a": "Order would immediately trigger."}, r.legRejects("7", true))
+	assert.Nil(t, r.legRejects("7", false))
+	assert.Len(t, r.legRejects("8", false), 1)
+}
+
+// sentOrderList returns the legs of the NewOrderList<E> sent by svc.
+func sentOrderList(t *testing.T, svc func(c *Client) *NewOrderListService) []map[quickfix.Tag]string {
+	t.Helper()
+
+	var sent *quickfix.Message
+	c := NewWithCaller(CallerFunc(func(_ context.Context, _ string, msg *quickfix.Message) (*quickfix.Message, error) {
+		sent = msg
+		return nil, ErrClosed
+	}))
+	_, err := svc(c).Symbol("BTCUSDT").Do(context.Background())
+	require.ErrorIs(t, err, ErrClosed)
+	require.NotNil(t, sent)
+	return listLegs(t, sent)
+}
+
+// listLegs returns the tags of every leg of a NewOrderList<E>. The list
+// triggering instructions are flattened to "type/index/action " strings.
+func listLegs(t *testing.T, msg *quickfix.Message) []map[quickfix.Tag]string {
+	t.Helper()
+
+	group := newListOrderGroup()
+	require.Nil(t, msg.Body.GetGroup(group))
+	legs := make([]map[quickfix.Tag]string, group.Len())
+	for i := range legs {
+		g := group.Get(i)
+		legs[i] = make(map[quickfix.Tag]string)
+		for _, k := range g.Tags() {
+			if k == binancetag.NoListTriggeringInstructions {
+				continue
+			}
+			var err error
+			legs[i][k], err = g.GetString(k)
+			require.NoError(t, err)
+		}
+
+		triggers := newListTriggerGroup()
+		if g.Has(binancetag.NoListTriggeringInstructions) {
+			require.Nil(t, g.GetGroup(triggers))
+		}
+		for j := 0; j < triggers.Len(); j++ {
+			tg := triggers.Get(j)
+			triggerType, _ := tg.GetString(binancetag.ListTriggerType)
+			index, _ := tg.GetString(binancetag.ListTriggerTriggerIndex)
+			action, _ := tg.GetString(binancetag.ListTriggerAction)
+			legs[i][binancetag.NoListTriggeringInstructions] += triggerType + "/" + index + "/" + action + " "
+		}
+	}
+	return legs
+}
+
+func TestNewOrderListOCOLegs(t *testing.T) {
+	legs := sentOrderList(t, func(c *Client) *NewOrderListService {
+		return c.NewOrderListService().
+			LimitMakerLeg(enum.Side_SELL, 0.1, 110.5).
+			StopLeg(enum.Side_SELL, 0.1, 90, 89.5)
+	})
+	require.Len(t, legs, 2)
+
+	delete(legs[0], tag.ClOrdID)
+	assert.Equal(t, map[quickfix.Tag]string{
+		tag.Symbol:                              "BTCUSDT",
+		tag.Side:                                string(enum.Side_SELL),
+		tag.OrdType:                             string(enum.OrdType_LIMIT),
+		tag.ExecInst:                            execInstParticipateDontInitiate,
+		tag.OrderQty:                            "0.1",
+		tag.Price:                               "110.5",
+		binancetag.NoListTriggeringInstructions: "2/1/2 ",
+	}, legs[0])
+
+	delete(legs[1], tag.ClOrdID)
+	assert.Equal(t, map[quickfix.Tag]string{
+		tag.Symbol:                              "BTCUSDT",
+		tag.Side:                                string(enum.Side_SELL),
+		tag.OrdType:                             string(enum.OrdType_STOP_LIMIT),
+		tag.OrderQty:                            "0.1",
+		tag.Price:                               "89.5",
+		tag.TimeInForce:                         string(enum.TimeInForce_GOOD_TILL_CANCEL),
+		tag.TriggerType:                         triggerTypePriceMovement,
+		tag.TriggerAction:                       triggerActionActivate,
+		tag.TriggerPrice:                        "90",
+		tag.TriggerPriceType:                    triggerPriceTypeLastTrade,
+		tag.TriggerPriceDirection:               string(TriggerDirectionDown),
+		binancetag.NoListTriggeringInstructions: "2/0/2 ",
+	}, legs[1])
+}
+
+func TestNewOrderListOTOLegs(t *testing.T) {
+	qty := decimal.RequireFromString("0.00012345")
+	working := ListLeg{
+		ClOrdID: "working", Side: enum.Side_BUY, Type: enum.OrdType_LIMIT, Quantity: qty,
+		Price: decimal.RequireFromString("100.01"), TimeInForce: enum.TimeInForce_GOOD_TILL_CANCEL,
+	}
+	takeProfit := ListLeg{
+		ClOrdID: "take-profit", Side: enum.Side_SELL, Type: enum.OrdType_LIMIT, Quantity: qty,
+		Price: decimal.RequireFromString("110"), LimitMaker: true,
+	}
+	stopLoss := ListLeg{
+		ClOrdID: "stop-loss", Side: enum.Side_SELL, Type: enum.OrdType_STOP, Quantity: qty,
+		TriggerPrice: decimal.RequireFromString("90"), TriggerDirection: TriggerDirectionDown,
+	}
+
+	legs := sentOrderList(t, func(c *Client) *NewOrderListService {
+		return c.NewOrderListService().WorkingLeg(working).PendingLeg(takeProfit)
+	})
+	require.Len(t, legs, 2)
+	assert.Equal(t, "working", legs[0][tag.ClOrdID])
+	assert.Equal(t, "0.00012345", legs[0][tag.OrderQty])
+	assert.Equal(t, "100.01", legs[0][tag.Price])
+	assert.NotContains(t, legs[0], binancetag.NoListTriggeringInstructions)
+	assert.Equal(t, "take-profit", legs[1][tag.ClOrdID])
+	assert.Equal(t, execInstParticipateDontInitiate, legs[1][tag.ExecInst])
+	assert.Equal(t, "3/0/1 ", legs[1][binancetag.NoListTriggeringInstructions])
+
+	// The pending legs of an OTOCO also cancel each other.
+	legs = sentOrderList(t, func(c *Client) *NewOrderListService {
+		return c.NewOrderListService().WorkingLeg(working).PendingLeg(takeProfit).PendingLeg(stopLoss)
+	})
+	require.Len(t, legs, 3)
+	assert.NotContains(t, legs[0], binancetag.NoListTriggeringInstructions)
+	assert.Equal(t, "3/0/1 2/2/2 ", legs[1][binancetag.NoListTriggeringInstructions])
+	assert.Equal(t, "stop-loss", legs[2][tag.ClOrdID])
+	assert.Equal(t, "90", legs[2][tag.TriggerPrice])
+	assert.Equal(t, "3/0/1 2/1/2 ", legs[2][binancetag.NoListTriggeringInstructions])
+}
@@ -0,0 +1,78 @@
+package fix
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/KyberNetwork/binance_fix_api/binancetag"
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/tag"
+)
+
+// errorCodeFilterFailure is the Binance error code of orders failing a
+// symbol filter.
+const errorCodeFilterFailure = -1013
+
+// Categories of order rejects, matched with errors.Is against an
+// *OrderRejectedError.
+var (
+	ErrRateLimited         = errors.New("rate limited")
+	ErrInsufficientBalance = errors.New("insufficient balance")
+	ErrFilterFailure       = errors.New("filter failure")
+)
+
+// OrderRejectedError is returned when the server rejects an order with an
+// ExecutionReport<8> whose OrdStatus<39> is REJECTED. It is returned along
+// with the decoded rejected Order.
+type OrderRejectedError struct {
+	ClOrdID      string
+	OrdRejReason int // OrdRejReason<103>, -1 if absent.
+	ErrorCode    int // Binance ErrorCode<25016>, 0 if absent.
+	Text         string
+}
+
+func (e *OrderRejectedError) Error() string {
+	return "order rejected: " + e.Text + " (code " + strconv.Itoa(e.ErrorCode) + ")"
+}
+
+// Is matches the reject categories ErrRateLimited, ErrInsufficientBalance,
+// ErrFilterFailure and ErrPostOnlyWouldTake.
+func (e *OrderRejectedError) Is(target error) bool {
+	text := strings.ToLower(e.Text)
+	switch target {
+	case ErrRateLimited:
+		return e.ErrorCode == errorCodeTooManyRequests || e.ErrorCode == errorCodeTooManyOrders ||
+			strings.Contains(text, "too many")
+	case ErrInsufficientBalance:
+		return strings.Contains(text, "insufficient balance")
+	case ErrFilterFailure:
+		return e.ErrorCode == errorCodeFilterFailure || strings.HasPrefix(text, "filter failure")
+	case ErrPostOnlyWouldTake:
+		return strings.Contains(text, "immediately match")
+	}
+	return false
+}
+
+func decodeOrderReject(msg *quickfix.Message) (*OrderRejectedError, error) {
+	var (
+		e   = OrderRejectedError{OrdRejReason: -1}
+		err error
+	)
+
+	if e.ClOrdID, err = getClientOrderID(msg); err != nil {
+		return nil, err
+	}
+	if msg.Body.Has(tag.OrdRejReason) {
+		if e.OrdRejReason, err = msg.Body.GetInt(tag.OrdRejReason); err != nil {
+			return nil, err
+		}
+	}
+	if e.ErrorCode, err = binancetag.GetErrorCode(msg); err != nil {
+		return nil, err
+	}
+	if e.Text, err = getText(msg); err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
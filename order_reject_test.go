@@ -0,0 +1,46 @@
+package fix
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/KyberNetwork/binance_fix_api/binancetag"
+	"github.com/quickfixgo/enum"
+	"github.com/quickfixgo/tag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeOrderReject(t *testing.T) {
+	reject := func(code int, text string) error {
+		msg := newTestReport("1", enum.OrdStatus_REJECTED)
+		msg.Body.SetInt(tag.OrdRejReason, 99)
+		msg.Body.SetInt(binancetag.ErrorCode, code)
+		msg.Body.SetString(tag.Text, text)
+		_, err := decodeExecutionReport(msg)
+		return err
+	}
+
+	msg := newTestReport("1", enum.OrdStatus_REJECTED)
+	msg.Body.SetString(tag.Text, "Order would immediately match and take.")
+	msg.Body.SetInt(binancetag.ErrorCode, -2010)
+	msg.Body.SetInt(tag.OrdRejReason, 99)
+	msg.Body.Remove(tag.OrderID)
+	order, err := decodeExecutionReport(msg)
+	assert.Equal(t, "BTCUSDT", order.Symbol)
+	assert.Equal(t, "1", order.ClientOrderID)
+	assert.Equal(t, OrderStatusRejected, order.Status)
+	assert.Zero(t, order.OrderID)
+
+	var rejErr *OrderRejectedError
+	require.ErrorAs(t, err, &rejErr)
+	assert.Equal(t, "1", rejErr.ClOrdID)
+	assert.Equal(t, 99, rejErr.OrdRejReason)
+	assert.Equal(t, -2010, rejErr.ErrorCode)
+	assert.True(t, errors.Is(err, ErrPostOnlyWouldTake))
+	assert.False(t, errors.Is(err, ErrFilterFailure))
+
+	assert.True(t, errors.Is(reject(-1013, "Filter failure: PRICE_FILTER"), ErrFilterFailure))
+	assert.True(t, errors.Is(reject(-1015, "Too many new orders."), ErrRateLimited))
+	assert.True(t, errors.Is(reject(-2010, "Account has insufficient balance for requested action."), ErrInsufficientBalance))
+}
@@ -0,0 +1,183 @@
+package fix
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/KyberNetwork/binance_fix_api/binancetag"
+	"github.com/quickfixgo/enum"
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/tag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrderComputedFields(t *testing.T) {
+	o := Order{OrderQty: 0.3, CumQty: 0.1, CumQuoteQty: 50.2}
+	assert.Equal(t, 0.2, o.RemainingQty())
+	assert.Equal(t, 0.3333333333333333, o.FilledRatio())
+	assert.Equal(t, 502.0, o.AvgFillPrice())
+
+	var empty Order
+	assert.Zero(t, empty.RemainingQty())
+	assert.Zero(t, empty.FilledRatio())
+	assert.Zero(t, empty.AvgFillPrice())
+}
+
+// newTestReport returns an ExecutionReport<8> with the tags every report has.
+func newTestReport(clOrdID string, status enum.OrdStatus) *quickfix.Message {
+	msg := newTestMessage(enum.MsgType_EXECUTION_REPORT)
+	msg.Body.SetString(tag.Symbol, "BTCUSDT")
+	msg.Body.SetString(tag.OrderID, "1")
+	msg.Body.SetString(tag.ClOrdID, clOrdID)
+	msg.Body.SetString(tag.OrdStatus, string(status))
+	msg.Body.SetString(tag.OrdType, string(enum.OrdType_MARKET))
+	msg.Body.SetString(tag.Side, string(enum.Side_BUY))
+	return msg
+}
+
+func TestDecodeExecutionReportRawTimes(t *testing.T) {
+	msg := newTestReport("a", enum.OrdStatus_NEW)
+	msg.Body.SetString(tag.TransactTime, "20240102-03:04:05.123456789")
+	msg.Body.SetString(tagWorkingTime, "20240102-03:04:05.123456")
+
+	order, err := decodeExecutionReport(msg)
+	require.NoError(t, err)
+	assert.Equal(t, "20240102-03:04:05.123456789", order.TransactTimeRaw)
+	assert.Equal(t, "20240102-03:04:05.123456", order.WorkingTimeRaw)
+	assert.Empty(t, order.OrderCreationTimeRaw)
+	assert.True(t, order.OrderCreationTime.IsZero())
+
+	assert.Equal(t, time.Date(2024, 1, 2, 3, 4, 5, 123456789, time.UTC), order.TransactTime)
+	assert.Equal(t, time.Date(2024, 1, 2, 3, 4, 5, 123456000, time.UTC), order.WorkingTime)
+}
+
+func TestOrderReceivedAt(t *testing.T) {
+	g := newTestGateway(t)
+	g.ackOrders(t, enum.OrdStatus_NEW, nil)
+	c := g.startClient(t)
+
+	sent := time.Now()
+	order, err := c.NewOrderSingleService().
+		Symbol("BTCUSDT").Side(enum.Side_BUY).Type(enum.OrdType_MARKET).Quantity(1).
+		Do(context.Background())
+	require.NoError(t, err)
+
+	// Stamped when read from the socket, with a monotonic clock reading.
+	assert.False(t, order.ReceivedAt.Before(sent))
+	assert.False(t, order.ReceivedAt.After(time.Now()))
+	assert.NotEqual(t, order.ReceivedAt.Round(0), order.ReceivedAt)
+
+	tracked, ok := c.Tracker().Order(order.ClientOrderID)
+	require.True(t, ok)
+	assert.Equal(t, order.ReceivedAt, tracked.ReceivedAt)
+}
+
+func TestDecodeExecutionReportSOR(t *testing.T) {
+	msg := newTestReport("a", enum.OrdStatus_NEW)
+	msg.Body.SetString(binancetag.WorkingFloor, "3")
+	msg.Body.SetBool(binancetag.SOR, true)
+
+	order, err := decodeExecutionReport(msg)
+	require.NoError(t, err)
+	assert.Equal(t, WorkingFloorSOR, order.WorkingFloor)
+	assert.True(t, order.UsedSOR)
+}
+
+func TestDecodeExecutionReportFill(t *testing.T) {
+	msg := newTestReport("a", enum.OrdStatus_PARTIALLY_FILLED)
+	msg.Header.SetString(tag.BeginString, quickfix.BeginStringFIX44)
+	msg.Body.SetString(tag.ExecType, string(enum.ExecType_TRADE))
+	msg.Body.SetString(tag.ExecID, "77")
+	msg.Body.SetString(tag.LastPx, "100.5")
+	msg.Body.SetString(tag.LastQty, "0.2")
+	msg.Body.SetString(tag.TradeID, "123456789012")
+	msg.Body.SetBool(tag.AggressorIndicator, true)
+
+	order, err := decodeExecutionReport(msg)
+	require.NoError(t, err)
+	assert.Equal(t, ExecTypeTrade, order.ExecType)
+	assert.Equal(t, "77", order.ExecID)
+	assert.Equal(t, 100.5, order.LastPx)
+	assert.Equal(t, 0.2, order.LastQty)
+	assert.Equal(t, 20.1, order.LastQuoteQty())
+	assert.Equal(t, int64(123456789012), order.TradeID)
+	assert.True(t, order.Aggressor)
+	require.NotNil(t, order.Exact)
+	assert.Equal(t, "100.5", order.Exact.LastPx.String())
+	assert.Equal(t, "0.2", order.Exact.LastQty.String())
+
+	assert.Empty(t, order.ExtraFields)
+	assert.Empty(t, order.Raw)
+}
+
+func TestRawMessageOpt(t *testing.T) {
+	msg := newTestReport("a", enum.OrdStatus_NEW)
+	msg.Header.SetString(tag.BeginString, quickfix.BeginStringFIX44)
+	msg.Body.SetString(tag.ExecID, "77")
+
+	c := NewWithCaller(nil)
+	c.handleSubscriptions(string(enum.MsgType_EXECUTION_REPORT), msg)
+	order, ok := c.Tracker().Order("a")
+	require.True(t, ok)
+	assert.Empty(t, order.Raw)
+
+	c = NewWithCaller(nil, WithRawMessageOpt())
+	c.handleSubscriptions(string(enum.MsgType_EXECUTION_REPORT), msg)
+	order, ok = c.Tracker().Order("a")
+	require.True(t, ok)
+	raw, err := order.RawMessage()
+	require.NoError(t, err)
+	execID, err := raw.Body.GetString(tag.ExecID)
+	require.NoError(t, err)
+	assert.Equal(t, "77", execID)
+}
+
+func TestDecodeExecutionReportExtraFields(t *testing.T) {
+	msg := newTestReport("a", enum.OrdStatus_NEW)
+	msg.Body.SetString(25099, "x")
+
+	order, err := decodeExecutionReport(msg)
+	require.NoError(t, err)
+	assert.Equal(t, map[quickfix.Tag]string{25099: "x"}, order.ExtraFields)
+}
+
+func TestDecodeExecutionReportPreventedMatch(t *testing.T) {
+	msg := newTestReport("a", enum.OrdStatus_EXPIRED)
+	msg.Body.SetString(binancetag.SelfTradePreventionMode, string(SelfTradePreventionModeExpireMaker))
+	msg.Body.SetString(binancetag.PreventedQty, "0.5")
+	msg.Body.SetString(binancetag.LastPreventedQty, "0.5")
+	msg.Body.SetString(binancetag.PreventedMatchID, "8")
+	msg.Body.SetString(binancetag.PreventedExecutionPrice, "100")
+	msg.Body.SetString(binancetag.PreventedExecutionQty, "0.5")
+	msg.Body.SetString(binancetag.TradeGroupID, "3")
+	msg.Body.SetString(binancetag.CounterSymbol, "BTCUSDT")
+	msg.Body.SetString(binancetag.CounterOrderID, "2")
+
+	order, err := decodeExecutionReport(msg)
+	require.NoError(t, err)
+	assert.Equal(t, SelfTradePreventionModeExpireMaker, order.SelfTradePreventionMode)
+	assert.Equal(t, 0.5, order.PreventedQty)
+	assert.Equal(t, 0.5, order.LastPreventedQty)
+	assert.Equal(t, &PreventedMatch{
+		ID: 8, ExecutionPrice: 100, ExecutionQty: 0.5, TradeGroupID: 3, CounterSymbol: "BTCUSDT", CounterOrderID: 2,
+	}, order.PreventedMatch)
+	assert.Empty(t, order.ExtraFields)
+
+	order, err = decodeExecutionReport(newTestReport("a", enum.OrdStatus_NEW))
+	require.NoError(t, err)
+	assert.Nil(t, order.PreventedMatch)
+}
+
+func TestDecodeExecutionReportOrigClOrdIDAndListID(t *testing.T) {
+	msg := newTestReport("b", enum.OrdStatus_CANCELED)
+	msg.Body.SetString(tag.OrigClOrdID, "a")
+	msg.Body.SetString(tag.ListID, "5")
+
+	order, err := decodeExecutionReport(msg)
+	require.NoError(t, err)
+	assert.Equal(t, "a", order.OrigClientOrderID)
+	assert.Equal(t, "5", order.ListID)
+	assert.Empty(t, order.ExtraFields)
+}
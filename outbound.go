@@ -0,0 +1,262 @@
+package fix
+
+import (
+	"container/heap"
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/quickfixgo/enum"
+	"github.com/quickfixgo/quickfix"
+)
+
+// budgetPollInterval is how often a queue held back by an exhausted rate
+// limit checks the budget again.
+const budgetPollInterval = 10 * time.Millisecond
+
+// Outbound priorities used by DefaultPriority. Higher values are sent first.
+const (
+	PriorityNewOrder = 0
+	PriorityDefault  = 1
+	PriorityCancel   = 2
+)
+
+// PriorityFunc ranks outbound messages for the outbound queue. Messages with
+// a higher value are sent first, equal values are sent in submission order.
+type PriorityFunc func(msg *quickfix.Message) int
+
+// DefaultPriority sends cancels and logouts ahead of everything else and new
+// or replaced orders last, so that reducing risk never waits behind quote
+// refreshes.
+func DefaultPriority(msg *quickfix.Message) int {
+	msgType, err := msg.MsgType()
+	if err != nil {
+		return PriorityDefault
+	}
+
+	switch enum.MsgType(msgType) {
+	case enum.MsgType_ORDER_CANCEL_REQUEST, enum.MsgType_ORDER_MASS_CANCEL_REQUEST, enum.MsgType_LOGOUT:
+		return PriorityCancel
+	case enum.MsgType_ORDER_SINGLE, enum.MsgType_ORDER_LIST, msgType_CANCEL_REPLACE_ORDER:
+		return PriorityNewOrder
+	default:
+		return PriorityDefault
+	}
+}
+
+// priorityAdmin ranks session-level messages ahead of any PriorityFunc.
+const priorityAdmin = math.MaxInt
+
+// adminMsgTypes are the session-level messages, which are never held back by
+// the rate limit budget.
+var adminMsgTypes = map[enum.MsgType]bool{
+	enum.MsgType_HEARTBEAT:      true,
+	enum.MsgType_TEST_REQUEST:   true,
+	enum.MsgType_RESEND_REQUEST: true,
+	enum.MsgType_REJECT:         true,
+	enum.MsgType_SEQUENCE_RESET: true,
+	enum.MsgType_LOGOUT:         true,
+	enum.MsgType_LOGON:          true,
+}
+
+type outboundItem struct {
+	msg      *quickfix.Message
+	msgType  enum.MsgType
+	priority int
+	seq      uint64
+	result   chan error
+
+	// Guarded by the queue lock.
+	taken   bool // Popped for sending.
+	dropped bool // Abandoned by its sender before being popped.
+}
+
+type outboundHeap []*outboundItem
+
+func (h outboundHeap) Len() int { return len(h) }
+
+func (h outboundHeap) Less(i, j int) bool {
+	if h[i].priority != h[j].priority {
+		return h[i].priority > h[j].priority
+	}
+	return h[i].seq < h[j].seq
+}
+
+func (h outboundHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+
+func (h *outboundHeap) Push(x any) { *h = append(*h, x.(*outboundItem)) }
+
+func (h *outboundHeap) Pop() any {
+	old := *h
+	item := old[len(old)-1]
+	old[len(old)-1] = nil
+	*h = old[:len(old)-1]
+	return item
+}
+
+// outboundQueue sends messages one at a time in priority order. It holds
+// messages back while the locally estimated rate limit budget is exhausted.
+type outboundQueue struct {
+	mu       sync.Mutex
+	items    outboundHeap
+	seq      uint64
+	closed   bool
+	notify   chan struct{}
+	stop     chan struct{}
+	stopped  chan struct{}
+	priority PriorityFunc
+	budget   *limitBudget
+	send     func(msg *quickfix.Message) error
+}
+
+func newOutboundQueue(
+	priority PriorityFunc, budget *limitBudget, send func(msg *quickfix.Message) error,
+) *outboundQueue {
+	if priority == nil {
+		priority = DefaultPriority
+	}
+	return &outboundQueue{
+		notify:   make(chan struct{}, 1),
+		stop:     make(chan struct{}),
+		stopped:  make(chan struct{}),
+		priority: priority,
+		budget:   budget,
+		send:     send,
+	}
+}
+
+func (q *outboundQueue) start() {
+	go q.run()
+}
+
+// push queues msg and returns the item receiving the result of sending it.
+func (q *outboundQueue) push(msg *quickfix.Message) *outboundItem {
+	item := &outboundItem{msg: msg, priority: q.priority(msg), result: make(chan error, 1)}
+	if msgType, err := msg.MsgType(); err == nil {
+		item.msgType = enum.MsgType(msgType)
+	}
+	if adminMsgTypes[item.msgType] {
+		item.priority = priorityAdmin
+	}
+
+	q.mu.Lock()
+	if q.closed {
+		q.mu.Unlock()
+		item.result <- ErrClosed
+		return item
+	}
+	q.seq++
+	item.seq = q.seq
+	heap.Push(&q.items, item)
+	q.mu.Unlock()
+
+	select {
+	case q.notify <- struct{}{}:
+	default:
+	}
+	return item
+}
+
+// wait returns the result of sending item. If ctx is done first and item was
+// not taken for sending yet, it is dropped and ctx.Err() returned.
+func (q *outboundQueue) wait(ctx context.Context, item *outboundItem) error {
+	select {
+	case err := <-item.result:
+		return err
+	case <-ctx.Done():
+	}
+
+	q.mu.Lock()
+	if !item.taken {
+		item.dropped = true
+		q.mu.Unlock()
+		return ctx.Err()
+	}
+	q.mu.Unlock()
+	return <-item.result
+}
+
+// close stops the queue and fails the messages which were not sent yet.
+func (q *outboundQueue) close() {
+	q.mu.Lock()
+	q.closed = true
+	items := q.items
+	q.items = nil
+	q.mu.Unlock()
+
+	close(q.stop)
+	<-q.stopped
+
+	for _, item := range items {
+		item.result <- ErrClosed
+	}
+}
+
+func (q *outboundQueue) run() {
+	defer close(q.stopped)
+
+	for {
+		item, wait := q.next()
+		if item != nil {
+			item.result <- q.send(item.msg)
+			continue
+		}
+
+		var poll <-chan time.Time
+		if wait {
+			poll = time.After(budgetPollInterval)
+		}
+		select {
+		case <-q.notify:
+		case <-poll:
+		case <-q.stop:
+			return
+		}
+	}
+}
+
+// next pops the message to send. It returns true instead if the head of the
+// queue is held back by the rate limit budget.
+func (q *outboundQueue) next() (*outboundItem, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for len(q.items) > 0 && q.items[0].dropped {
+		heap.Pop(&q.items)
+	}
+	if len(q.items) == 0 {
+		return nil, false
+	}
+	if q.exhausted(q.items[0].msgType) {
+		return nil, true
+	}
+	item := heap.Pop(&q.items).(*outboundItem)
+	item.taken = true
+	return item, false
+}
+
+func (q *outboundQueue) exhausted(msgType enum.MsgType) bool {
+	if adminMsgTypes[msgType] {
+		return false
+	}
+	now := time.Now()
+	if left, ok := q.budget.remaining(LimitTypeMessage, now); ok && left == 0 {
+		return true
+	}
+	if orderMsgTypes[msgType] {
+		if left, ok := q.budget.remaining(LimitTypeOrder, now); ok && left == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// transmit hands msg over to quickfix, through the outbound queue if enabled.
+// A message still queued when ctx is done is not sent.
+func (c *Client) transmit(ctx context.Context, msg *quickfix.Message) error {
+	if q := c.outbound.Load(); q != nil {
+		return q.wait(ctx, q.push(msg))
+	}
+	return quickfix.SendToTarget(msg, c.sessionID)
+}
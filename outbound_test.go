@@ -0,0 +1,117 @@
+package fix
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/quickfixgo/enum"
+	"github.com/quickfixgo/field"
+	"github.com/quickfixgo/quickfix"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestMessage(msgType enum.MsgType) *quickfix.Message {
+	msg := quickfix.NewMessage()
+	msg.Header.Set(field.NewMsgType(msgType))
+	return msg
+}
+
+func TestOutboundQueuePriority(t *testing.T) {
+	var (
+		budget  limitBudget
+		sent    []enum.MsgType
+		gate    = make(chan struct{})
+		entered = make(chan struct{}, 10)
+	)
+	q := newOutboundQueue(nil, &budget, func(msg *quickfix.Message) error {
+		entered <- struct{}{}
+		<-gate
+		msgType, _ := msg.MsgType()
+		sent = append(sent, enum.MsgType(msgType))
+		return nil
+	})
+	q.start()
+
+	// The first message is picked up right away and blocks the sender.
+	results := []<-chan error{q.push(newTestMessage(enum.MsgType_TEST_REQUEST)).result}
+	<-entered
+	for _, msgType := range []enum.MsgType{
+		enum.MsgType_ORDER_SINGLE,
+		msgType_CANCEL_REPLACE_ORDER,
+		enum.MsgType_ORDER_CANCEL_REQUEST,
+		enum.MsgType_ORDER_STATUS_REQUEST,
+		enum.MsgType_ORDER_MASS_CANCEL_REQUEST,
+	} {
+		results = append(results, q.push(newTestMessage(msgType)).result)
+	}
+	close(gate)
+	for _, result := range results {
+		assert.NoError(t, <-result)
+	}
+	q.close()
+
+	assert.Equal(t, []enum.MsgType{
+		enum.MsgType_TEST_REQUEST,
+		enum.MsgType_ORDER_CANCEL_REQUEST,
+		enum.MsgType_ORDER_MASS_CANCEL_REQUEST,
+		enum.MsgType_ORDER_STATUS_REQUEST,
+		enum.MsgType_ORDER_SINGLE,
+		msgType_CANCEL_REPLACE_ORDER,
+	}, sent)
+}
+
+func TestOutboundQueueBudget(t *testing.T) {
+	var budget limitBudget
+	budget.update(LimitResponse{Limits: []Limit{
+		{LimitType: LimitTypeOrder, LimitCount: 10, LimitMax: 10},
+	}}, time.Now())
+
+	q := newOutboundQueue(nil, &budget, func(*quickfix.Message) error { return nil })
+	q.start()
+
+	cancel := q.push(newTestMessage(enum.MsgType_ORDER_CANCEL_REQUEST))
+	assert.NoError(t, <-cancel.result)
+
+	order := q.push(newTestMessage(enum.MsgType_ORDER_SINGLE))
+	q.close()
+	assert.ErrorIs(t, <-order.result, ErrClosed)
+}
+
+func TestOutboundQueueDropsCanceled(t *testing.T) {
+	var (
+		budget limitBudget
+		sent   []enum.MsgType
+		mu     sync.Mutex
+	)
+	budget.update(LimitResponse{Limits: []Limit{
+		{LimitType: LimitTypeOrder, LimitCount: 10, LimitMax: 10},
+		{LimitType: LimitTypeMessage, LimitCount: 10, LimitMax: 10},
+	}}, time.Now())
+	q := newOutboundQueue(nil, &budget, func(msg *quickfix.Message) error {
+		mu.Lock()
+		defer mu.Unlock()
+		msgType, _ := msg.MsgType()
+		sent = append(sent, enum.MsgType(msgType))
+		return nil
+	})
+	q.start()
+	defer q.close()
+
+	// Held back by the exhausted budget until ctx is done.
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	order := q.push(newTestMessage(enum.MsgType_ORDER_SINGLE))
+	assert.ErrorIs(t, q.wait(ctx, order), context.DeadlineExceeded)
+
+	// Admin messages skip the budget.
+	assert.NoError(t, q.wait(context.Background(), q.push(newTestMessage(enum.MsgType_HEARTBEAT))))
+
+	budget.update(LimitResponse{}, time.Now())
+	assert.NoError(t, q.wait(context.Background(), q.push(newTestMessage(enum.MsgType_ORDER_CANCEL_REQUEST))))
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, []enum.MsgType{enum.MsgType_HEARTBEAT, enum.MsgType_ORDER_CANCEL_REQUEST}, sent)
+}
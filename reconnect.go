@@ -0,0 +1,135 @@
+package fix
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"time"
+)
+
+const (
+	defaultReconnectMinDelay = time.Second
+	defaultReconnectMaxDelay = time.Minute
+)
+
+// ReconnectPolicy controls how the client reconnects once its session drops
+// without Stop or Logout being called. Every logon, the first one included,
+// sends the current MessageHandling<25035> and ResponseMode<25036>, so they
+// are restored on reconnection.
+type ReconnectPolicy struct {
+	// Disabled leaves reconnection to quickfix, which retries every
+	// ReconnectInterval of the session settings.
+	Disabled bool
+	// MinDelay is the wait before the first attempt, doubled after every
+	// failed attempt up to MaxDelay. A random jitter of up to half the delay
+	// is subtracted from each wait. Zero values use the defaults.
+	MinDelay time.Duration
+	MaxDelay time.Duration
+}
+
+// WithReconnectPolicyOpt sets how the client reconnects, by default with a
+// backoff from 1 second to 1 minute.
+func WithReconnectPolicyOpt(p ReconnectPolicy) NewClientOption {
+	return func(o *Options) {
+		o.reconnectPolicy = p
+	}
+}
+
+// nextDelay returns the wait before the attempt following one which waited
+// delay, zero for the first attempt, without jitter.
+func (p ReconnectPolicy) nextDelay(delay time.Duration) time.Duration {
+	minDelay, maxDelay := p.MinDelay, p.MaxDelay
+	if minDelay <= 0 {
+		minDelay = defaultReconnectMinDelay
+	}
+	if maxDelay <= 0 {
+		maxDelay = defaultReconnectMaxDelay
+	}
+	if delay == 0 {
+		return minDelay
+	}
+	return max(min(delay*2, maxDelay), minDelay)
+}
+
+// sleepUnlessStopped waits for d and reports whether stopped was not closed
+// in the meantime.
+func sleepUnlessStopped(d time.Duration, stopped <-chan struct{}) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return true
+	case <-stopped:
+		return false
+	}
+}
+
+func jitter(delay time.Duration) time.Duration {
+	if delay <= 1 {
+		return delay
+	}
+	return delay - rand.N(delay/2)
+}
+
+// shouldReconnect reports whether a dropped session must be reconnected by
+// the client.
+func (c *Client) shouldReconnect() bool {
+	if c.options.reconnectPolicy.Disabled || c.closing.Load() {
+		return false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.initiator != nil
+}
+
+// reconnect restarts the session, which dropped or went stale with cause,
+// until it logs on again, Stop or Logout is called. The initiator is stopped
+// between attempts so that quickfix does not reconnect on its own. With
+// WithEndpointsOpt, the client fails over from the endpoint whose session
+// dropped and from every endpoint an attempt failed on.
+func (c *Client) reconnect(cause error) {
+	if !c.reconnecting.CompareAndSwap(false, true) {
+		return
+	}
+	defer c.reconnecting.Store(false)
+
+	stopped := c.stopSignal()
+	if c.closing.Load() {
+		return
+	}
+
+	if end, ok := c.scheduledDowntimeEnd(time.Now()); ok {
+		c.l.Infow("Session dropped for scheduled maintenance, reconnecting once it ends", "end", end)
+		c.stop()
+		if !sleepUnlessStopped(time.Until(end), stopped) {
+			return
+		}
+	} else {
+		c.endpointFailed(cause)
+	}
+
+	p := c.options.reconnectPolicy
+	var delay time.Duration
+	for attempt := 1; ; attempt++ {
+		c.stop()
+
+		delay = p.nextDelay(delay)
+		wait := jitter(delay)
+		c.l.Infow("Reconnecting", "attempt", attempt, "in", wait)
+		if !sleepUnlessStopped(wait, stopped) || c.closing.Load() {
+			return
+		}
+
+		if err := c.start(context.Background()); err != nil {
+			if errors.Is(err, ErrClosed) && c.closing.Load() {
+				return
+			}
+			c.l.Warnw("Failed to reconnect", "attempt", attempt, "error", err)
+			c.endpointFailed(err)
+			continue
+		}
+		c.l.Infow("Reconnected", "attempts", attempt)
+		return
+	}
+}
@@ -0,0 +1,58 @@
+package fix
+
+import (
+	"testing"
+	"time"
+
+	"github.com/quickfixgo/enum"
+	"github.com/quickfixgo/field"
+	"github.com/quickfixgo/quickfix"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReconnectPolicyNextDelay(t *testing.T) {
+	p := ReconnectPolicy{MinDelay: time.Second, MaxDelay: 5 * time.Second}
+	var delays []time.Duration
+	var delay time.Duration
+	for i := 0; i < 5; i++ {
+		delay = p.nextDelay(delay)
+		delays = append(delays, delay)
+	}
+	assert.Equal(t, []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second}, delays)
+
+	assert.Equal(t, defaultReconnectMinDelay, ReconnectPolicy{}.nextDelay(0))
+	assert.Equal(t, defaultReconnectMaxDelay, ReconnectPolicy{}.nextDelay(defaultReconnectMaxDelay))
+
+	for i := 0; i < 100; i++ {
+		d := jitter(time.Second)
+		assert.True(t, d > time.Second/2 && d <= time.Second, d)
+	}
+}
+
+func TestStopInterruptsReconnect(t *testing.T) {
+	g := newTestGateway(t)
+	c := g.startClient(t, WithReconnectPolicyOpt(ReconnectPolicy{MinDelay: time.Hour, MaxDelay: time.Hour}))
+
+	logout := quickfix.NewMessage()
+	logout.Header.Set(field.NewMsgType(enum.MsgType_LOGOUT))
+	g.reply(t, quickfix.SessionID{BeginString: quickfix.BeginStringFIX44, SenderCompID: "SPOT", TargetCompID: "EXAMPLE"}, logout)
+	require.Eventually(t, c.reconnecting.Load, 5*time.Second, 10*time.Millisecond)
+
+	c.Stop()
+	assert.Eventually(t, func() bool { return !c.reconnecting.Load() }, time.Second, 10*time.Millisecond)
+	assert.False(t, c.IsConnected())
+}
+
+func TestStartInitiatorAfterStop(t *testing.T) {
+	g := newTestGateway(t)
+	c := g.newClient(t)
+	c.Stop()
+
+	// A reconnection which passed its last check before Stop.
+	_, err := c.startInitiator()
+	assert.ErrorIs(t, err, ErrClosed)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	assert.Nil(t, c.initiator)
+}
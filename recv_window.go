@@ -0,0 +1,64 @@
+package fix
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/KyberNetwork/binance_fix_api/binancetag"
+	"github.com/quickfixgo/enum"
+	"github.com/quickfixgo/quickfix"
+)
+
+// maxRecvWindow is the largest RecvWindow<25000> accepted by the server.
+const maxRecvWindow = time.Minute
+
+// recvWindowMsgTypes are the order entry messages RecvWindow<25000> is set on.
+var recvWindowMsgTypes = map[enum.MsgType]bool{
+	enum.MsgType_ORDER_SINGLE:              true,
+	enum.MsgType_ORDER_CANCEL_REQUEST:      true,
+	enum.MsgType_ORDER_MASS_CANCEL_REQUEST: true,
+	enum.MsgType_ORDER_LIST:                true,
+	msgType_CANCEL_REPLACE_ORDER:           true,
+	msgType_AMEND_KEEP_PRIORITY:            true,
+}
+
+// WithRecvWindowOpt makes the server reject order entry messages received
+// more than window after their SendingTime<52>, instead of executing them
+// late after a network stall. Windows are sent with millisecond precision
+// and cannot exceed one minute.
+func WithRecvWindowOpt(window time.Duration) NewClientOption {
+	return func(o *Options) {
+		o.recvWindow = window
+	}
+}
+
+type recvWindowCtxKey struct{}
+
+// ContextWithRecvWindow returns a copy of ctx overriding the window set with
+// WithRecvWindowOpt for the requests made with it.
+func ContextWithRecvWindow(ctx context.Context, window time.Duration) context.Context {
+	return context.WithValue(ctx, recvWindowCtxKey{}, window)
+}
+
+// setRecvWindow sets RecvWindow<25000> on msg if it is an order entry
+// message and a window is configured.
+func (c *Client) setRecvWindow(ctx context.Context, msg *quickfix.Message) error {
+	window := c.options.recvWindow
+	if w, ok := ctx.Value(recvWindowCtxKey{}).(time.Duration); ok {
+		window = w
+	}
+	if window <= 0 {
+		return nil
+	}
+	if window > maxRecvWindow {
+		return errors.New("recv window cannot exceed one minute")
+	}
+
+	msgType, err := msg.MsgType()
+	if err != nil || !recvWindowMsgTypes[enum.MsgType(msgType)] {
+		return nil
+	}
+	msg.Header.SetInt(binancetag.RecvWindow, int(window.Milliseconds()))
+	return nil
+}
@@ -0,0 +1,35 @@
+package fix
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/KyberNetwork/binance_fix_api/binancetag"
+	"github.com/quickfixgo/enum"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetRecvWindow(t *testing.T) {
+	c := &Client{}
+	WithRecvWindowOpt(5 * time.Second)(&c.options)
+
+	order := newTestMessage(enum.MsgType_ORDER_SINGLE)
+	require.NoError(t, c.setRecvWindow(context.Background(), order))
+	window, err := order.Header.GetInt(binancetag.RecvWindow)
+	require.NoError(t, err)
+	assert.Equal(t, 5000, window)
+
+	order = newTestMessage(enum.MsgType_ORDER_SINGLE)
+	require.NoError(t, c.setRecvWindow(ContextWithRecvWindow(context.Background(), 250*time.Millisecond), order))
+	window, err = order.Header.GetInt(binancetag.RecvWindow)
+	require.NoError(t, err)
+	assert.Equal(t, 250, window)
+
+	limits := newTestMessage(msgType_LIMIT_REQUEST)
+	require.NoError(t, c.setRecvWindow(context.Background(), limits))
+	assert.False(t, limits.Header.Has(binancetag.RecvWindow))
+
+	assert.Error(t, c.setRecvWindow(ContextWithRecvWindow(context.Background(), 2*time.Minute), order))
+}
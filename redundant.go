@@ -0,0 +1,85 @@
+package fix
+
+import (
+	"strconv"
+	"sync"
+
+	"github.com/quickfixgo/enum"
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/tag"
+)
+
+// maxDedupEntries bounds the memory used to suppress duplicate reports.
+const maxDedupEntries = 10000
+
+// redundantMsgTypes are the requests sent through the redundant session
+// while the session of the client is down.
+var redundantMsgTypes = map[enum.MsgType]bool{
+	enum.MsgType_ORDER_SINGLE:              true,
+	enum.MsgType_ORDER_LIST:                true,
+	msgType_CANCEL_REPLACE_ORDER:           true,
+	enum.MsgType_ORDER_CANCEL_REQUEST:      true,
+	enum.MsgType_ORDER_MASS_CANCEL_REQUEST: true,
+}
+
+// WithRedundantSessionOpt pairs the client with peer, a client logged on
+// with another session of the same account. Binance identifies the sessions
+// of an API key by their SenderCompID<49>, so peer must use another one.
+// Order entry requests are sent on the session of the client while it is
+// logged on and on the session of peer otherwise, never on both: Binance only
+// rejects a reused ClOrdID<11> while the first order is open, so the copy of
+// an order filled right away would be placed as a second order. Execution
+// reports of both sessions are merged into the subscribers of this client,
+// without duplicates, so both clients must use ResponseModeEverything.
+func WithRedundantSessionOpt(peer *Client) NewClientOption {
+	return func(o *Options) {
+		o.redundantPeer = peer
+	}
+}
+
+// reportDedup suppresses execution reports already delivered through the
+// other session.
+type reportDedup struct {
+	mu   sync.Mutex
+	seen map[string]bool
+	keys []string // Insertion order of seen, oldest first.
+}
+
+func newReportDedup() *reportDedup {
+	return &reportDedup{seen: make(map[string]bool)}
+}
+
+func reportKey(o *Order) string {
+	if o.ExecID != "" {
+		return o.ClientOrderID + "|" + o.ExecID
+	}
+	return o.ClientOrderID + "|" +
+		strconv.FormatInt(o.OrderID, 10) + "|" +
+		string(o.Status) + "|" +
+		floatToString(o.CumQty) + "|" +
+		o.TransactTimeRaw
+}
+
+// firstSeen reports whether o has not been delivered yet, and remembers it.
+func (d *reportDedup) firstSeen(o *Order) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	key := reportKey(o)
+	if d.seen[key] {
+		return false
+	}
+
+	d.seen[key] = true
+	d.keys = append(d.keys, key)
+	if len(d.keys) > maxDedupEntries {
+		delete(d.seen, d.keys[0])
+		d.keys = d.keys[1:]
+	}
+	return true
+}
+
+func isRejectedReport(msg *quickfix.Message) bool {
+	status, err := msg.Body.GetString(tag.OrdStatus)
+	return err == nil && enum.OrdStatus(status) == enum.OrdStatus_REJECTED
+}
@@ -0,0 +1,72 @@
+package fix
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/quickfixgo/enum"
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/tag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReportDedup(t *testing.T) {
+	d := newReportDedup()
+
+	ack := &Order{ClientOrderID: "a", OrderID: 1, Status: OrderStatusNew, TransactTimeRaw: "20240101-00:00:00.000"}
+	assert.True(t, d.firstSeen(ack))
+	assert.False(t, d.firstSeen(ack))
+
+	fill := &Order{ClientOrderID: "a", OrderID: 1, Status: OrderStatusFilled, CumQty: 1, TransactTimeRaw: "20240101-00:00:01.000"}
+	assert.True(t, d.firstSeen(fill))
+	assert.False(t, d.firstSeen(fill))
+
+	// Orders rejected, reported through both sessions, are reported once.
+	reject := &Order{ClientOrderID: "b", Status: OrderStatusRejected}
+	assert.True(t, d.firstSeen(reject))
+	assert.False(t, d.firstSeen(reject))
+}
+
+func TestRedundantSessionSendsOnce(t *testing.T) {
+	g := newTestGateway(t)
+	received := make(chan *quickfix.Message, 10)
+	g.ackOrders(t, enum.OrdStatus_NEW, received)
+
+	peer := g.startClient(t)
+	// The client shares the session of peer but is never started: its
+	// requests go through peer.
+	c := g.newClient(t, WithRedundantSessionOpt(peer))
+
+	order, err := c.NewOrderSingleService().
+		Symbol("BTCUSDT").
+		Side(enum.Side_BUY).
+		Type(enum.OrdType_MARKET).
+		Quantity(1).
+		Do(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, OrderStatusNew, order.Status)
+
+	msg := <-received
+	clOrdID, err := msg.Body.GetString(tag.ClOrdID)
+	require.NoError(t, err)
+	assert.Equal(t, order.ClientOrderID, clOrdID)
+	assert.Empty(t, received)
+}
+
+func TestSessionIDOfKeepsQualifier(t *testing.T) {
+	settings, err := quickfix.ParseSettings(strings.NewReader(`
+[DEFAULT]
+BeginString=FIX.4.4
+SenderCompID=EXAMPLE
+TargetCompID=SPOT
+
+[SESSION]
+SessionQualifier=backup
+`))
+	require.NoError(t, err)
+
+	id := sessionIDOf(settings, quickfix.BeginStringFIX44, "EXAMPLE", "SPOT")
+	assert.Equal(t, "backup", id.Qualifier)
+}
@@ -0,0 +1,100 @@
+package fix
+
+import (
+	"sync"
+	"time"
+
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/tag"
+)
+
+// ReplayPolicy decides what happens to application messages resent by the
+// server (PossDupFlag=Y) after a ResendRequest.
+type ReplayPolicy int
+
+const (
+	// ReplayPolicyDeliver delivers resent messages to subscribers, marked
+	// with Order.PossDup.
+	ReplayPolicyDeliver ReplayPolicy = 1
+	// ReplayPolicyDrop only uses resent messages to resolve pending calls and
+	// never delivers them to subscribers.
+	ReplayPolicyDrop ReplayPolicy = 2
+)
+
+// ReplayAlertHandler is called with the elapsed time when a replay lasts
+// longer than tolerated.
+type ReplayAlertHandler func(elapsed time.Duration)
+
+// replayState tracks whether the session is currently receiving resent
+// messages and since when.
+type replayState struct {
+	mu        sync.Mutex
+	startedAt time.Time
+	timer     *time.Timer // Alerts once the replay lasts too long.
+}
+
+func isPossDup(msg *quickfix.Message) bool {
+	if !msg.Header.Has(tag.PossDupFlag) {
+		return false
+	}
+	possDup, err := msg.Header.GetBool(tag.PossDupFlag)
+	return err == nil && possDup
+}
+
+// trackReplay updates the replay state with an incoming application message.
+// The first resent message starts a timer alerting when the replay has been
+// going on for too long, even if no other message arrives meanwhile.
+func (c *Client) trackReplay(possDup bool) {
+	if !possDup {
+		c.endReplay()
+		return
+	}
+
+	c.replay.mu.Lock()
+	defer c.replay.mu.Unlock()
+
+	if !c.replay.startedAt.IsZero() {
+		return
+	}
+	startedAt := time.Now()
+	c.replay.startedAt = startedAt
+	c.l.Infow("Receiving resent messages")
+	if c.options.maxReplayDuration > 0 {
+		c.replay.timer = time.AfterFunc(c.options.maxReplayDuration, func() {
+			c.alertReplay(startedAt)
+		})
+	}
+}
+
+// endReplay leaves the replay mode, on the first message which is not resent
+// or once the session is logged out.
+func (c *Client) endReplay() {
+	c.replay.mu.Lock()
+	defer c.replay.mu.Unlock()
+
+	if c.replay.startedAt.IsZero() {
+		return
+	}
+	c.l.Infow("Replay of resent messages finished", "elapsed", time.Since(c.replay.startedAt))
+	c.replay.startedAt = time.Time{}
+	if c.replay.timer != nil {
+		c.replay.timer.Stop()
+		c.replay.timer = nil
+	}
+}
+
+// alertReplay alerts that the replay started at startedAt is still going on.
+func (c *Client) alertReplay(startedAt time.Time) {
+	c.replay.mu.Lock()
+	ongoing := c.replay.startedAt.Equal(startedAt)
+	c.replay.mu.Unlock()
+	if !ongoing {
+		return
+	}
+
+	elapsed := time.Since(startedAt)
+	c.l.Warnw("Replay of resent messages is taking too long", "elapsed", elapsed)
+	if c.options.replayAlertHandler != nil {
+		c.options.replayAlertHandler(elapsed)
+	}
+}
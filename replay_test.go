@@ -0,0 +1,47 @@
+package fix
+
+import (
+	"testing"
+	"time"
+
+	"github.com/quickfixgo/enum"
+	"github.com/quickfixgo/tag"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReplayAlertWithoutLaterMessage(t *testing.T) {
+	alerts := make(chan time.Duration, 1)
+	c := NewWithCaller(nil, WithReplayAlertOpt(50*time.Millisecond, func(elapsed time.Duration) {
+		alerts <- elapsed
+	}))
+
+	// A single resent message, the replay stalls right after it.
+	msg := newTestReport("a", enum.OrdStatus_NEW)
+	msg.Header.SetBool(tag.PossDupFlag, true)
+	c.processApp(msg)
+
+	select {
+	case elapsed := <-alerts:
+		assert.GreaterOrEqual(t, elapsed, 50*time.Millisecond)
+	case <-time.After(time.Second):
+		t.Fatal("stalled replay was not alerted")
+	}
+}
+
+func TestReplayAlertStopsWithReplay(t *testing.T) {
+	alerts := make(chan time.Duration, 1)
+	c := NewWithCaller(nil, WithReplayAlertOpt(50*time.Millisecond, func(elapsed time.Duration) {
+		alerts <- elapsed
+	}))
+
+	resent := newTestReport("a", enum.OrdStatus_NEW)
+	resent.Header.SetBool(tag.PossDupFlag, true)
+	c.processApp(resent)
+	c.processApp(newTestReport("b", enum.OrdStatus_NEW))
+
+	select {
+	case <-alerts:
+		t.Fatal("finished replay was alerted")
+	case <-time.After(200 * time.Millisecond):
+	}
+}
@@ -0,0 +1,349 @@
+package fix
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/quickfixgo/enum"
+	"github.com/quickfixgo/field"
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/quickfix/config"
+	"github.com/quickfixgo/tag"
+)
+
+// clientStoreFactory keeps a reference to the message store of the session so
+// the client can inspect and adjust sequence numbers.
+type clientStoreFactory struct {
+	factory quickfix.MessageStoreFactory
+	c       *Client
+}
+
+func (f clientStoreFactory) Create(sessionID quickfix.SessionID) (quickfix.MessageStore, error) {
+	created, err := f.factory.Create(sessionID)
+	if err != nil {
+		return nil, err
+	}
+	store := &syncStore{MessageStore: created}
+
+	f.c.mu.Lock()
+	defer f.c.mu.Unlock()
+
+	if err := f.c.nextSeqNums.apply(store); err != nil {
+		return nil, err
+	}
+	f.c.nextSeqNums = seqNumOverride{}
+	f.c.store = store
+	return store, nil
+}
+
+// syncStore serializes the calls to a message store, which quickfix makes
+// from the session goroutines while the client reads the sequence numbers.
+type syncStore struct {
+	mu sync.Mutex
+	quickfix.MessageStore
+	resetSenderTo int // Next sender sequence number after the message being sent.
+}
+
+// resetSenderAfterSend moves the next sender sequence number to next once the
+// message being sent is stored, instead of incrementing it. It is called from
+// ToAdmin, so the session cannot send anything in between.
+func (s *syncStore) resetSenderAfterSend(next int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.resetSenderTo = next
+}
+
+// takeSenderReset returns the pending next sender sequence number, if any.
+func (s *syncStore) takeSenderReset() (int, bool) {
+	next := s.resetSenderTo
+	s.resetSenderTo = 0
+	return next, next > 0
+}
+
+func (s *syncStore) NextSenderMsgSeqNum() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.MessageStore.NextSenderMsgSeqNum()
+}
+
+func (s *syncStore) NextTargetMsgSeqNum() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.MessageStore.NextTargetMsgSeqNum()
+}
+
+func (s *syncStore) IncrNextSenderMsgSeqNum() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if next, ok := s.takeSenderReset(); ok {
+		return s.MessageStore.SetNextSenderMsgSeqNum(next)
+	}
+	return s.MessageStore.IncrNextSenderMsgSeqNum()
+}
+
+func (s *syncStore) IncrNextTargetMsgSeqNum() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.MessageStore.IncrNextTargetMsgSeqNum()
+}
+
+func (s *syncStore) SetNextSenderMsgSeqNum(next int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.MessageStore.SetNextSenderMsgSeqNum(next)
+}
+
+func (s *syncStore) SetNextTargetMsgSeqNum(next int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.MessageStore.SetNextTargetMsgSeqNum(next)
+}
+
+func (s *syncStore) CreationTime() time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.MessageStore.CreationTime()
+}
+
+func (s *syncStore) SetCreationTime(t time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.MessageStore.SetCreationTime(t)
+}
+
+func (s *syncStore) SaveMessage(seqNum int, msg []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.MessageStore.SaveMessage(seqNum, msg)
+}
+
+func (s *syncStore) SaveMessageAndIncrNextSenderMsgSeqNum(seqNum int, msg []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if next, ok := s.takeSenderReset(); ok {
+		if err := s.MessageStore.SaveMessage(seqNum, msg); err != nil {
+			return err
+		}
+		return s.MessageStore.SetNextSenderMsgSeqNum(next)
+	}
+	return s.MessageStore.SaveMessageAndIncrNextSenderMsgSeqNum(seqNum, msg)
+}
+
+func (s *syncStore) GetMessages(beginSeqNum, endSeqNum int) ([][]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.MessageStore.GetMessages(beginSeqNum, endSeqNum)
+}
+
+func (s *syncStore) IterateMessages(beginSeqNum, endSeqNum int, cb func([]byte) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.MessageStore.IterateMessages(beginSeqNum, endSeqNum, cb)
+}
+
+func (s *syncStore) Refresh() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.MessageStore.Refresh()
+}
+
+func (s *syncStore) Reset() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.MessageStore.Reset()
+}
+
+func (s *syncStore) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.MessageStore.Close()
+}
+
+// seqNumOverride holds the sequence numbers set while the client is stopped,
+// zero if unset.
+type seqNumOverride struct {
+	sender int
+	target int
+}
+
+func (o seqNumOverride) apply(store quickfix.MessageStore) error {
+	if o.sender > 0 {
+		if err := store.SetNextSenderMsgSeqNum(o.sender); err != nil {
+			return err
+		}
+	}
+	if o.target > 0 {
+		return store.SetNextTargetMsgSeqNum(o.target)
+	}
+	return nil
+}
+
+func (c *Client) messageStore() (quickfix.MessageStore, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.store == nil {
+		return nil, ErrNoSession
+	}
+	return c.store, nil
+}
+
+// NextSenderMsgSeqNum returns the sequence number of the next message sent,
+// or 0 if the session has not been created yet.
+func (c *Client) NextSenderMsgSeqNum() int {
+	store, err := c.messageStore()
+	if err != nil {
+		return 0
+	}
+	return store.NextSenderMsgSeqNum()
+}
+
+// NextTargetMsgSeqNum returns the sequence number expected on the next message
+// received, or 0 if the session has not been created yet.
+func (c *Client) NextTargetMsgSeqNum() int {
+	store, err := c.messageStore()
+	if err != nil {
+		return 0
+	}
+	return store.NextTargetMsgSeqNum()
+}
+
+// LastProcessedMsgSeqNum returns the sequence number of the last message
+// received from the server and handed to the client.
+func (c *Client) LastProcessedMsgSeqNum() int {
+	return int(c.lastProcessedSeqNum.Load())
+}
+
+func (c *Client) recordProcessedSeqNum(msg *quickfix.Message) {
+	if seqNum, err := msg.Header.GetInt(tag.MsgSeqNum); err == nil {
+		c.lastProcessedSeqNum.Store(int64(seqNum))
+	}
+}
+
+// SendResendRequest asks the server to resend messages from beginSeqNo to
+// endSeqNo. An endSeqNo of 0 requests everything after beginSeqNo.
+func (c *Client) SendResendRequest(beginSeqNo, endSeqNo int) error {
+	msg := quickfix.NewMessage()
+	msg.Header.Set(field.NewMsgType(enum.MsgType_RESEND_REQUEST))
+	msg.Body.Set(field.NewBeginSeqNo(beginSeqNo))
+	msg.Body.Set(field.NewEndSeqNo(endSeqNo))
+
+	return c.sendAdmin(msg)
+}
+
+// SendSequenceReset sends a SequenceReset<4> telling the server that the next
+// message it receives will have newSeqNo. With gapFill the messages skipped
+// are considered administrative, otherwise the sequence is hard reset.
+// The local sender sequence number is moved to newSeqNo as well, in the same
+// step as the SequenceReset is sent so that no other message gets in between.
+func (c *Client) SendSequenceReset(newSeqNo int, gapFill bool) error {
+	if _, err := c.messageStore(); err != nil {
+		return err
+	}
+
+	msg := quickfix.NewMessage()
+	msg.Header.Set(field.NewMsgType(enum.MsgType_SEQUENCE_RESET))
+	msg.Body.Set(field.NewGapFillFlag(gapFill))
+	msg.Body.Set(field.NewNewSeqNo(newSeqNo))
+
+	c.seqResetMu.Lock()
+	defer c.seqResetMu.Unlock()
+	c.seqReset.Store(msg)
+	defer c.seqReset.Store(nil)
+
+	return c.sendAdmin(msg)
+}
+
+// resetSenderSeqNum arranges for the store to move the next sender sequence
+// number once msg, being sent, is the SequenceReset<4> of SendSequenceReset.
+// The ones quickfix sends to gap fill resent messages are left alone.
+func (c *Client) resetSenderSeqNum(msg *quickfix.Message) {
+	if msg != c.seqReset.Load() {
+		return
+	}
+	newSeqNo, err := msg.Body.GetInt(tag.NewSeqNo)
+	if err != nil {
+		return
+	}
+	c.mu.Lock()
+	store := c.store
+	c.mu.Unlock()
+	if store != nil {
+		store.resetSenderAfterSend(newSeqNo)
+	}
+}
+
+// SetNextSenderMsgSeqNum overrides the sequence number of the next message
+// sent by the next session. It fails with ErrSessionStarted unless the client
+// is stopped, as quickfix moves the sequence numbers of a running session on
+// its own, and with ErrSeqNumsReset if the logon would reset the override,
+// see resetsSeqNums.
+func (c *Client) SetNextSenderMsgSeqNum(next int) error {
+	c.startMu.Lock()
+	defer c.startMu.Unlock()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.initiator != nil {
+		return ErrSessionStarted
+	}
+	if c.resetsSeqNums() {
+		return ErrSeqNumsReset
+	}
+	c.l.Warnw("Overriding next sender sequence number", "to", next)
+	c.nextSeqNums.sender = next
+	return nil
+}
+
+// SetNextTargetMsgSeqNum overrides the sequence number expected on the next
+// message received by the next session, see SetNextSenderMsgSeqNum.
+func (c *Client) SetNextTargetMsgSeqNum(next int) error {
+	c.startMu.Lock()
+	defer c.startMu.Unlock()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.initiator != nil {
+		return ErrSessionStarted
+	}
+	if c.resetsSeqNums() {
+		return ErrSeqNumsReset
+	}
+	c.l.Warnw("Overriding next target sequence number", "to", next)
+	c.nextSeqNums.target = next
+	return nil
+}
+
+// resetsSeqNums reports whether the sequence numbers restart from 1 on logon,
+// because of ResetSeqNumFlag<141> Y or the ResetOnLogon setting of the session.
+func (c *Client) resetsSeqNums() bool {
+	if !c.options.keepSeqNums {
+		return true
+	}
+	for _, session := range c.settings.SessionSettings() {
+		if reset, err := session.BoolSetting(config.ResetOnLogon); err == nil && reset {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *Client) sendAdmin(msg *quickfix.Message) error {
+	if !c.IsConnected() {
+		return ErrClosed
+	}
+
+	c.addCommonHeaders(msg)
+	if err := c.intercept(msg); err != nil {
+		c.l.Warnw("Outbound message rejected by interceptor", "msg", msg, "error", err)
+		return err
+	}
+	// Admin messages skip the budget, so they are not held back in the queue.
+	if err := c.transmit(context.Background(), msg); err != nil {
+		c.l.Errorw("Failed to send admin message", "msg", msg, "error", err)
+		return err
+	}
+
+	return nil
+}
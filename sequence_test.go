@@ -0,0 +1,183 @@
+package fix
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/quickfixgo/enum"
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/quickfix/config"
+	"github.com/quickfixgo/tag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// newKeptSeqNumsClient creates a client of g keeping its sequence numbers on
+// logon, stopped once the test is done.
+func newKeptSeqNumsClient(t *testing.T, g *testGateway) *Client {
+	t.Helper()
+
+	settings := g.settings(t)
+	settings.GlobalSettings().Set(config.ResetOnLogon, "N")
+	c, err := New(zap.NewNop().Sugar(), Config{
+		APIKey:             "key",
+		PrivateKeyFilePath: "./sample/ed25519.pem",
+		Settings:           settings,
+	}, WithResetSeqNumFlagOpt(false))
+	require.NoError(t, err)
+	t.Cleanup(c.Stop)
+	return c
+}
+
+func TestSetNextMsgSeqNumWhileStopped(t *testing.T) {
+	g := newTestGateway(t)
+	c := newKeptSeqNumsClient(t, g)
+
+	require.NoError(t, c.SetNextSenderMsgSeqNum(5))
+	require.NoError(t, c.SetNextTargetMsgSeqNum(7))
+	store, err := clientStoreFactory{factory: quickfix.NewMemoryStoreFactory(), c: c}.Create(c.sessionID)
+	require.NoError(t, err)
+	assert.Equal(t, 5, store.NextSenderMsgSeqNum())
+	assert.Equal(t, 7, store.NextTargetMsgSeqNum())
+	assert.Equal(t, 5, c.NextSenderMsgSeqNum())
+
+	require.NoError(t, c.Start(context.Background()))
+	assert.ErrorIs(t, c.SetNextSenderMsgSeqNum(1), ErrSessionStarted)
+	assert.ErrorIs(t, c.SetNextTargetMsgSeqNum(1), ErrSessionStarted)
+}
+
+func TestSetNextMsgSeqNumResetOnLogon(t *testing.T) {
+	g := newTestGateway(t)
+
+	// ResetSeqNumFlag<141> Y.
+	c := g.newClient(t)
+	assert.ErrorIs(t, c.SetNextSenderMsgSeqNum(5), ErrSeqNumsReset)
+	assert.ErrorIs(t, c.SetNextTargetMsgSeqNum(5), ErrSeqNumsReset)
+
+	// ResetOnLogon=Y.
+	c = g.newClient(t, WithResetSeqNumFlagOpt(false))
+	assert.ErrorIs(t, c.SetNextSenderMsgSeqNum(5), ErrSeqNumsReset)
+	assert.ErrorIs(t, c.SetNextTargetMsgSeqNum(5), ErrSeqNumsReset)
+}
+
+func TestSetNextMsgSeqNumLogon(t *testing.T) {
+	g := newTestGateway(t)
+	logons := make(chan int, 2)
+	g.handleAdmin(func(msg *quickfix.Message, _ quickfix.SessionID) {
+		if msg.IsMsgTypeOf(string(enum.MsgType_LOGON)) {
+			seqNum, _ := msg.Header.GetInt(tag.MsgSeqNum)
+			logons <- seqNum
+		}
+	})
+	c := newKeptSeqNumsClient(t, g)
+	ctx := context.Background()
+
+	require.NoError(t, c.Start(ctx))
+	assert.Equal(t, 1, <-logons)
+	c.Stop()
+
+	// The memory store of the next session starts over from 1, pick up
+	// where the gateway left off instead.
+	sender, target := c.NextSenderMsgSeqNum(), c.NextTargetMsgSeqNum()
+	require.Greater(t, sender, 1)
+	require.Greater(t, target, 1)
+	require.NoError(t, c.SetNextSenderMsgSeqNum(sender))
+	require.NoError(t, c.SetNextTargetMsgSeqNum(target))
+
+	require.NoError(t, c.Start(ctx))
+	assert.Equal(t, sender, <-logons)
+	_, err := c.Ping(ctx)
+	require.NoError(t, err)
+	assert.Greater(t, c.NextSenderMsgSeqNum(), sender)
+	assert.Greater(t, c.NextTargetMsgSeqNum(), target)
+}
+
+// Run with -race: the sequence numbers are read while the session sends and
+// receives.
+func TestNextMsgSeqNumWhileRunning(t *testing.T) {
+	g := newTestGateway(t)
+	c := g.startClient(t)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 20; i++ {
+			_, err := c.Ping(context.Background())
+			assert.NoError(t, err)
+		}
+	}()
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	last := 0
+	for {
+		select {
+		case <-done:
+			assert.Greater(t, c.NextSenderMsgSeqNum(), 20)
+			assert.Greater(t, c.NextTargetMsgSeqNum(), 20)
+			return
+		default:
+		}
+		next := c.NextSenderMsgSeqNum()
+		assert.GreaterOrEqual(t, next, last)
+		last = next
+		c.NextTargetMsgSeqNum()
+	}
+}
+
+func TestSendSequenceReset(t *testing.T) {
+	g := newTestGateway(t)
+	c := g.startClient(t)
+
+	type received struct {
+		msgType string
+		seqNum  int
+	}
+	var (
+		mu   sync.Mutex
+		msgs []received
+	)
+	g.handleAdmin(func(msg *quickfix.Message, _ quickfix.SessionID) {
+		msgType, _ := msg.MsgType()
+		seqNum, _ := msg.Header.GetInt(tag.MsgSeqNum)
+		mu.Lock()
+		msgs = append(msgs, received{msgType, seqNum})
+		mu.Unlock()
+	})
+
+	// Other messages are sent while the sequence is reset.
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 10; i++ {
+			_, err := c.Ping(context.Background())
+			assert.NoError(t, err)
+		}
+	}()
+	require.NoError(t, c.SendSequenceReset(100, true))
+	wg.Wait()
+	_, err := c.Ping(context.Background())
+	require.NoError(t, err)
+
+	mu.Lock()
+	defer mu.Unlock()
+	for i, msg := range msgs {
+		if msg.msgType != string(enum.MsgType_SEQUENCE_RESET) {
+			continue
+		}
+		require.Less(t, i+1, len(msgs))
+		assert.Equal(t, 100, msgs[i+1].seqNum)
+		for _, after := range msgs[i+1:] {
+			assert.GreaterOrEqual(t, after.seqNum, 100)
+		}
+		return
+	}
+	t.Fatal("SequenceReset not received")
+}
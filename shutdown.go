@@ -0,0 +1,62 @@
+package fix
+
+import (
+	"context"
+	"time"
+)
+
+// shutdownPollInterval is how often Shutdown checks whether the pending calls
+// were answered.
+const shutdownPollInterval = 10 * time.Millisecond
+
+// Shutdown stops the client gracefully: new calls fail with ErrShutdown right
+// away, the responses of the pending calls are awaited until ctx is done,
+// then the calls still pending fail with ErrShutdown and the session is
+// logged out and stopped. Calls failed this way stay checkpointed, see
+// WithCallCheckpointOpt. Unlike Stop, no waiter is left to fail with
+// ErrClosed. The client can be started again.
+func (c *Client) Shutdown(ctx context.Context) error {
+	c.shuttingDown.Store(true)
+
+	ticker := time.NewTicker(shutdownPollInterval)
+	defer ticker.Stop()
+	for c.pendingCount() > 0 {
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+	}
+
+	c.mu.Lock()
+	pending := c.pending
+	c.pending = make(map[string]*call)
+	c.mu.Unlock()
+	// Their outcome is unknown, so they stay checkpointed.
+	for _, call := range pending {
+		call.done <- ErrShutdown
+		close(call.done)
+	}
+	if len(pending) > 0 {
+		c.l.Warnw("Shutdown with calls still pending", "count", len(pending))
+	}
+
+	if !c.IsConnected() {
+		c.Stop()
+		return ctx.Err()
+	}
+	if err := c.Logout(ctx, "Shutdown"); err != nil {
+		c.Stop()
+		return err
+	}
+	return nil
+}
+
+func (c *Client) pendingCount() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return len(c.pending)
+}
@@ -0,0 +1,56 @@
+package fix
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/quickfixgo/enum"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestShutdown(t *testing.T) {
+	c := &Client{l: zap.NewNop().Sugar(), pending: make(map[string]*call)}
+	answered := &call{done: make(chan error, 1)}
+	stuck := &call{done: make(chan error, 1)}
+	c.pending["answered"] = answered
+	c.pending["stuck"] = stuck
+
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		c.forgetCall("answered", answered)
+		answered.done <- nil
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, c.Shutdown(ctx), context.DeadlineExceeded)
+
+	require.NoError(t, <-answered.done)
+	assert.ErrorIs(t, <-stuck.done, ErrShutdown)
+	assert.Zero(t, c.pendingCount())
+
+	_, err := c.send(context.Background(), c.l, "new", newTestMessage(enum.MsgType_ORDER_SINGLE))
+	assert.ErrorIs(t, err, ErrShutdown)
+}
+
+func TestShutdownKeepsPendingCallsCheckpointed(t *testing.T) {
+	cp := NewMemoryCallCheckpoint()
+	c := &Client{l: zap.NewNop().Sugar(), pending: make(map[string]*call)}
+	c.options.callCheckpoint = cp
+	stuck := &call{done: make(chan error, 1)}
+	c.pending["stuck"] = stuck
+	c.checkpointCall("stuck", string(enum.MsgType_ORDER_SINGLE), time.Now())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, c.Shutdown(ctx), context.DeadlineExceeded)
+	assert.ErrorIs(t, <-stuck.done, ErrShutdown)
+
+	calls, err := c.OutstandingCalls()
+	require.NoError(t, err)
+	require.Len(t, calls, 1)
+	assert.Equal(t, "stuck", calls[0].ID)
+}
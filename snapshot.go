@@ -0,0 +1,39 @@
+package fix
+
+import (
+	"context"
+	"sort"
+	"time"
+)
+
+// OpenOrdersSnapshot is a point-in-time view of the open orders.
+type OpenOrdersSnapshot struct {
+	Seq     uint64 // Sequence of the last order event reflected.
+	TakenAt time.Time
+	Orders  []Order // Sorted by ClientOrderID.
+}
+
+// OpenOrdersSnapshot returns the open orders as of every execution report
+// received so far. It first waits for the reports queued by the dispatcher,
+// if any, to be applied, then briefly holds back new order events so that
+// the snapshot matches a single event sequence.
+func (c *Client) OpenOrdersSnapshot(ctx context.Context) (OpenOrdersSnapshot, error) {
+	if d := c.dispatcher.Load(); d != nil {
+		if err := d.caughtUp(ctx); err != nil {
+			return OpenOrdersSnapshot{}, err
+		}
+	}
+
+	c.eventsMu.Lock()
+	snapshot := OpenOrdersSnapshot{
+		Seq:     c.tracker.LastSeq(),
+		TakenAt: time.Now(),
+		Orders:  c.tracker.OpenOrders(),
+	}
+	c.eventsMu.Unlock()
+
+	sort.Slice(snapshot.Orders, func(i, j int) bool {
+		return snapshot.Orders[i].ClientOrderID < snapshot.Orders[j].ClientOrderID
+	})
+	return snapshot, nil
+}
@@ -0,0 +1,66 @@
+package fix
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpenOrdersSnapshot(t *testing.T) {
+	c := newTestGateway(t).startClient(t)
+	for _, o := range []Order{
+		{ClientOrderID: "c", Status: OrderStatusNew},
+		{ClientOrderID: "a", Status: OrderStatusPartiallyFilled},
+		{ClientOrderID: "b", Status: OrderStatusNew},
+		{ClientOrderID: "b", Status: OrderStatusFilled},
+	} {
+		c.deliverOrder(o)
+	}
+
+	snapshot, err := c.OpenOrdersSnapshot(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, uint64(4), snapshot.Seq)
+	require.Len(t, snapshot.Orders, 2)
+	assert.Equal(t, "a", snapshot.Orders[0].ClientOrderID)
+	assert.Equal(t, "c", snapshot.Orders[1].ClientOrderID)
+}
+
+func TestOpenOrdersSnapshotConsistent(t *testing.T) {
+	const (
+		writers = 4
+		orders  = 1000
+	)
+
+	c := newTestGateway(t).startClient(t)
+	var wg sync.WaitGroup
+	for w := 0; w < writers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// Every event opens a new order.
+			for i := 0; i < orders; i++ {
+				c.deliverOrder(Order{ClientOrderID: fmt.Sprint(w, "-", i), Status: OrderStatusNew})
+			}
+		}()
+	}
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	for {
+		snapshot, err := c.OpenOrdersSnapshot(context.Background())
+		require.NoError(t, err)
+		require.Len(t, snapshot.Orders, int(snapshot.Seq))
+		select {
+		case <-done:
+			return
+		default:
+		}
+	}
+}
@@ -0,0 +1,156 @@
+package fix
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// standbyRetryInterval is the wait between two failed attempts to warm up a
+// standby session.
+const standbyRetryInterval = 5 * time.Second
+
+// StandbyFactory creates a client whose session logs on right away, see
+// NewClient. Binance identifies concurrent sessions of an API key by their
+// SenderCompID<49>, so standby sessions must not share the SenderCompID of
+// the primary.
+type StandbyFactory func(ctx context.Context) (*Client, error)
+
+// WarmStandby keeps a logged on session ready to take over, so that
+// promoting it skips the TCP, TLS and logon round trips. The standby is
+// reconnected like any session if it drops, and a new one is warmed up in
+// the background every time it is promoted.
+type WarmStandby struct {
+	l       *zap.SugaredLogger
+	factory StandbyFactory
+
+	mu      sync.Mutex
+	standby *Client
+	ready   chan struct{} // Closed once standby is set, renewed on promotion.
+	closed  bool
+
+	stop chan struct{}
+}
+
+// NewWarmStandby creates the first standby session and waits for its logon.
+func NewWarmStandby(ctx context.Context, l *zap.SugaredLogger, factory StandbyFactory) (*WarmStandby, error) {
+	standby, err := factory(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ws := &WarmStandby{
+		l:       l,
+		factory: factory,
+		standby: standby,
+		ready:   make(chan struct{}),
+		stop:    make(chan struct{}),
+	}
+	close(ws.ready)
+	return ws, nil
+}
+
+// Promote hands over the standby session and starts warming up its
+// replacement. If the previous replacement is still warming up, Promote
+// waits for it.
+func (ws *WarmStandby) Promote(ctx context.Context) (*Client, error) {
+	for {
+		ws.mu.Lock()
+		if ws.closed {
+			ws.mu.Unlock()
+			return nil, ErrClosed
+		}
+		standby, ready := ws.standby, ws.ready
+		if standby != nil {
+			ws.standby = nil
+			ws.ready = make(chan struct{})
+			ws.mu.Unlock()
+
+			go ws.warmUp()
+			// Wait for the logon in case the standby is reconnecting.
+			if err := standby.Start(ctx); err != nil {
+				return nil, err
+			}
+			return standby, nil
+		}
+		ws.mu.Unlock()
+
+		select {
+		case <-ready:
+		case <-ws.stop:
+			return nil, ErrClosed
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// PromoteOnLogout promotes the standby once primary logs out or drops, and
+// hands the promoted client, or the error promoting it, to onPromote. The
+// primary is stopped first so that it does not reconnect alongside the
+// promoted standby.
+func (ws *WarmStandby) PromoteOnLogout(primary *Client, onPromote func(c *Client, err error)) {
+	primary.mu.Lock()
+	loggedOut := primary.loggedOut
+	primary.mu.Unlock()
+
+	go func() {
+		select {
+		case <-loggedOut:
+		case <-ws.stop:
+			return
+		}
+		ws.l.Warnw("Primary session dropped, promoting standby")
+		primary.Stop()
+		ctx, cancel := context.WithTimeout(context.Background(), logonTimeout)
+		defer cancel()
+		onPromote(ws.Promote(ctx))
+	}()
+}
+
+// Close stops the standby session which has not been promoted, if any.
+func (ws *WarmStandby) Close() {
+	ws.mu.Lock()
+	if ws.closed {
+		ws.mu.Unlock()
+		return
+	}
+	ws.closed = true
+	standby := ws.standby
+	ws.standby = nil
+	close(ws.stop)
+	ws.mu.Unlock()
+
+	if standby != nil {
+		standby.Stop()
+	}
+}
+
+// warmUp creates a standby session, retrying until it succeeds or ws is
+// closed.
+func (ws *WarmStandby) warmUp() {
+	for {
+		standby, err := ws.factory(context.Background())
+		if err == nil {
+			ws.mu.Lock()
+			if ws.closed {
+				ws.mu.Unlock()
+				standby.Stop()
+				return
+			}
+			ws.standby = standby
+			close(ws.ready)
+			ws.mu.Unlock()
+			return
+		}
+
+		ws.l.Warnw("Failed to warm up standby session", "error", err)
+		select {
+		case <-time.After(standbyRetryInterval):
+		case <-ws.stop:
+			return
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package fix
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/quickfixgo/enum"
+	"github.com/quickfixgo/field"
+	"github.com/quickfixgo/quickfix"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestPromoteOnLogoutStopsPrimary(t *testing.T) {
+	g := newTestGateway(t)
+	fastReconnect := WithReconnectPolicyOpt(ReconnectPolicy{MinDelay: 10 * time.Millisecond, MaxDelay: 10 * time.Millisecond})
+	primary := g.startClient(t, fastReconnect)
+
+	ws, err := NewWarmStandby(context.Background(), zap.NewNop().Sugar(), func(ctx context.Context) (*Client, error) {
+		c := g.newClientAs(t, "STANDBY", fastReconnect)
+		return c, c.Start(ctx)
+	})
+	require.NoError(t, err)
+	t.Cleanup(ws.Close)
+
+	promoted := make(chan *Client, 1)
+	ws.PromoteOnLogout(primary, func(c *Client, err error) {
+		assert.NoError(t, err)
+		promoted <- c
+	})
+
+	logout := quickfix.NewMessage()
+	logout.Header.Set(field.NewMsgType(enum.MsgType_LOGOUT))
+	g.reply(t, quickfix.SessionID{BeginString: quickfix.BeginStringFIX44, SenderCompID: "SPOT", TargetCompID: "EXAMPLE"}, logout)
+
+	select {
+	case c := <-promoted:
+		assert.Equal(t, "STANDBY", c.senderCompID)
+		assert.True(t, c.IsConnected())
+	case <-time.After(5 * time.Second):
+		t.Fatal("standby not promoted")
+	}
+	assert.Never(t, primary.IsConnected, 3*time.Second, 10*time.Millisecond)
+}
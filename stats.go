@@ -0,0 +1,95 @@
+package fix
+
+import (
+	"time"
+
+	"github.com/quickfixgo/enum"
+	"github.com/quickfixgo/field"
+	"github.com/quickfixgo/quickfix"
+)
+
+// Stats is a point-in-time snapshot of the client's request/response matching.
+type Stats struct {
+	PendingCalls       int           // Calls waiting for a response.
+	OldestPendingAge   time.Duration // Age of the oldest pending call.
+	MatchedResponses   uint64
+	UnmatchedResponses uint64 // Responses to requests with no pending call.
+	LastMatchLatency   time.Duration
+	AvgMatchLatency    time.Duration
+
+	// ClockSkew is the estimated offset of the server clock from the local
+	// one, positive when the server is ahead. It is biased down by the
+	// inbound latency and zero until a message has been received.
+	ClockSkew     time.Duration
+	LastClockSkew time.Duration // Raw sample of the last inbound message.
+}
+
+// callStats accumulates matching telemetry, guarded by Client.mu.
+type callStats struct {
+	matched          uint64
+	unmatched        uint64
+	lastMatchLatency time.Duration
+	sumMatchLatency  time.Duration
+}
+
+// Stats returns a snapshot of the client telemetry.
+func (c *Client) Stats() Stats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	stats := Stats{
+		PendingCalls:       len(c.pending),
+		MatchedResponses:   c.stats.matched,
+		UnmatchedResponses: c.stats.unmatched,
+		LastMatchLatency:   c.stats.lastMatchLatency,
+	}
+	if c.stats.matched > 0 {
+		stats.AvgMatchLatency = c.stats.sumMatchLatency / time.Duration(c.stats.matched)
+	}
+
+	stats.ClockSkew, _ = c.skew.estimate()
+	stats.LastClockSkew = c.skew.lastSample()
+
+	now := time.Now()
+	for _, call := range c.pending {
+		if age := now.Sub(call.sentAt); age > stats.OldestPendingAge {
+			stats.OldestPendingAge = age
+		}
+	}
+
+	return stats
+}
+
+// recordMatch must be called with c.mu held.
+func (c *Client) recordMatch(call *call) {
+	latency := time.Since(call.sentAt)
+	c.stats.matched++
+	c.stats.lastMatchLatency = latency
+	c.stats.sumMatchLatency += latency
+}
+
+// recordUnmatched counts msg as unmatched if it is the direct answer to a
+// request, as opposed to a follow-up update such as a fill. Must be called
+// with c.mu held.
+func (c *Client) recordUnmatched(msg *quickfix.Message) {
+	if isDirectResponse(msg) {
+		c.stats.unmatched++
+	}
+}
+
+func isDirectResponse(msg *quickfix.Message) bool {
+	if !msg.IsMsgTypeOf(string(enum.MsgType_EXECUTION_REPORT)) {
+		return true
+	}
+
+	var execType field.ExecTypeField
+	if err := msg.Body.Get(&execType); err != nil {
+		return false
+	}
+	switch execType.Value() {
+	case enum.ExecType_NEW, enum.ExecType_REJECTED:
+		return true
+	default:
+		return false
+	}
+}
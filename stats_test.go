@@ -0,0 +1,52 @@
+package fix
+
+import (
+	"testing"
+	"time"
+
+	"github.com/quickfixgo/enum"
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/tag"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStats(t *testing.T) {
+	report := func(clOrdID string, status enum.OrdStatus, execType enum.ExecType) *quickfix.Message {
+		msg := quickfix.NewMessage()
+		msg.Header.SetString(tag.BeginString, quickfix.BeginStringFIX44)
+		msg.Header.SetString(tag.MsgType, string(enum.MsgType_EXECUTION_REPORT))
+		msg.Body.SetString(tag.Symbol, "BTCUSDT")
+		msg.Body.SetString(tag.OrderID, "1")
+		msg.Body.SetString(tag.ClOrdID, clOrdID)
+		msg.Body.SetString(tag.OrdStatus, string(status))
+		msg.Body.SetString(tag.ExecType, string(execType))
+		msg.Body.SetString(tag.OrdType, string(enum.OrdType_MARKET))
+		msg.Body.SetString(tag.Side, string(enum.Side_BUY))
+		return msg
+	}
+
+	g := newTestGateway(t)
+	c := g.startClient(t)
+	c.mu.Lock()
+	c.pending["a"] = &call{l: c.l, sentAt: time.Now(), done: make(chan error, 1)}
+	c.mu.Unlock()
+	time.Sleep(time.Millisecond)
+
+	stats := c.Stats()
+	assert.Equal(t, 1, stats.PendingCalls)
+	assert.Positive(t, stats.OldestPendingAge)
+
+	c.FromApp(report("a", enum.OrdStatus_NEW, enum.ExecType_NEW), quickfix.SessionID{})
+
+	// An ack nobody waits for is unmatched, a fill is a follow-up update.
+	c.FromApp(report("b", enum.OrdStatus_NEW, enum.ExecType_NEW), quickfix.SessionID{})
+	c.FromApp(report("c", enum.OrdStatus_FILLED, enum.ExecType_TRADE), quickfix.SessionID{})
+
+	stats = c.Stats()
+	assert.Zero(t, stats.PendingCalls)
+	assert.Zero(t, stats.OldestPendingAge)
+	assert.Equal(t, uint64(1), stats.MatchedResponses)
+	assert.Equal(t, uint64(1), stats.UnmatchedResponses)
+	assert.Positive(t, stats.LastMatchLatency)
+	assert.Equal(t, stats.LastMatchLatency, stats.AvgMatchLatency)
+}
@@ -0,0 +1,69 @@
+package fix
+
+import (
+	"github.com/KyberNetwork/binance_fix_api/binancetag"
+	"github.com/quickfixgo/quickfix"
+)
+
+// SelfTradePreventionMode<25001> decides what happens when an order would
+// match another order of the same account.
+type SelfTradePreventionMode string
+
+const (
+	SelfTradePreventionModeNone        SelfTradePreventionMode = "1"
+	SelfTradePreventionModeExpireTaker SelfTradePreventionMode = "2"
+	SelfTradePreventionModeExpireMaker SelfTradePreventionMode = "3"
+	SelfTradePreventionModeExpireBoth  SelfTradePreventionMode = "4"
+)
+
+// WithSelfTradePreventionModeOpt sends mode with every new order which does
+// not set its own. The server applies the default mode of the account
+// otherwise.
+func WithSelfTradePreventionModeOpt(mode SelfTradePreventionMode) NewClientOption {
+	return func(o *Options) {
+		o.selfTradePreventionMode = mode
+	}
+}
+
+// PreventedMatch describes the match an order of the account was prevented
+// from making with another of its orders by self-trade prevention.
+type PreventedMatch struct {
+	ID             int64   // PreventedMatchID<25024>
+	ExecutionPrice float64 // PreventedExecutionPrice<25025>
+	ExecutionQty   float64 // PreventedExecutionQty<25026>
+	TradeGroupID   int64   // TradeGroupID<25027>
+	CounterSymbol  string  // CounterSymbol<25028>
+	CounterOrderID int64   // CounterOrderID<25029>, the other order of the match.
+}
+
+// decodePreventedMatch returns the prevented match reported by an
+// ExecutionReport<8>, nil if there is none.
+func decodePreventedMatch(msg *quickfix.Message) (*PreventedMatch, error) {
+	if !msg.Body.Has(binancetag.PreventedMatchID) {
+		return nil, nil
+	}
+
+	var (
+		m   PreventedMatch
+		err error
+	)
+	if m.ID, err = binancetag.GetInt64(msg, binancetag.PreventedMatchID); err != nil {
+		return nil, err
+	}
+	if m.ExecutionPrice, err = binancetag.GetFloat(msg, binancetag.PreventedExecutionPrice); err != nil {
+		return nil, err
+	}
+	if m.ExecutionQty, err = binancetag.GetFloat(msg, binancetag.PreventedExecutionQty); err != nil {
+		return nil, err
+	}
+	if m.TradeGroupID, err = binancetag.GetInt64(msg, binancetag.TradeGroupID); err != nil {
+		return nil, err
+	}
+	if m.CounterSymbol, err = binancetag.GetString(msg, binancetag.CounterSymbol); err != nil {
+		return nil, err
+	}
+	if m.CounterOrderID, err = binancetag.GetInt64(msg, binancetag.CounterOrderID); err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
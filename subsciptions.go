@@ -5,3 +5,13 @@ type ExecutionReportHandler func(o *Order)
 func (c *Client) SubscribeToExecutionReport(listener ExecutionReportHandler) {
 	c.emitter.On(ExecutionReportTopic, listener)
 }
+
+func (c *Client) SubscribeToNews(listener NewsHandler) {
+	c.emitter.On(NewsTopic, listener)
+}
+
+// SubscribeToInternalErrors registers listener for the internal errors
+// escalated under EscalationEmitEvent.
+func (c *Client) SubscribeToInternalErrors(listener InternalErrorHandler) {
+	c.emitter.On(InternalErrorTopic, listener)
+}
@@ -0,0 +1,110 @@
+package fix
+
+import "sync"
+
+// defaultMaxTerminalOrders bounds how many finished orders a tracker keeps.
+const defaultMaxTerminalOrders = 10000
+
+// OrderTracker keeps the latest known state of every order, keyed by client
+// order ID, by applying order events in sequence. Events already applied are
+// ignored so the tracker can be rebuilt from an EventLog at any point.
+type OrderTracker struct {
+	mu       sync.RWMutex
+	orders   map[string]Order
+	terminal []string        // Client order IDs of finished orders, oldest first.
+	finished map[string]bool // The IDs in terminal.
+	maxTerm  int
+	lastSeq  uint64
+}
+
+func NewOrderTracker() *OrderTracker {
+	return &OrderTracker{
+		orders:   make(map[string]Order),
+		finished: make(map[string]bool),
+		maxTerm:  defaultMaxTerminalOrders,
+	}
+}
+
+// Apply updates the tracker with e unless it was already applied.
+func (t *OrderTracker) Apply(e OrderEvent) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if e.Seq != 0 && e.Seq <= t.lastSeq {
+		return
+	}
+	if e.Seq != 0 {
+		t.lastSeq = e.Seq
+	}
+
+	id := e.Order.trackedID()
+	if orig := e.Order.OrigClientOrderID; orig != "" && orig != id && e.Order.ExecType == ExecTypeReplaced {
+		// The order was amended and is known by its new ClOrdID<11> from now on.
+		delete(t.orders, orig)
+	}
+	t.orders[id] = e.Order
+
+	if e.Order.Status.IsTerminal() && !t.finished[id] {
+		t.finished[id] = true
+		t.terminal = append(t.terminal, id)
+		for len(t.terminal) > t.maxTerm {
+			oldest := t.terminal[0]
+			t.terminal = t.terminal[1:]
+			delete(t.finished, oldest)
+			// A late report may have reopened it.
+			if o, ok := t.orders[oldest]; ok && o.Status.IsTerminal() {
+				delete(t.orders, oldest)
+			}
+		}
+	}
+}
+
+// trackedID returns the client order ID of the order an execution report is
+// about. The report of a cancel carries the ClOrdID<11> of the cancel request
+// and the one of the canceled order as OrigClOrdID<41>, while an amended order
+// takes the ClOrdID of the amend request.
+func (o Order) trackedID() string {
+	if o.OrigClientOrderID != "" && o.ExecType == ExecTypeCanceled {
+		return o.OrigClientOrderID
+	}
+	return o.ClientOrderID
+}
+
+// Rebuild applies every event of log the tracker has not seen yet.
+func (t *OrderTracker) Rebuild(log EventLog) error {
+	return log.Replay(t.LastSeq()+1, func(e OrderEvent) error {
+		t.Apply(e)
+		return nil
+	})
+}
+
+// LastSeq returns the sequence of the last applied event.
+func (t *OrderTracker) LastSeq() uint64 {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	return t.lastSeq
+}
+
+// Order returns the latest state of the order with the given client order ID.
+func (t *OrderTracker) Order(clientOrderID string) (Order, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	o, ok := t.orders[clientOrderID]
+	return o, ok
+}
+
+// OpenOrders returns all orders which have not reached a terminal state.
+func (t *OrderTracker) OpenOrders() []Order {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	orders := make([]Order, 0)
+	for _, o := range t.orders {
+		if !o.Status.IsTerminal() {
+			orders = append(orders, o)
+		}
+	}
+	return orders
+}
@@ -0,0 +1,105 @@
+package fix
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrderTrackerRebuild(t *testing.T) {
+	log, err := NewFileEventLog(filepath.Join(t.TempDir(), "events.log"), false)
+	require.NoError(t, err)
+	defer log.Close()
+
+	events := []OrderEvent{
+		{Seq: 1, Order: Order{ClientOrderID: "a", Status: OrderStatusNew}},
+		{Seq: 2, Order: Order{ClientOrderID: "b", Status: OrderStatusNew}},
+		{Seq: 3, Order: Order{ClientOrderID: "a", Status: OrderStatusFilled}},
+	}
+	for _, e := range events {
+		require.NoError(t, log.Append(e))
+	}
+
+	tracker := NewOrderTracker()
+	tracker.Apply(events[0])
+	require.NoError(t, tracker.Rebuild(log))
+	assert.Equal(t, uint64(3), tracker.LastSeq())
+
+	a, ok := tracker.Order("a")
+	require.True(t, ok)
+	assert.Equal(t, OrderStatusFilled, a.Status)
+	assert.Equal(t, []Order{events[1].Order}, tracker.OpenOrders())
+
+	// Events already applied are ignored.
+	tracker.Apply(OrderEvent{Seq: 2, Order: Order{ClientOrderID: "b", Status: OrderStatusCanceled}})
+	b, _ := tracker.Order("b")
+	assert.Equal(t, OrderStatusNew, b.Status)
+}
+
+func TestOrderTrackerCancelAndAmend(t *testing.T) {
+	tracker := NewOrderTracker()
+	tracker.Apply(OrderEvent{Order: Order{ClientOrderID: "a", Status: OrderStatusNew}})
+	tracker.Apply(OrderEvent{Order: Order{ClientOrderID: "b", Status: OrderStatusNew}})
+
+	// The cancel report carries the ClOrdID of the cancel request.
+	tracker.Apply(OrderEvent{Order: Order{
+		ClientOrderID:     "cancel-a",
+		OrigClientOrderID: "a",
+		ExecType:          ExecTypeCanceled,
+		Status:            OrderStatusCanceled,
+	}})
+	a, ok := tracker.Order("a")
+	require.True(t, ok)
+	assert.Equal(t, OrderStatusCanceled, a.Status)
+	_, ok = tracker.Order("cancel-a")
+	assert.False(t, ok)
+
+	// An amended order is known by the ClOrdID of the amend request.
+	tracker.Apply(OrderEvent{Order: Order{
+		ClientOrderID:     "amend-b",
+		OrigClientOrderID: "b",
+		ExecType:          ExecTypeReplaced,
+		Status:            OrderStatusNew,
+	}})
+	_, ok = tracker.Order("b")
+	assert.False(t, ok)
+	open := tracker.OpenOrders()
+	require.Len(t, open, 1)
+	assert.Equal(t, "amend-b", open[0].ClientOrderID)
+}
+
+func TestWatchersNotifyOrigClOrdID(t *testing.T) {
+	var ws orderWatchers
+	w := ws.add("a")
+	defer ws.remove(w)
+
+	ws.notify(Order{ClientOrderID: "cancel-a", OrigClientOrderID: "a", Status: OrderStatusCanceled})
+	o, err := w.next(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, OrderStatusCanceled, o.Status)
+}
+
+func TestOrderTrackerDuplicateTerminalReport(t *testing.T) {
+	tracker := NewOrderTracker()
+	tracker.maxTerm = 2
+	tracker.Apply(OrderEvent{Order: Order{ClientOrderID: "a", Status: OrderStatusNew}})
+	tracker.Apply(OrderEvent{Order: Order{ClientOrderID: "a", Status: OrderStatusFilled}})
+	tracker.Apply(OrderEvent{Order: Order{ClientOrderID: "a", Status: OrderStatusFilled}})
+	// A late report reopens the order, which then finishes again.
+	tracker.Apply(OrderEvent{Order: Order{ClientOrderID: "a", Status: OrderStatusPartiallyFilled}})
+	tracker.Apply(OrderEvent{Order: Order{ClientOrderID: "a", Status: OrderStatusFilled}})
+	assert.Equal(t, []string{"a"}, tracker.terminal)
+	assert.Empty(t, tracker.OpenOrders())
+
+	tracker.Apply(OrderEvent{Order: Order{ClientOrderID: "b", Status: OrderStatusNew}})
+	assert.Equal(t, []Order{{ClientOrderID: "b", Status: OrderStatusNew}}, tracker.OpenOrders())
+
+	tracker.Apply(OrderEvent{Order: Order{ClientOrderID: "c", Status: OrderStatusCanceled}})
+	tracker.Apply(OrderEvent{Order: Order{ClientOrderID: "d", Status: OrderStatusCanceled}})
+	_, ok := tracker.Order("a")
+	assert.False(t, ok)
+	assert.Len(t, tracker.OpenOrders(), 1)
+}
@@ -0,0 +1,66 @@
+package fix
+
+import (
+	"github.com/KyberNetwork/binance_fix_api/binancetag"
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/tag"
+)
+
+// UnknownEnumHandler is called with the tag and raw value of every enum
+// field of an execution report this package does not know yet, e.g. an
+// OrdStatus<39> newly introduced by Binance.
+type UnknownEnumHandler func(t quickfix.Tag, value string, order *Order)
+
+// WithUnknownEnumHandlerOpt sets handler to be called on unknown enum
+// values, on top of the warning logged for them.
+func WithUnknownEnumHandlerOpt(handler UnknownEnumHandler) NewClientOption {
+	return func(o *Options) {
+		o.unknownEnumHandler = handler
+	}
+}
+
+// lookupEnum maps a FIX enum value, to unknown if it is not in m. An empty
+// value, i.e. an absent field, maps to the zero value.
+func lookupEnum[K, V ~string](m map[K]V, value K, unknown V) V {
+	if value == "" {
+		return ""
+	}
+	if v, ok := m[value]; ok {
+		return v
+	}
+	return unknown
+}
+
+// unknownValues returns the raw values of the enum fields of order set to
+// their Unknown constant, nil if there is none.
+func unknownValues(msg *quickfix.Message, order Order) map[quickfix.Tag]string {
+	var values map[quickfix.Tag]string
+	for t, unknown := range map[quickfix.Tag]bool{
+		tag.OrdStatus:           order.Status == OrderStatusUnknown,
+		tag.ExecType:            order.ExecType == ExecTypeUnknown,
+		tag.TimeInForce:         order.TimeInForce == TimeInForceUnknown,
+		tag.OrdType:             order.Type == OrderTypeUnknown,
+		tag.Side:                order.Side == SideTypeUnknown,
+		binancetag.WorkingFloor: order.WorkingFloor == WorkingFloorUnknown,
+	} {
+		if !unknown {
+			continue
+		}
+		if values == nil {
+			values = make(map[quickfix.Tag]string)
+		}
+		values[t], _ = binancetag.GetString(msg, t)
+	}
+	return values
+}
+
+// reportUnknownValues warns about the unknown enum values of order.
+func (c *Client) reportUnknownValues(order *Order) {
+	for t, value := range order.UnknownValues {
+		c.l.Warnw("Unknown enum value in execution report",
+			"tag", t, "value", value, "clOrdID", order.ClientOrderID)
+		if h := c.options.unknownEnumHandler; h != nil {
+			h(t, value, order)
+		}
+	}
+}
@@ -0,0 +1,37 @@
+package fix
+
+import (
+	"testing"
+
+	"github.com/quickfixgo/enum"
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/tag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestDecodeUnknownEnumValues(t *testing.T) {
+	msg := newTestReport("a", "Z")
+	msg.Body.SetString(tag.TimeInForce, "9")
+
+	order, err := decodeExecutionReport(msg)
+	require.NoError(t, err)
+	assert.Equal(t, OrderStatusUnknown, order.Status)
+	assert.Equal(t, TimeInForceUnknown, order.TimeInForce)
+	assert.Equal(t, OrderTypeMarket, order.Type)
+	assert.Empty(t, order.ExecType)
+	assert.Equal(t, map[quickfix.Tag]string{tag.OrdStatus: "Z", tag.TimeInForce: "9"}, order.UnknownValues)
+
+	var reported []quickfix.Tag
+	c := &Client{l: zap.NewNop().Sugar()}
+	WithUnknownEnumHandlerOpt(func(t quickfix.Tag, value string, o *Order) {
+		reported = append(reported, t)
+	})(&c.options)
+	c.reportUnknownValues(&order)
+	assert.ElementsMatch(t, []quickfix.Tag{tag.OrdStatus, tag.TimeInForce}, reported)
+
+	order, err = decodeExecutionReport(newTestReport("a", enum.OrdStatus_NEW))
+	require.NoError(t, err)
+	assert.Nil(t, order.UnknownValues)
+}
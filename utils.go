@@ -9,28 +9,46 @@ import (
 	"encoding/pem"
 	"errors"
 	"io"
+	"io/fs"
 	"os"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/KyberNetwork/binance_fix_api/binancetag"
 	"github.com/quickfixgo/enum"
 	"github.com/quickfixgo/field"
 	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/tag"
+	"go.uber.org/zap"
 )
 
 const (
-	utcTimestampMillisFmt = "20060102-15:04:05.000"
-	utcTimestampMicrosFmt = "20060102-15:04:05.000000"
-	blockTypePrivateKey   = "PRIVATE KEY"
+	utcTimestampSecondsFmt = "20060102-15:04:05"
+	utcTimestampMillisFmt  = "20060102-15:04:05.000"
+	utcTimestampMicrosFmt  = "20060102-15:04:05.000000"
+	utcTimestampNanosFmt   = "20060102-15:04:05.000000000"
+	blockTypePrivateKey    = "PRIVATE KEY"
 )
 
 var (
-	ErrClosed = errors.New("connection is closed")
+	ErrClosed    = errors.New("connection is closed")
+	ErrNoSession = errors.New("session has not been created")
 
 	ErrNilPrivateKeyValue  = errors.New("nil private key value")
 	ErrInvalidEd25519Key   = errors.New("invalid key ed25519 key")
 	ErrInvalidRequestIDTag = errors.New("request id tag not found")
+
+	ErrInvalidTimestampPrecision = errors.New("invalid timestamp precision")
+	ErrNoEventLog                = errors.New("no event log configured")
+	ErrMaintenance               = errors.New("new orders are paused for exchange maintenance")
+	ErrDuplicateID               = errors.New("a call with the same id is pending")
+	ErrPostOnlyWouldTake         = errors.New("post-only order would take liquidity")
+	ErrInvalidOrder              = errors.New("invalid order")
+	ErrShutdown                  = errors.New("client is shutting down")
+	ErrSessionStale              = errors.New("no message received from the server")
+	ErrSessionStarted            = errors.New("session is started, stop the client first")
+	ErrSeqNumsReset              = errors.New("sequence numbers are reset on logon")
 )
 
 func ParseEd25519PrivateKey(data []byte) (ed25519.PrivateKey, error) {
@@ -79,45 +97,77 @@ func GetLogonRawData(
 	return base64.StdEncoding.EncodeToString(data)
 }
 
+// SendingTimeNow returns the current time as a UTCTimestamp in millis. The
+// client itself stamps SendingTime<52> with Config.TimestampPrecision.
 func SendingTimeNow() string {
 	return time.Now().UTC().Format(utcTimestampMillisFmt)
 }
 
-func copyMessage(msg *quickfix.Message) (*quickfix.Message, error) {
-	out := quickfix.NewMessage()
-	err := quickfix.ParseMessage(out, bytes.NewBufferString(msg.String()))
-	if err != nil {
-		return nil, err
+// formatUTCTimestamp formats t the way quickfix does for the given precision.
+func formatUTCTimestamp(t time.Time, precision quickfix.TimestampPrecision) string {
+	switch precision {
+	case quickfix.Seconds:
+		return t.UTC().Format(utcTimestampSecondsFmt)
+	case quickfix.Micros:
+		return t.UTC().Format(utcTimestampMicrosFmt)
+	case quickfix.Nanos:
+		return t.UTC().Format(utcTimestampNanosFmt)
+	default:
+		return t.UTC().Format(utcTimestampMillisFmt)
 	}
-	return out, nil
 }
 
 type call struct {
+	l        *zap.SugaredLogger
 	request  *quickfix.Message
 	response *quickfix.Message
+	seqNum   int // MsgSeqNum<34> assigned to the request once sent.
+	sentAt   time.Time
 	done     chan error
 }
 
 type waiter struct {
 	*call
+	spin time.Duration // Busy-poll for the response this long before parking.
 }
 
 // wait for the response message of an ongoing FIX call.
 func (w waiter) wait(ctx context.Context) (*quickfix.Message, error) {
+	if w.spin > 0 {
+		deadline := time.Now().Add(w.spin)
+		for {
+			select {
+			case err, ok := <-w.call.done:
+				return w.result(err, ok)
+			default:
+			}
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			if time.Now().After(deadline) {
+				break
+			}
+		}
+	}
+
 	select {
 	case err, ok := <-w.call.done:
-		if !ok {
-			err = ErrClosed
-		}
-		if err != nil {
-			return nil, err
-		}
-		return w.call.response, nil
+		return w.result(err, ok)
 	case <-ctx.Done():
 		return nil, ctx.Err()
 	}
 }
 
+func (w waiter) result(err error, ok bool) (*quickfix.Message, error) {
+	if !ok {
+		err = ErrClosed
+	}
+	if err != nil {
+		return nil, err
+	}
+	return w.call.response, nil
+}
+
 func LoadQuickfixSettings(filePath string) (*quickfix.Settings, error) {
 	cfg, err := os.Open(filePath)
 	if err != nil {
@@ -125,17 +175,47 @@ func LoadQuickfixSettings(filePath string) (*quickfix.Settings, error) {
 	}
 	defer cfg.Close()
 
-	data, err := io.ReadAll(cfg)
+	return LoadQuickfixSettingsFromReader(cfg)
+}
+
+// LoadQuickfixSettingsFromReader parses quickfix settings read from r.
+func LoadQuickfixSettingsFromReader(r io.Reader) (*quickfix.Settings, error) {
+	return quickfix.ParseSettings(r)
+}
+
+// ParseQuickfixSettings parses quickfix settings held in data.
+func ParseQuickfixSettings(data []byte) (*quickfix.Settings, error) {
+	return quickfix.ParseSettings(bytes.NewReader(data))
+}
+
+// LoadQuickfixSettingsFS parses the quickfix settings file at path in fsys,
+// e.g. an embed.FS.
+func LoadQuickfixSettingsFS(fsys fs.FS, path string) (*quickfix.Settings, error) {
+	cfg, err := fsys.Open(path)
 	if err != nil {
 		return nil, err
 	}
+	defer cfg.Close()
 
-	appSettings, err := quickfix.ParseSettings(bytes.NewReader(data))
-	if err != nil {
-		return nil, err
+	return LoadQuickfixSettingsFromReader(cfg)
+}
+
+// customFields are raw body fields set through the SetField escape hatch of
+// service builders.
+type customFields map[quickfix.Tag]string
+
+func (f *customFields) set(t quickfix.Tag, value string) {
+	if *f == nil {
+		*f = make(customFields)
 	}
+	(*f)[t] = value
+}
 
-	return appSettings, nil
+// apply sets the custom fields on msg, overriding fields set by the builder.
+func (f customFields) apply(msg *quickfix.Message) {
+	for t, value := range f {
+		msg.Body.SetString(t, value)
+	}
 }
 
 func floatToString(f float64) string {
@@ -146,34 +226,89 @@ type Order struct {
 	Symbol            string
 	OrderID           int64
 	ClientOrderID     string
+	OrigClientOrderID string // OrigClOrdID<41> of the order canceled or replaced.
+	ListID            string // ListID<66> of the order list the order is part of.
 	Price             float64
 	OrderQty          float64
+	CashOrderQty      float64 // Quote quantity of a quote-quantity order.
 	CumQty            float64
 	CumQuoteQty       float64
 	Status            OrderStatus
+	ExecType          ExecType
+	ExecID            string
+	LastPx            float64 // Price of the fill reported, if ExecType is TRADE.
+	LastQty           float64 // Quantity of the fill reported, if ExecType is TRADE.
+	TradeID           int64   // TradeID<1003> of the fill reported, 0 if absent.
+	Aggressor         bool    // The fill reported took liquidity: a taker fill.
 	TimeInForce       TimeInForce
 	Type              OrderType
 	Side              SideType
 	IcebergQuantity   float64
+	TriggerPrice      float64
+	TrailingDeltaBps  int       // TriggerTrailingDeltaBps<25009> of a trailing stop.
+	TrailingTime      time.Time // When a trailing stop started tracking the price.
+	PostOnly          bool      // ExecInst<18> is PARTICIPATE_DONT_INITIATE.
 	TransactTime      time.Time // Timestamp when this event occurred.
 	OrderCreationTime time.Time
 	WorkingTime       time.Time // When this order appeared on the order book.
+
+	WorkingFloor WorkingFloor `json:",omitempty"`
+	UsedSOR      bool         // The order was placed through smart order routing.
+
+	SelfTradePreventionMode SelfTradePreventionMode `json:",omitempty"`
+	PreventedQty            float64                 // Quantity expired by self-trade prevention.
+	LastPreventedQty        float64                 // Quantity expired by the prevented match reported.
+	PreventedMatch          *PreventedMatch         `json:",omitempty"`
+
+	// UnknownValues holds the raw value of the enum fields above set to
+	// their Unknown constant, by tag.
+	UnknownValues map[quickfix.Tag]string `json:",omitempty"`
+
+	// Exact values of the amounts above, nil unless decoded from a message.
+	Exact *ExactAmounts `json:",omitempty"`
+
+	// ExtraFields holds the body fields not decoded above, such as tags
+	// newly added by Binance.
+	ExtraFields map[quickfix.Tag]string `json:",omitempty"`
+
+	// Exchange strings the times above were parsed from, empty if absent.
+	TransactTimeRaw      string
+	OrderCreationTimeRaw string
+	WorkingTimeRaw       string
+
+	// Local time the message was read from the socket, with a monotonic clock
+	// reading, so time.Since(ReceivedAt) measures the internal queuing delay.
+	ReceivedAt time.Time
+
+	PossDup  bool // Resent by the server after a ResendRequest.
+	Replayed bool // Replayed from the event log, not received live.
+
+	// Raw is the FIX message the order was decoded from, to read the fields
+	// not mapped above, only set with WithRawMessageOpt. See RawMessage.
+	Raw string `json:",omitempty"`
+
+	// Metadata attached with ContextWithMetadata to the context the order
+	// was placed with.
+	Metadata Metadata `json:",omitempty"`
+
+	backlogSeq uint64 // Position in the event backlog, zero without one.
 }
 
+// decodeExecutionReport decodes msg into an Order. A rejected order is
+// returned along with an *OrderRejectedError.
 func decodeExecutionReport(msg *quickfix.Message) (Order, error) {
 	status, err := getOrderStatus(msg)
 	if err != nil {
 		return Order{}, err
 	}
 
+	var rejErr error
 	if status == OrderStatusRejected {
-		reason, err := getText(msg)
+		e, err := decodeOrderReject(msg)
 		if err != nil {
 			return Order{}, err
 		}
-		if reason != "" {
-			return Order{}, errors.New(reason)
-		}
+		rejErr = e
 	}
 
 	symbol, err := getSymbol(msg)
@@ -191,6 +326,16 @@ func decodeExecutionReport(msg *quickfix.Message) (Order, error) {
 		return Order{}, err
 	}
 
+	origClientOrderID, err := binancetag.GetString(msg, tag.OrigClOrdID)
+	if err != nil {
+		return Order{}, err
+	}
+
+	listID, err := binancetag.GetString(msg, tag.ListID)
+	if err != nil {
+		return Order{}, err
+	}
+
 	price, err := getPrice(msg)
 	if err != nil {
 		return Order{}, err
@@ -201,6 +346,41 @@ func decodeExecutionReport(msg *quickfix.Message) (Order, error) {
 		return Order{}, err
 	}
 
+	execType, err := binancetag.GetString(msg, tag.ExecType)
+	if err != nil {
+		return Order{}, err
+	}
+
+	execID, err := binancetag.GetString(msg, tag.ExecID)
+	if err != nil {
+		return Order{}, err
+	}
+
+	lastPx, err := binancetag.GetFloat(msg, tag.LastPx)
+	if err != nil {
+		return Order{}, err
+	}
+
+	lastQty, err := binancetag.GetFloat(msg, tag.LastQty)
+	if err != nil {
+		return Order{}, err
+	}
+
+	tradeID, err := getTradeID(msg)
+	if err != nil {
+		return Order{}, err
+	}
+
+	aggressor, err := getAggressor(msg)
+	if err != nil {
+		return Order{}, err
+	}
+
+	cashOrderQty, err := getCashOrderQty(msg)
+	if err != nil {
+		return Order{}, err
+	}
+
 	cumQty, err := getCumQty(msg)
 	if err != nil {
 		return Order{}, err
@@ -231,6 +411,61 @@ func decodeExecutionReport(msg *quickfix.Message) (Order, error) {
 		return Order{}, err
 	}
 
+	triggerPrice, err := binancetag.GetFloat(msg, tag.TriggerPrice)
+	if err != nil {
+		return Order{}, err
+	}
+
+	trailingDelta, err := binancetag.GetInt(msg, binancetag.TriggerTrailingDeltaBps)
+	if err != nil {
+		return Order{}, err
+	}
+
+	trailingTime, err := binancetag.GetTrailingTime(msg)
+	if err != nil {
+		return Order{}, err
+	}
+
+	workingFloor, err := binancetag.GetString(msg, binancetag.WorkingFloor)
+	if err != nil {
+		return Order{}, err
+	}
+
+	usedSOR, err := getUsedSOR(msg)
+	if err != nil {
+		return Order{}, err
+	}
+
+	execInst, err := binancetag.GetString(msg, tag.ExecInst)
+	if err != nil {
+		return Order{}, err
+	}
+
+	stpMode, err := binancetag.GetString(msg, binancetag.SelfTradePreventionMode)
+	if err != nil {
+		return Order{}, err
+	}
+
+	preventedQty, err := binancetag.GetFloat(msg, binancetag.PreventedQty)
+	if err != nil {
+		return Order{}, err
+	}
+
+	lastPreventedQty, err := binancetag.GetFloat(msg, binancetag.LastPreventedQty)
+	if err != nil {
+		return Order{}, err
+	}
+
+	preventedMatch, err := decodePreventedMatch(msg)
+	if err != nil {
+		return Order{}, err
+	}
+
+	exact, err := decodeExactAmounts(msg)
+	if err != nil {
+		return Order{}, err
+	}
+
 	transactTime, err := getTransactTime(msg)
 	if err != nil {
 		return Order{}, err
@@ -246,23 +481,72 @@ func decodeExecutionReport(msg *quickfix.Message) (Order, error) {
 		return Order{}, err
 	}
 
-	return Order{
+	transactTimeRaw, err := binancetag.GetString(msg, tag.TransactTime)
+	if err != nil {
+		return Order{}, err
+	}
+
+	orderCreationTimeRaw, err := binancetag.GetString(msg, tagOrderCreationTime)
+	if err != nil {
+		return Order{}, err
+	}
+
+	workingTimeRaw, err := binancetag.GetString(msg, tagWorkingTime)
+	if err != nil {
+		return Order{}, err
+	}
+
+	order := Order{
 		Symbol:            symbol,
 		OrderID:           orderID,
 		ClientOrderID:     clientOrderID,
+		OrigClientOrderID: origClientOrderID,
+		ListID:            listID,
 		Price:             price,
 		OrderQty:          orderQty,
+		CashOrderQty:      cashOrderQty,
 		CumQty:            cumQty,
 		CumQuoteQty:       cumQuoteQty,
 		Status:            status,
+		ExecType:          lookupEnum(mappedExecType, enum.ExecType(execType), ExecTypeUnknown),
+		ExecID:            execID,
+		LastPx:            lastPx,
+		LastQty:           lastQty,
+		TradeID:           tradeID,
+		Aggressor:         aggressor,
 		TimeInForce:       timeInForce,
 		Type:              orderType,
 		Side:              side,
 		IcebergQuantity:   maxFloor,
+		TriggerPrice:      triggerPrice,
+		TrailingDeltaBps:  trailingDelta,
+		TrailingTime:      trailingTime,
+		PostOnly:          enum.ExecInst(execInst) == enum.ExecInst_PARTICIPANT_DONT_INITIATE,
 		TransactTime:      transactTime,
 		OrderCreationTime: orderCreationTime,
 		WorkingTime:       workingTime,
-	}, nil
+
+		WorkingFloor: lookupEnum(mappedWorkingFloor, workingFloor, WorkingFloorUnknown),
+		UsedSOR:      usedSOR,
+
+		SelfTradePreventionMode: SelfTradePreventionMode(stpMode),
+		PreventedQty:            preventedQty,
+		LastPreventedQty:        lastPreventedQty,
+		PreventedMatch:          preventedMatch,
+
+		Exact:       exact,
+		ExtraFields: extraFields(msg, executionReportTags),
+
+		TransactTimeRaw:      transactTimeRaw,
+		OrderCreationTimeRaw: orderCreationTimeRaw,
+		WorkingTimeRaw:       workingTimeRaw,
+
+		ReceivedAt: msg.ReceiveTime,
+
+		PossDup: isPossDup(msg),
+	}
+	order.UnknownValues = unknownValues(msg, order)
+	return order, rejErr
 }
 
 func getText(msg *quickfix.Message) (v string, err error) {
@@ -283,12 +567,14 @@ func getSymbol(msg *quickfix.Message) (v string, err error) {
 	return
 }
 
+// getOrderID returns 0 if OrderID<37> is absent, as on some rejects.
 func getOrderID(msg *quickfix.Message) (v int64, err error) {
 	var f field.OrderIDField
-	if msg.Body.Has(f.Tag()) {
-		if err = msg.Body.Get(&f); err != nil {
-			return
-		}
+	if !msg.Body.Has(f.Tag()) {
+		return
+	}
+	if err = msg.Body.Get(&f); err != nil {
+		return
 	}
 
 	return strconv.ParseInt(f.Value(), 10, 64)
@@ -307,7 +593,7 @@ func getClientOrderID(msg *quickfix.Message) (v string, err error) {
 func getOrderStatus(msg *quickfix.Message) (v OrderStatus, err error) {
 	var f field.OrdStatusField
 	if err = msg.Body.Get(&f); err == nil {
-		v = mappedOrderStatus[f.Value()]
+		v = lookupEnum(mappedOrderStatus, f.Value(), OrderStatusUnknown)
 	}
 	return
 }
@@ -315,7 +601,7 @@ func getOrderStatus(msg *quickfix.Message) (v OrderStatus, err error) {
 func getOrdType(msg *quickfix.Message) (v OrderType, err error) {
 	var f field.OrdTypeField
 	if err = msg.Body.Get(&f); err == nil {
-		v = mappedOrderType[f.Value()]
+		v = lookupEnum(mappedOrderType, f.Value(), OrderTypeUnknown)
 	}
 	return
 }
@@ -323,7 +609,7 @@ func getOrdType(msg *quickfix.Message) (v OrderType, err error) {
 func getSide(msg *quickfix.Message) (v SideType, err error) {
 	var f field.SideField
 	if err = msg.Body.Get(&f); err == nil {
-		v = mappedSideType[f.Value()]
+		v = lookupEnum(mappedSideType, f.Value(), SideTypeUnknown)
 	}
 	return
 }
@@ -332,7 +618,7 @@ func getTimeInForce(msg *quickfix.Message) (v TimeInForce, err error) {
 	var f field.TimeInForceField
 	if msg.Body.Has(f.Tag()) {
 		if err = msg.Body.Get(&f); err == nil {
-			v = mappedTimeInForce[f.Value()]
+			v = lookupEnum(mappedTimeInForce, f.Value(), TimeInForceUnknown)
 		}
 	}
 	return
@@ -372,16 +658,38 @@ func getCumQty(msg *quickfix.Message) (float64, error) {
 }
 
 func getCumQuoteQty(msg *quickfix.Message) (float64, error) {
-	if msg.Body.Has(tagCumQuoteQty) {
-		str, err := msg.Body.GetString(tagCumQuoteQty)
-		if err != nil {
+	return binancetag.GetCumQuoteQty(msg)
+}
+
+func getCashOrderQty(msg *quickfix.Message) (float64, error) {
+	var f field.CashOrderQtyField
+	if msg.Body.Has(f.Tag()) {
+		if err := msg.Body.Get(&f); err != nil {
 			return 0, err
 		}
-		return strconv.ParseFloat(str, 64)
+		return f.InexactFloat64(), nil
 	}
 	return 0, nil
 }
 
+func getTradeID(msg *quickfix.Message) (int64, error) {
+	return binancetag.GetInt64(msg, tag.TradeID)
+}
+
+func getAggressor(msg *quickfix.Message) (bool, error) {
+	if !msg.Body.Has(tag.AggressorIndicator) {
+		return false, nil
+	}
+	return msg.Body.GetBool(tag.AggressorIndicator)
+}
+
+func getUsedSOR(msg *quickfix.Message) (bool, error) {
+	if !msg.Body.Has(binancetag.SOR) {
+		return false, nil
+	}
+	return msg.Body.GetBool(binancetag.SOR)
+}
+
 func getMaxFloor(msg *quickfix.Message) (float64, error) {
 	var f field.MaxFloorField
 	if msg.Body.Has(f.Tag()) {
@@ -393,34 +701,18 @@ func getMaxFloor(msg *quickfix.Message) (float64, error) {
 	return 0, nil
 }
 
-func getTransactTime(msg *quickfix.Message) (v time.Time, err error) {
-	var f field.TransactTimeField
-	if msg.Body.Has(f.Tag()) {
-		if err = msg.Body.Get(&f); err == nil {
-			v = f.Value()
-		}
-	}
-	return
+func getTransactTime(msg *quickfix.Message) (time.Time, error) {
+	return getUTCTimestamp(msg, tag.TransactTime)
 }
 
 func getOrderCreationTime(msg *quickfix.Message) (time.Time, error) {
-	if msg.Body.Has(tagOrderCreationTime) {
-		str, err := msg.Body.GetString(tagOrderCreationTime)
-		if err != nil {
-			return time.Time{}, err
-		}
-		return time.Parse(utcTimestampMicrosFmt, str)
-	}
-	return time.Time{}, nil
+	return binancetag.GetOrderCreationTime(msg)
 }
 
 func getWorkingTime(msg *quickfix.Message) (time.Time, error) {
-	if msg.Body.Has(tagWorkingTime) {
-		str, err := msg.Body.GetString(tagWorkingTime)
-		if err != nil {
-			return time.Time{}, err
-		}
-		return time.Parse(utcTimestampMicrosFmt, str)
-	}
-	return time.Time{}, nil
+	return binancetag.GetWorkingTime(msg)
+}
+
+func getUTCTimestamp(msg *quickfix.Message, t quickfix.Tag) (time.Time, error) {
+	return binancetag.GetUTCTimestamp(msg, t)
 }
@@ -1,8 +1,15 @@
 package fix
 
 import (
+	"context"
+	"os"
 	"testing"
+	"testing/fstest"
+	"time"
 
+	"github.com/quickfixgo/enum"
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/tag"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -20,3 +27,68 @@ func TestLogonRawData(t *testing.T) {
 		GetLogonRawData(privateKey, "EXAMPLE", "SPOT", "20240627-11:17:25.223"),
 	)
 }
+
+func TestFormatUTCTimestamp(t *testing.T) {
+	ts := time.Date(2024, 6, 27, 11, 17, 25, 223456789, time.UTC)
+
+	assert.Equal(t, "20240627-11:17:25", formatUTCTimestamp(ts, quickfix.Seconds))
+	assert.Equal(t, "20240627-11:17:25.223", formatUTCTimestamp(ts, quickfix.Millis))
+	assert.Equal(t, "20240627-11:17:25.223456", formatUTCTimestamp(ts, quickfix.Micros))
+	assert.Equal(t, "20240627-11:17:25.223456789", formatUTCTimestamp(ts, quickfix.Nanos))
+}
+
+func TestServicesSetField(t *testing.T) {
+	const custom quickfix.Tag = 29999
+
+	g := newTestGateway(t)
+	received := make(chan *quickfix.Message, 8)
+	// The gateway never answers.
+	g.handle(func(msg *quickfix.Message, _ quickfix.SessionID) { received <- msg })
+	c := g.startClient(t, WithCallTimeout(100*time.Millisecond))
+	ctx := context.Background()
+
+	_, _ = c.NewOrderSingleService().Symbol("BTCUSDT").Side(enum.Side_BUY).Type(enum.OrdType_MARKET).Quantity(1).
+		SetField(custom, "order").Do(ctx)
+	_, _ = c.NewGetLimitService().
+		SetField(custom, "limit").Do(ctx)
+	_, _ = c.NewOrderCancelService().Symbol("BTCUSDT").OrigClientOrderID("a").
+		SetField(custom, "cancel").Do(ctx)
+	_, _ = c.NewMassCancelService().Symbol("BTCUSDT").
+		SetField(custom, "mass-cancel").Do(ctx)
+	_, _ = c.NewAmendOrderService().Symbol("BTCUSDT").OrigClientOrderID("a").Quantity(1).
+		SetField(custom, "amend").Do(ctx)
+	_, _ = c.NewOrderListService().Symbol("BTCUSDT").
+		LimitMakerLeg(enum.Side_SELL, 1, 110).StopLeg(enum.Side_SELL, 1, 90, 0).
+		SetField(custom, "list").Do(ctx)
+	_, _ = c.NewCancelReplaceService().OrigClientOrderID("a").
+		NewOrder(c.NewOrderSingleService().Symbol("BTCUSDT").Side(enum.Side_BUY).Type(enum.OrdType_MARKET).Quantity(1)).
+		SetField(custom, "cancel-replace").Do(ctx)
+
+	for _, want := range []string{"order", "limit", "cancel", "mass-cancel", "amend", "list", "cancel-replace"} {
+		value, err := (<-received).Body.GetString(custom)
+		require.NoError(t, err)
+		assert.Equal(t, want, value)
+	}
+
+	// A custom field overrides the one set by the builder.
+	_, _ = c.NewOrderSingleService().Symbol("BTCUSDT").Side(enum.Side_BUY).Type(enum.OrdType_MARKET).Quantity(1).
+		SetField(tag.Symbol, "ETHUSDT").Do(ctx)
+	symbol, err := (<-received).Body.GetString(tag.Symbol)
+	require.NoError(t, err)
+	assert.Equal(t, "ETHUSDT", symbol)
+}
+
+func TestLoadQuickfixSettingsFS(t *testing.T) {
+	data, err := os.ReadFile("./sample/fix.conf")
+	require.NoError(t, err)
+
+	fromFile, err := LoadQuickfixSettings("./sample/fix.conf")
+	require.NoError(t, err)
+	fromBytes, err := ParseQuickfixSettings(data)
+	require.NoError(t, err)
+	fromFS, err := LoadQuickfixSettingsFS(fstest.MapFS{"fix.conf": {Data: data}}, "fix.conf")
+	require.NoError(t, err)
+
+	assert.Equal(t, fromFile, fromBytes)
+	assert.Equal(t, fromFile, fromFS)
+}
@@ -0,0 +1,165 @@
+package fix
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/quickfix/datadictionary"
+	"github.com/quickfixgo/tag"
+)
+
+const msgTypeSessionReject = "3"
+
+// ValidationError describes a message which does not conform to the data
+// dictionary. It is returned before sending when the client is configured with
+// a data dictionary, and when the server answers a request with Reject<3>.
+type ValidationError struct {
+	MsgType      string
+	Tag          quickfix.Tag // Offending tag, 0 if unknown.
+	RejectReason int          // SessionRejectReason<373> value.
+	Text         string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("invalid message %s: tag %d: %s", e.MsgType, e.Tag, e.Text)
+}
+
+func newValidationError(msgType string, rej quickfix.MessageRejectError) *ValidationError {
+	e := &ValidationError{
+		MsgType:      msgType,
+		RejectReason: rej.RejectReason(),
+		Text:         rej.Error(),
+	}
+	if refTag := rej.RefTagID(); refTag != nil {
+		e.Tag = *refTag
+	}
+	return e
+}
+
+// messageValidator checks outgoing message bodies against a data dictionary.
+type messageValidator struct {
+	dd *datadictionary.DataDictionary
+}
+
+func newMessageValidator(path string) (*messageValidator, error) {
+	dd, err := datadictionary.Parse(path)
+	if err != nil {
+		return nil, err
+	}
+	return &messageValidator{dd: dd}, nil
+}
+
+func (v *messageValidator) validate(msg *quickfix.Message) error {
+	msgType, err := msg.MsgType()
+	if err != nil {
+		return err
+	}
+
+	def, ok := v.dd.Messages[msgType]
+	if !ok {
+		return newValidationError(msgType, quickfix.InvalidMessageType())
+	}
+
+	for required := range def.RequiredTags {
+		if !msg.Body.Has(quickfix.Tag(required)) {
+			return newValidationError(msgType, quickfix.RequiredTagMissing(quickfix.Tag(required)))
+		}
+	}
+
+	for _, t := range msg.Body.Tags() {
+		if _, ok := def.Tags[int(t)]; !ok {
+			return newValidationError(msgType, quickfix.TagNotDefinedForThisMessageType(t))
+		}
+
+		fieldType, ok := v.dd.FieldTypeByTag[int(t)]
+		if !ok {
+			return newValidationError(msgType, quickfix.InvalidTagNumber(t))
+		}
+
+		value, err := msg.Body.GetString(t)
+		if err != nil {
+			return newValidationError(msgType, quickfix.IncorrectDataFormatForValue(t))
+		}
+
+		if rej := validateFieldValue(t, fieldType, value); rej != nil {
+			return newValidationError(msgType, rej)
+		}
+	}
+
+	return nil
+}
+
+func validateFieldValue(t quickfix.Tag, fieldType *datadictionary.FieldType, value string) quickfix.MessageRejectError {
+	if value == "" {
+		return quickfix.TagSpecifiedWithoutAValue(t)
+	}
+
+	var err error
+	switch fieldType.Type {
+	case "INT", "LENGTH", "SEQNUM", "NUMINGROUP", "TAGNUM", "DAYOFMONTH":
+		_, err = strconv.Atoi(value)
+	case "FLOAT", "QTY", "PRICE", "PRICEOFFSET", "AMT", "PERCENTAGE":
+		_, err = strconv.ParseFloat(value, 64)
+	case "BOOLEAN":
+		if value != "Y" && value != "N" {
+			return quickfix.IncorrectDataFormatForValue(t)
+		}
+	case "CHAR":
+		if len(value) != 1 {
+			return quickfix.IncorrectDataFormatForValue(t)
+		}
+	}
+	if err != nil {
+		return quickfix.IncorrectDataFormatForValue(t)
+	}
+
+	switch fieldType.Type {
+	case "MULTIPLEVALUESTRING", "MULTIPLESTRINGVALUE", "MULTIPLECHARVALUE":
+		return nil
+	}
+	if len(fieldType.Enums) > 0 {
+		if _, ok := fieldType.Enums[value]; !ok {
+			return quickfix.ValueIsIncorrect(t)
+		}
+	}
+
+	return nil
+}
+
+// decodeSessionReject converts a Reject<3> message into a ValidationError,
+// returning the sequence number of the rejected message as well.
+func decodeSessionReject(msg *quickfix.Message) (*ValidationError, int, error) {
+	var (
+		e   ValidationError
+		err error
+	)
+
+	refSeqNum, err := msg.Body.GetInt(tag.RefSeqNum)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if msg.Body.Has(tag.RefMsgType) {
+		if e.MsgType, err = msg.Body.GetString(tag.RefMsgType); err != nil {
+			return nil, 0, err
+		}
+	}
+	if msg.Body.Has(tag.RefTagID) {
+		refTagID, err := msg.Body.GetInt(tag.RefTagID)
+		if err != nil {
+			return nil, 0, err
+		}
+		e.Tag = quickfix.Tag(refTagID)
+	}
+	if msg.Body.Has(tag.SessionRejectReason) {
+		if e.RejectReason, err = msg.Body.GetInt(tag.SessionRejectReason); err != nil {
+			return nil, 0, err
+		}
+	}
+	if e.Text, err = getText(msg); err != nil {
+		return nil, 0, err
+	}
+
+	return &e, refSeqNum, nil
+}
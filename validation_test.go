@@ -0,0 +1,43 @@
+package fix
+
+import (
+	"testing"
+
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/quickfix/datadictionary"
+	"github.com/quickfixgo/tag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateFieldValue(t *testing.T) {
+	qty := datadictionary.NewFieldType("OrderQty", 38, "QTY")
+	assert.Nil(t, validateFieldValue(tag.OrderQty, qty, "0.01"))
+	assert.NotNil(t, validateFieldValue(tag.OrderQty, qty, "abc"))
+	assert.NotNil(t, validateFieldValue(tag.OrderQty, qty, ""))
+
+	side := datadictionary.NewFieldType("Side", 54, "CHAR")
+	side.Enums = map[string]datadictionary.Enum{"1": {Value: "1"}, "2": {Value: "2"}}
+	assert.Nil(t, validateFieldValue(tag.Side, side, "2"))
+	assert.NotNil(t, validateFieldValue(tag.Side, side, "7"))
+	assert.NotNil(t, validateFieldValue(tag.Side, side, "12"))
+}
+
+func TestDecodeSessionReject(t *testing.T) {
+	msg := quickfix.NewMessage()
+	msg.Body.SetInt(tag.RefSeqNum, 7)
+	msg.Body.SetString(tag.RefMsgType, "D")
+	msg.Body.SetInt(tag.RefTagID, 44)
+	msg.Body.SetInt(tag.SessionRejectReason, 5)
+	msg.Body.SetString(tag.Text, "Invalid price")
+
+	rejErr, refSeqNum, err := decodeSessionReject(msg)
+	require.NoError(t, err)
+	assert.Equal(t, 7, refSeqNum)
+	assert.Equal(t, &ValidationError{
+		MsgType:      "D",
+		Tag:          tag.Price,
+		RejectReason: 5,
+		Text:         "Invalid price",
+	}, rejErr)
+}
@@ -0,0 +1,208 @@
+package fix
+
+import (
+	"context"
+	"reflect"
+	"sync"
+)
+
+// orderWatchBuffer is the number of updates queued per watch before the
+// oldest non-terminal ones are dropped. Terminal updates are never dropped.
+const orderWatchBuffer = 64
+
+type orderWatch struct {
+	clOrdID string
+	limit   int           // Queued updates beyond which some are dropped, 0 for none.
+	ready   chan struct{} // Signaled when an update is queued.
+
+	mu      sync.Mutex
+	updates []Order
+}
+
+// push queues o and reports whether an older update had to be dropped.
+func (w *orderWatch) push(o Order) (dropped bool) {
+	w.mu.Lock()
+	if w.limit > 0 && len(w.updates) >= w.limit {
+		for i, u := range w.updates {
+			if !u.Status.IsTerminal() {
+				w.updates = append(w.updates[:i], w.updates[i+1:]...)
+				dropped = true
+				break
+			}
+		}
+	}
+	w.updates = append(w.updates, o)
+	w.mu.Unlock()
+
+	select {
+	case w.ready <- struct{}{}:
+	default:
+	}
+	return dropped
+}
+
+// poll returns the oldest queued update, if any.
+func (w *orderWatch) poll() (Order, bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if len(w.updates) == 0 {
+		return Order{}, false
+	}
+	o := w.updates[0]
+	w.updates = w.updates[1:]
+	return o, true
+}
+
+// skipThrough drops the queued updates up to the last one equal to o, which
+// were applied before o was read from the tracker.
+func (w *orderWatch) skipThrough(o Order) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for i := len(w.updates) - 1; i >= 0; i-- {
+		if reflect.DeepEqual(w.updates[i], o) {
+			w.updates = w.updates[i+1:]
+			return
+		}
+	}
+}
+
+// next returns the oldest queued update, waiting for one until ctx is done.
+func (w *orderWatch) next(ctx context.Context) (Order, error) {
+	for {
+		if o, ok := w.poll(); ok {
+			return o, nil
+		}
+		select {
+		case <-w.ready:
+		case <-ctx.Done():
+			return Order{}, ctx.Err()
+		}
+	}
+}
+
+// orderWatchers dispatches execution reports to the watches registered for
+// their client order ID.
+type orderWatchers struct {
+	mu   sync.Mutex
+	byID map[string]map[*orderWatch]struct{}
+}
+
+func (ws *orderWatchers) add(clOrdID string) *orderWatch {
+	return ws.addWatch(clOrdID, orderWatchBuffer)
+}
+
+func (ws *orderWatchers) addWatch(clOrdID string, limit int) *orderWatch {
+	w := &orderWatch{clOrdID: clOrdID, limit: limit, ready: make(chan struct{}, 1)}
+
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+
+	if ws.byID == nil {
+		ws.byID = make(map[string]map[*orderWatch]struct{})
+	}
+	if ws.byID[clOrdID] == nil {
+		ws.byID[clOrdID] = make(map[*orderWatch]struct{})
+	}
+	ws.byID[clOrdID][w] = struct{}{}
+	return w
+}
+
+func (ws *orderWatchers) remove(w *orderWatch) {
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+
+	delete(ws.byID[w.clOrdID], w)
+	if len(ws.byID[w.clOrdID]) == 0 {
+		delete(ws.byID, w.clOrdID)
+	}
+}
+
+// notify reports whether some non-terminal updates had to be dropped because
+// a watch was not consumed fast enough.
+func (ws *orderWatchers) notify(o Order) (dropped bool) {
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+
+	ids := []string{o.ClientOrderID}
+	if o.OrigClientOrderID != "" && o.OrigClientOrderID != o.ClientOrderID {
+		ids = append(ids, o.OrigClientOrderID)
+	}
+	for _, id := range ids {
+		for w := range ws.byID[id] {
+			if w.push(o) {
+				dropped = true
+			}
+		}
+	}
+	return dropped
+}
+
+// WaitForOrder blocks until the order with the given client order ID reaches a
+// terminal state (FILLED, CANCELED, REJECTED or EXPIRED) and returns it.
+func (c *Client) WaitForOrder(ctx context.Context, clOrdID string) (Order, error) {
+	// Watch before looking at the tracker so no update can be missed.
+	w := c.watchers.add(clOrdID)
+	defer c.watchers.remove(w)
+
+	if o, ok := c.tracker.Order(clOrdID); ok && o.Status.IsTerminal() {
+		return o, nil
+	}
+
+	for {
+		o, err := w.next(ctx)
+		if err != nil {
+			return Order{}, err
+		}
+		if o.Status.IsTerminal() {
+			return o, nil
+		}
+	}
+}
+
+// OrderWatcher returns a channel delivering the latest known state of the
+// order with the given client order ID, if any, then every subsequent
+// execution report of it. The channel is closed after the order reaches a
+// terminal state or when ctx is done, whichever happens first. No update is
+// dropped, however slowly the channel is drained.
+func (c *Client) OrderWatcher(ctx context.Context, clOrdID string) <-chan Order {
+	// Watch before looking at the tracker so no update can be missed.
+	w := c.watchers.addWatch(clOrdID, 0)
+	seed, seeded := c.tracker.Order(clOrdID)
+	out := make(chan Order)
+
+	go func() {
+		defer close(out)
+		defer c.watchers.remove(w)
+
+		if seeded {
+			select {
+			case out <- seed:
+			case <-ctx.Done():
+				return
+			}
+			if seed.Status.IsTerminal() {
+				return
+			}
+			w.skipThrough(seed)
+		}
+
+		for {
+			o, err := w.next(ctx)
+			if err != nil {
+				return
+			}
+			select {
+			case out <- o:
+			case <-ctx.Done():
+				return
+			}
+			if o.Status.IsTerminal() {
+				return
+			}
+		}
+	}()
+
+	return out
+}
@@ -0,0 +1,114 @@
+package fix
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrderWatchKeepsTerminalUpdates(t *testing.T) {
+	var ws orderWatchers
+	w := ws.add("a")
+	defer ws.remove(w)
+
+	for i := 0; i < orderWatchBuffer; i++ {
+		assert.False(t, ws.notify(Order{ClientOrderID: "a", Status: OrderStatusPartiallyFilled}))
+	}
+	assert.True(t, ws.notify(Order{ClientOrderID: "a", Status: OrderStatusFilled}))
+
+	var last Order
+	for {
+		o, ok := w.poll()
+		if !ok {
+			break
+		}
+		last = o
+	}
+	assert.Equal(t, OrderStatusFilled, last.Status)
+}
+
+func TestWaitForOrderAfterFlood(t *testing.T) {
+	c := NewWithCaller(nil)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	result := make(chan Order, 1)
+	go func() {
+		o, err := c.WaitForOrder(ctx, "a")
+		assert.NoError(t, err)
+		result <- o
+	}()
+	require.Eventually(t, func() bool {
+		c.watchers.mu.Lock()
+		defer c.watchers.mu.Unlock()
+		return len(c.watchers.byID["a"]) == 1
+	}, time.Second, time.Millisecond)
+
+	for i := 0; i < 2*orderWatchBuffer; i++ {
+		c.deliverOrder(Order{ClientOrderID: "a", Status: OrderStatusPartiallyFilled})
+	}
+	c.deliverOrder(Order{ClientOrderID: "a", Status: OrderStatusFilled})
+	assert.Equal(t, OrderStatusFilled, (<-result).Status)
+}
+
+func drain(t *testing.T, ch <-chan Order) []Order {
+	t.Helper()
+	var orders []Order
+	timeout := time.After(time.Second)
+	for {
+		select {
+		case o, ok := <-ch:
+			if !ok {
+				return orders
+			}
+			orders = append(orders, o)
+		case <-timeout:
+			t.Fatal("watcher not closed")
+		}
+	}
+}
+
+func TestOrderWatcherSeedsFromTracker(t *testing.T) {
+	c := NewWithCaller(nil)
+	c.deliverOrder(Order{ClientOrderID: "done", Status: OrderStatusFilled})
+	orders := drain(t, c.OrderWatcher(context.Background(), "done"))
+	require.Len(t, orders, 1)
+	assert.Equal(t, OrderStatusFilled, orders[0].Status)
+
+	c.deliverOrder(Order{ClientOrderID: "a", Status: OrderStatusNew})
+	ch := c.OrderWatcher(context.Background(), "a")
+	c.deliverOrder(Order{ClientOrderID: "a", Status: OrderStatusCanceled})
+	orders = drain(t, ch)
+	require.Len(t, orders, 2)
+	assert.Equal(t, OrderStatusNew, orders[0].Status)
+	assert.Equal(t, OrderStatusCanceled, orders[1].Status)
+}
+
+func TestOrderWatcherDropsNothing(t *testing.T) {
+	c := NewWithCaller(nil)
+	ch := c.OrderWatcher(context.Background(), "a")
+
+	n := 2 * orderWatchBuffer
+	for i := 0; i < n; i++ {
+		c.deliverOrder(Order{ClientOrderID: "a", Status: OrderStatusPartiallyFilled, CumQty: float64(i)})
+	}
+	c.deliverOrder(Order{ClientOrderID: "a", Status: OrderStatusFilled})
+
+	orders := drain(t, ch)
+	require.Len(t, orders, n+1)
+	for i := 0; i < n; i++ {
+		assert.Equal(t, float64(i), orders[i].CumQty)
+	}
+	assert.Equal(t, OrderStatusFilled, orders[n].Status)
+}
+
+func TestOrderWatcherClosesOnContext(t *testing.T) {
+	c := NewWithCaller(nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := c.OrderWatcher(ctx, "a")
+	cancel()
+	assert.Empty(t, drain(t, ch))
+}
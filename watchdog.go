@@ -0,0 +1,96 @@
+package fix
+
+import (
+	"time"
+
+	"github.com/quickfixgo/quickfix/config"
+)
+
+const (
+	defaultHeartbeatInterval = 30 * time.Second
+	defaultMissedHeartbeats  = 3
+)
+
+// WithHeartbeatWatchdogOpt declares the session stale once no message was
+// received for missedHeartbeats heartbeat intervals, 3 if not positive, and
+// reconnects it with the delays of the ReconnectPolicy, even if disabled.
+// quickfix already sends a TestRequest<1> after 1.2 intervals of silence, so
+// the watchdog catches sessions stuck beyond that.
+func WithHeartbeatWatchdogOpt(missedHeartbeats int) NewClientOption {
+	return func(o *Options) {
+		if missedHeartbeats <= 0 {
+			missedHeartbeats = defaultMissedHeartbeats
+		}
+		o.missedHeartbeats = missedHeartbeats
+	}
+}
+
+// LastActivityAt returns when the last message was received from the server,
+// zero if none was.
+func (c *Client) LastActivityAt() time.Time {
+	at := c.lastActivity.Load()
+	if at == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, at)
+}
+
+func (c *Client) recordActivity() {
+	c.lastActivity.Store(time.Now().UnixNano())
+}
+
+// heartbeatInterval returns the HeartBtInt of the session.
+func (c *Client) heartbeatInterval() time.Duration {
+	for _, session := range c.settings.SessionSettings() {
+		if secs, err := session.IntSetting(config.HeartBtInt); err == nil && secs > 0 {
+			return time.Duration(secs) * time.Second
+		}
+	}
+	return defaultHeartbeatInterval
+}
+
+// startWatchdog watches the session for traffic until stopWatchdog.
+func (c *Client) startWatchdog() {
+	if c.options.missedHeartbeats <= 0 {
+		return
+	}
+	interval := c.heartbeatInterval()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.watchdogStop != nil {
+		return
+	}
+	c.watchdogStop = make(chan struct{})
+	go c.runWatchdog(c.watchdogStop, interval, time.Duration(c.options.missedHeartbeats)*interval)
+}
+
+func (c *Client) stopWatchdog() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.watchdogStop != nil {
+		close(c.watchdogStop)
+		c.watchdogStop = nil
+	}
+}
+
+func (c *Client) runWatchdog(stop <-chan struct{}, interval, timeout time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+		case <-stop:
+			return
+		}
+
+		last := c.LastActivityAt()
+		if time.Since(last) < timeout || c.closing.Load() {
+			continue
+		}
+		c.l.Warnw("No message received, reconnecting stale session", "lastActivity", last, "timeout", timeout)
+		go c.reconnect(ErrSessionStale)
+		return
+	}
+}
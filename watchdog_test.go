@@ -0,0 +1,52 @@
+package fix
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/quickfixgo/quickfix"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestHeartbeatInterval(t *testing.T) {
+	settings, err := quickfix.ParseSettings(strings.NewReader(`
+[DEFAULT]
+BeginString=FIX.4.4
+SenderCompID=EXAMPLE
+TargetCompID=SPOT
+
+[SESSION]
+HeartBtInt=5
+`))
+	require.NoError(t, err)
+
+	c := &Client{settings: settings}
+	assert.Equal(t, 5*time.Second, c.heartbeatInterval())
+	c.settings = quickfix.NewSettings()
+	assert.Equal(t, defaultHeartbeatInterval, c.heartbeatInterval())
+}
+
+func TestWatchdogDetectsStaleSession(t *testing.T) {
+	c := &Client{l: zap.NewNop().Sugar()}
+	assert.True(t, c.LastActivityAt().IsZero())
+	c.recordActivity()
+	assert.WithinDuration(t, time.Now(), c.LastActivityAt(), time.Second)
+
+	// Keep the triggered reconnect from running.
+	c.reconnecting.Store(true)
+	done := make(chan struct{})
+	go func() {
+		c.runWatchdog(make(chan struct{}), time.Millisecond, 20*time.Millisecond)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		assert.GreaterOrEqual(t, time.Since(c.LastActivityAt()), 20*time.Millisecond)
+	case <-time.After(time.Second):
+		t.Fatal("stale session not detected")
+	}
+}
@@ -0,0 +1,46 @@
+package fix
+
+import (
+	"time"
+
+	"github.com/quickfixgo/quickfix"
+)
+
+// WireTap receives the raw bytes of every inbound message together with the
+// time they were read, which carries a monotonic clock reading usable with
+// time.Since. data is a copy owned by the tap.
+type WireTap func(data []byte, receivedAt time.Time)
+
+// wireTapLogFactory wraps a quickfix LogFactory so the logs it creates also
+// feed a WireTap.
+type wireTapLogFactory struct {
+	quickfix.LogFactory
+	tap WireTap
+}
+
+func (f wireTapLogFactory) Create() (quickfix.Log, error) {
+	l, err := f.LogFactory.Create()
+	if err != nil {
+		return nil, err
+	}
+	return wireTapLog{Log: l, tap: f.tap}, nil
+}
+
+func (f wireTapLogFactory) CreateSessionLog(sessionID quickfix.SessionID) (quickfix.Log, error) {
+	l, err := f.LogFactory.CreateSessionLog(sessionID)
+	if err != nil {
+		return nil, err
+	}
+	return wireTapLog{Log: l, tap: f.tap}, nil
+}
+
+type wireTapLog struct {
+	quickfix.Log
+	tap WireTap
+}
+
+func (l wireTapLog) OnIncoming(data []byte) {
+	receivedAt := time.Now()
+	l.tap(append([]byte(nil), data...), receivedAt)
+	l.Log.OnIncoming(data)
+}
@@ -0,0 +1,37 @@
+package fix
+
+import (
+	"bytes"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWireTap(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		inbound  [][]byte
+		received []time.Time
+	)
+	g := newTestGateway(t)
+	start := time.Now()
+	c := g.startClient(t, WithWireTap(func(data []byte, receivedAt time.Time) {
+		mu.Lock()
+		defer mu.Unlock()
+		inbound = append(inbound, data)
+		received = append(received, receivedAt)
+	}))
+	require.True(t, c.IsConnected())
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.NotEmpty(t, inbound)
+	// The logon answer of the gateway is the first inbound message.
+	assert.True(t, bytes.Contains(inbound[0], []byte("\x0135=A\x01")))
+	assert.False(t, received[0].Before(start))
+	// The receive time carries a monotonic clock reading.
+	assert.NotEqual(t, received[0].Round(0), received[0])
+}